@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
 	"employee-management/internal/config"
 	"employee-management/internal/database"
 	"employee-management/internal/handlers"
+	"employee-management/internal/middleware"
+	"employee-management/internal/models"
+	"employee-management/internal/pii"
 	"employee-management/internal/services"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,6 +23,22 @@ import (
 func main() {
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.CheckProductionSafety(); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
+	if err := services.CheckExportConfig(&cfg.Export); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
+
+	pii.SetLogRedaction(cfg.Server.RedactPIILogs)
+	if !cfg.Server.RedactPIILogs && cfg.Server.Mode == gin.ReleaseMode {
+		log.Println("Warning: REDACT_PII_LOGS is off; emails and phone numbers are logged in full")
+	}
+
+	// Encryption at rest must be set up before any row is read or written
+	if err := database.ConfigurePII(&cfg.Database); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
 
 	// Initialize database
 	db, err := database.NewDatabase(&cfg.Database)
@@ -26,45 +51,129 @@ func main() {
 	if err := db.AutoMigrate(); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
+	if err := db.SyncStoredPII(); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if err := db.SyncUniquePhoneIndex(cfg.Database.UniquePhone); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
 
-	// Initialize Redis
-	cache, err := database.NewRedisClient(&cfg.Redis)
+	// Initialize cache (Redis, falling back to in-memory when unreachable)
+	cache, err := database.NewCache(&cfg.Redis)
 	if err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
+		log.Fatalf("Failed to initialize cache: %v", err)
 	}
 	defer cache.Close()
 
 	// Initialize services
 	employeeRepo := database.NewEmployeeRepository(db)
-	employeeService := services.NewEmployeeService(employeeRepo, cache)
+	if marker, ok := cache.(database.WriteMarker); ok {
+		// Writes of every instance keep reads off the lagging replica
+		employeeRepo.ShareWrites(marker)
+	}
+	employeeService := services.NewEmployeeService(employeeRepo, cache, cfg)
 	excelService := services.NewExcelService(employeeService, cfg)
-	employeeHandler := handlers.NewEmployeeHandler(employeeService, excelService)
+	employeeHandler := handlers.NewEmployeeHandler(employeeService, excelService, cfg)
+
+	// Readiness covers every dependency; check it once so problems show at startup
+	readiness := services.NewReadiness(&cfg.Readiness)
+	readiness.AddCheck(services.DependencyDatabase, db.Ready)
+	readiness.AddCheck(services.DependencyCache, func(context.Context) error { return cache.Health() })
+	if check := employeeService.EventBrokerCheck(); check != nil {
+		readiness.AddCheck(services.DependencyEvents, check)
+	}
+	employeeHandler.SetReadiness(readiness)
+	logReadiness(readiness.Check(context.Background()))
 
 	// Setup router
-	router := setupRoutes(employeeHandler)
+	router := setupRoutes(employeeHandler, cfg)
 
 	// Start server
-	log.Printf("🚀 Server starting on port %s", cfg.Server.Port)
-	log.Fatal(http.ListenAndServe(":"+cfg.Server.Port, router))
+	server := &http.Server{Addr: ":" + cfg.Server.Port, Handler: router}
+	go func() {
+		log.Printf("🚀 Server starting on port %s", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server failed: %v", err)
+		}
+	}()
+
+	// On SIGINT or SIGTERM stop taking requests, then stop the background work
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	log.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Warning: server did not shut down cleanly: %v", err)
+	}
+	excelService.Stop()
+}
+
+// shutdownTimeout is how long in-flight requests get to finish on shutdown
+const shutdownTimeout = 30 * time.Second
+
+// logReadiness logs the startup readiness evaluation. An unready service still
+// starts; the readiness probe keeps traffic away until dependencies recover.
+func logReadiness(report models.ReadinessReport) {
+	for name, status := range report.Dependencies {
+		if status.Status != models.DependencyUp {
+			log.Printf("Warning: dependency %s is %s (required: %t): %s", name, status.Status, status.Required, status.Error)
+		}
+	}
+	if report.Ready {
+		log.Println("✅ All required dependencies are ready")
+	} else {
+		log.Println("⚠️  Warning: required dependencies are unavailable; /api/ready answers 503")
+	}
 }
 
-// setupRoutes configures all API routes
-func setupRoutes(employeeHandler *handlers.EmployeeHandler) *gin.Engine {
-	router := gin.Default()
+// setupRoutes configures all API routes, mounted under the configured route
+// prefix so the service can sit behind a path-based ingress. Configured
+// route aliases are served by the canonical routes.
+func setupRoutes(employeeHandler *handlers.EmployeeHandler, cfg *config.Config) http.Handler {
+	models.SetNullableResponseFields(cfg.Server.NullableResponseFields)
+
+	router := gin.New()
+	router.Use(middleware.RequestID(cfg.Server.RequestIDHeader), middleware.Logger(), gin.Recovery())
 
 	// API routes
-	api := router.Group("/api")
+	api := router.Group(cfg.Server.RoutePrefix + "/api")
 	{
 		api.GET("/health", employeeHandler.HealthCheck)
+		api.GET("/ready", employeeHandler.ReadinessCheck)
+		api.GET("/health/ready", employeeHandler.DetailedHealthCheck)
+		api.GET("/cache/stats", employeeHandler.GetCacheStats)
 
-		employees := api.Group("/employees")
+		// Only the list and single-employee reads may be reused by the client;
+		// the list varies with the admin key because of explain=true
+		employees := api.Group("/employees", middleware.NoStore())
+		cacheable := middleware.CacheControl(cfg.Redis.CacheExpiry, middleware.AdminKeyHeader)
 		{
 			employees.POST("/upload", employeeHandler.UploadExcel)
+			employees.POST("/upload/init", employeeHandler.InitChunkedUpload)
+			employees.PUT("/upload/:upload_id/chunk/:n", employeeHandler.PutUploadChunk)
+			employees.POST("/upload/:upload_id/complete", employeeHandler.CompleteChunkedUpload)
 			employees.POST("/validate-excel", employeeHandler.ValidateExcel)
-			employees.GET("", employeeHandler.GetEmployees)
+			employees.POST("/annotate", employeeHandler.AnnotateExcel)
+			employees.GET("/jobs/:id/errors.xlsx", employeeHandler.DownloadJobErrors)
+			employees.GET("", cacheable, employeeHandler.GetEmployees)
+			employees.GET("/export", employeeHandler.ExportEmployees)
+			employees.GET("/stream", employeeHandler.StreamEmployees)
+			employees.GET("/geojson", employeeHandler.GetEmployeesGeoJSON)
+			employees.GET("/stats", employeeHandler.GetEmployeeStats)
+			employees.GET("/diff", employeeHandler.DiffEmployees)
+			employees.GET("/next", employeeHandler.GetNextEmployee)
+			employees.GET("/staging/:batch", employeeHandler.GetStagedEmployees)
+			employees.POST("/staging/:batch/promote", employeeHandler.PromoteStagingBatch)
+			employees.DELETE("/staging/:batch", employeeHandler.DiscardStagingBatch)
+			employees.POST("/bulk-delete", employeeHandler.BulkDeleteEmployees)
 			employees.POST("", employeeHandler.CreateEmployee)
-			employees.GET("/:id", employeeHandler.GetEmployee)
+			employees.GET("/:id", cacheable, employeeHandler.GetEmployee)
 			employees.PUT("/:id", employeeHandler.UpdateEmployee)
+			employees.PATCH("/:id", employeeHandler.PatchEmployee)
+			employees.POST("/:id/touch", employeeHandler.TouchEmployee)
 			employees.DELETE("/:id", employeeHandler.DeleteEmployee)
 		}
 
@@ -73,7 +182,18 @@ func setupRoutes(employeeHandler *handlers.EmployeeHandler) *gin.Engine {
 		{
 			jobs.GET("/:id", employeeHandler.GetJobStatus)
 		}
+
+		// Diagnostics, only reachable with ADMIN_API_KEY
+		admin := api.Group("/admin", middleware.AdminAuth(cfg.Server.AdminAPIKey))
+		{
+			admin.GET("/config", employeeHandler.GetConfig)
+			admin.GET("/cache/*key", employeeHandler.InspectCache)
+			admin.POST("/export", employeeHandler.StartBackup)
+		}
 	}
 
+	if aliases := middleware.ParseRouteAliases(cfg.Server.RouteAliases, cfg.Server.RoutePrefix); len(aliases) > 0 {
+		return middleware.RouteAliases(router, aliases)
+	}
 	return router
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/handlers"
+	"employee-management/internal/models"
+	"employee-management/internal/services"
+	"employee-management/internal/testutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetupRoutes_RoutePrefix(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Server: config.ServerConfig{RoutePrefix: "/employee-svc"}}
+	employeeService := services.NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), cfg)
+	router := setupRoutes(handlers.NewEmployeeHandler(employeeService, nil, cfg), cfg)
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{"/employee-svc/api/health", http.StatusOK},
+		{"/api/health", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.expected {
+				t.Errorf("GET %s: expected status %d, got %d", tt.path, tt.expected, w.Code)
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_RouteAliases(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Server: config.ServerConfig{
+		PageBase:     1,
+		RouteAliases: "/api/employee=/api/employees, /api/emp/list=/api/employees, /api/health=/api/employees",
+	}}
+	repo := testutil.NewFakeRepository()
+	repo.Seed(models.Employee{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com"})
+	employeeService := services.NewEmployeeService(repo, testutil.NewFakeCache(), cfg)
+	router := setupRoutes(handlers.NewEmployeeHandler(employeeService, nil, cfg), cfg)
+
+	tests := []struct {
+		path     string
+		contains string
+	}{
+		{"/api/employee/1", `"email":"john@example.com"`},
+		{"/api/emp/list?limit=5", `"total":1`},
+		{"/api/employees/1", `"email":"john@example.com"`},
+		{"/api/health", `"status"`}, // Overlaps a real route, so the alias is ignored
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("GET %s: expected 200 containing %s, got %d: %s", tt.path, tt.contains, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_CacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{PageBase: 1},
+		Redis:  config.RedisConfig{CacheExpiry: 5 * time.Minute},
+	}
+	repo := testutil.NewFakeRepository()
+	repo.Seed(models.Employee{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com"})
+	employeeService := services.NewEmployeeService(repo, testutil.NewFakeCache(), cfg)
+	router := setupRoutes(handlers.NewEmployeeHandler(employeeService, nil, cfg), cfg)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/employees", "private, max-age=300"},
+		{"/api/employees/1", "private, max-age=300"},
+		{"/api/employees/staging/batch-1", "no-store"},
+		{"/api/employees/stream", "no-store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if got := w.Header().Get("Cache-Control"); got != tt.expected {
+				t.Errorf("GET %s: expected Cache-Control %q, got %q (status %d)", tt.path, tt.expected, got, w.Code)
+			}
+		})
+	}
+}
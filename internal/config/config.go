@@ -3,8 +3,10 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,9 +14,14 @@ import (
 
 // Config holds all configuration for our application
 type Config struct {
-	Database DatabaseConfig
-	Redis    RedisConfig
-	Server   ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	Server     ServerConfig
+	Import     ImportConfig
+	Validation ValidationConfig
+	Export     ExportConfig
+	Events     EventsConfig
+	Readiness  ReadinessConfig
 }
 
 // DatabaseConfig holds database configuration
@@ -25,6 +32,32 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// StatementTimeout bounds how long a list or search query may run before
+	// the server aborts it (MAX_EXECUTION_TIME hint) and how long the driver
+	// waits on any single read or write. Cursor reads behind streams and
+	// exports are exempt from the execution budget.
+	StatementTimeout time.Duration
+
+	MaxOpenConns int // Size of the connection pool shared by API requests and imports
+
+	UniquePhone bool // Enforce globally unique non-empty phone numbers with a unique index
+
+	// ReplicaHost enables read/write splitting: read-only queries go to this
+	// MySQL replica (same user, password and database) and writes stay on
+	// Host. Reads within ReplicaLagWindow of a write go to the primary.
+	ReplicaHost      string
+	ReplicaPort      int
+	ReplicaLagWindow time.Duration
+
+	// SearchIndexHint names the indexes (comma-separated) searches are told
+	// to use with USE INDEX. Empty leaves the choice to the optimizer.
+	SearchIndexHint string
+
+	// PIIEncryptionKey (base64, 32 bytes) enables encryption at rest of the
+	// PIIEncryptedFields columns. Losing it makes those values unreadable.
+	PIIEncryptionKey   string
+	PIIEncryptedFields string
 }
 
 // RedisConfig holds Redis configuration
@@ -36,6 +69,18 @@ type RedisConfig struct {
 	MaxRetries  int
 	IdleTimeout time.Duration
 	CacheExpiry time.Duration // 5 minutes as per requirement
+	MaxCacheTTL time.Duration // Upper bound for the per-request cache_ttl override on list endpoints
+
+	// Backend selects the cache implementation: redis, memory or none.
+	// With redis, a failed connection at startup falls back to memory.
+	Backend     string
+	MaxEntries  int  // Capacity of the in-memory LRU cache
+	FailOnError bool // Surface cache write failures as errors instead of logging them
+	Compress    bool // Gzip JSON payloads stored in Redis
+
+	// SingleFlight makes concurrent cache misses on the same key share one
+	// database read instead of each running it
+	SingleFlight bool
 }
 
 // ServerConfig holds server configuration
@@ -44,8 +89,101 @@ type ServerConfig struct {
 	Mode         string // debug, release, test
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
-	MaxFileSize  int64 // Maximum upload file size in bytes
-	MaxWorkers   int   // Maximum concurrent Excel processing workers
+	MaxFileSize  int64  // Maximum upload file size in bytes
+	MaxWorkers   int    // Maximum concurrent Excel processing workers
+	PageBase     int    // Number of the first page in list endpoints (0 or 1)
+	MaxPage      int    // Highest page number list endpoints accept; 0 uses the default
+	RoutePrefix  string // Path prefix all routes are mounted under, e.g. "/employee-svc"
+	EmptySearch  string // Meaning of a present but empty ?search=: "all" (same as absent) or "none" (no results)
+
+	SkipUnchangedUpdates bool // Updates that change no field skip the write and keep updated_at
+
+	NullableResponseFields bool // Blank optional fields in employee responses are null instead of ""
+
+	AdminAPIKey string // Key required in X-Admin-Key by /api/admin endpoints; empty disables them
+
+	RequestIDHeader string // Header carrying the correlation ID, echoed on every response
+
+	RouteAliases string // Deprecated paths served by canonical ones, e.g. "/api/employee=/api/employees"
+
+	StrictProd bool // In release mode, refuse to start with insecure settings instead of only warning
+
+	RedactPIILogs bool // Mask emails and phone numbers in log output; defaults to on in release mode
+}
+
+// ImportConfig holds Excel import configuration
+type ImportConfig struct {
+	MinValidRatio       float64 // Minimum fraction of valid rows required to import (0 disables the check)
+	MinValidRatioStrict bool    // Fail the import when below MinValidRatio instead of only warning
+	Charset             string  // Encoding of CSV imports: auto, utf-8, windows-1252 or iso-8859-1
+	BlankRequiredRows   string  // Rows with only whitespace in required fields: "error" (report once) or "skip"
+	StatusPolicy        string  // HTTP status for finished jobs: "multi-status" (200/207/400 by outcome) or "always-200"
+	DBConnFraction      float64 // Share of DB_MAX_OPEN_CONNS imports may hold at once (0 disables the cap)
+	MaxDuplicatesShown  int     // Duplicate emails listed in the import response and message
+	MaxValidationErrors int     // Detailed validation errors kept per import; further invalid rows are only counted
+	RequiredHeaders     string  // Comma-separated headers an import file must contain (empty uses the field definitions)
+	DuplicateHeaders    string  // A template column named twice: "error", "first-wins" or "last-wins"
+	SplitRules          string  // JSON array of rules deriving several fields from one column (see services.SplitRule)
+	Transforms          string  // Comma-separated built-in transforms applied to each imported row, e.g. "uppercase_postal,default_company"
+	DefaultCompany      string  // Company set by the default_company transform on rows without one
+
+	ChunkedUploadDir string        // Where chunked uploads are assembled (default: a directory under the system temp dir)
+	ChunkedUploadTTL time.Duration // Incomplete chunked uploads idle this long are discarded
+
+	JobTTL             time.Duration // Finished jobs and their invalid rows are kept this long (0 keeps them forever)
+	JobCleanupInterval time.Duration // How often finished jobs are expired in the background (0 only expires on new jobs)
+	MaxRetainedJobs    int           // Finished jobs kept at most, oldest evicted first (0 disables the cap)
+
+	MaxUploadsPerIP int // Imports one client IP may have queued or running at once (0 disables the limit)
+
+	MemoryLimitMB       int           // New uploads are refused with 503 while the process holds more memory than this (0 disables the guard)
+	MemoryCheckInterval time.Duration // How often memory use is sampled for MemoryLimitMB
+
+	MaxZipUncompressed int64 // Total bytes the Excel files in one ZIP import may expand to
+
+	AllowReplace bool // Accept replace=true imports, which swap every employee for the file's rows
+
+	StatusActions string // What each value of an import's status column does, e.g. "active=upsert,delete=delete"
+}
+
+// ValidationConfig holds optional validation applied to API writes
+type ValidationConfig struct {
+	VerifyEmailDomain string        // DNS check on create: off, warn (soft warning) or error (reject)
+	DNSTimeout        time.Duration // Budget for the DNS lookups of a single create
+	DNSCacheTTL       time.Duration // How long DNS answers are reused
+	AutoFixWebScheme  bool          // Prepend https:// to web values without a scheme before validating
+	NormalizeWeb      bool          // Lowercase the web scheme and host and drop default ports
+	WebTrailingSlash  bool          // With NormalizeWeb, also strip a trailing slash from the path
+	EmailMode         string        // Email syntax check: simple, rfc, strict, or empty for the validator's built-in check
+	RejectClientID    bool          // Reject creates whose body sets id instead of ignoring it
+	TitleCaseNames    string        // Title-case first and last names: off, import, or all (imports plus API writes)
+	PostalPadWidth    int           // Left-pad all-digit postal codes with zeros to this width, e.g. 5 for US ZIP codes (0 disables)
+	AllowedCounties   string        // Comma-separated counties accepted on create, update and import (empty allows any)
+	Severity          string        // Per-rule severity overrides, e.g. "phone:max=warning,web=warning"; unlisted rules are errors
+}
+
+// ExportConfig holds file export configuration
+type ExportConfig struct {
+	ColumnOrder string // Comma-separated column names emitted first, e.g. "email,last_name,first_name"
+	StreamFlush int    // NDJSON stream flushes to the client every this many rows
+	BackupDir   string // Where on-demand backups are written (default: a directory under the system temp dir)
+}
+
+// EventsConfig holds domain event publishing configuration
+type EventsConfig struct {
+	Enabled     bool          // Publish an event after every successful employee write
+	Broker      string        // Where events go: log or http
+	URL         string        // Endpoint the http broker POSTs each event to, e.g. a Kafka REST proxy or NATS HTTP gateway
+	Timeout     time.Duration // Budget for publishing one event
+	FailOnError bool          // Publish before answering and fail the request when publishing fails, instead of logging in the background
+	ActorHeader string        // Request header naming the caller, recorded as the event's actor
+}
+
+// ReadinessConfig holds readiness probe configuration
+type ReadinessConfig struct {
+	Required string        // Comma-separated dependencies that must be up: database, cache, events
+	CacheTTL time.Duration // How long one evaluation is reused, so probes do not hammer dependencies
+	Timeout  time.Duration // Budget for checking one dependency
 }
 
 // Load loads configuration from environment variables with defaults
@@ -58,6 +196,8 @@ func Load() *Config {
 		log.Println("✅ .env file loaded successfully")
 	}
 
+	ginMode := getEnv("GIN_MODE", "debug")
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -66,6 +206,20 @@ func Load() *Config {
 			Password: getEnv("DB_PASSWORD", "password"),
 			DBName:   getEnv("DB_NAME", "employee_management"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+
+			StatementTimeout: getEnvAsDuration("DB_STATEMENT_TIMEOUT", 30*time.Second),
+			MaxOpenConns:     getEnvAsInt("DB_MAX_OPEN_CONNS", 100),
+
+			UniquePhone: getEnvAsBool("UNIQUE_PHONE", false),
+
+			ReplicaHost:      getEnv("DB_REPLICA_HOST", ""),
+			ReplicaPort:      getEnvAsInt("DB_REPLICA_PORT", 3306),
+			ReplicaLagWindow: getEnvAsDuration("DB_REPLICA_LAG_WINDOW", 5*time.Second),
+
+			SearchIndexHint: getEnv("DB_SEARCH_INDEX_HINT", ""),
+
+			PIIEncryptionKey:   getEnv("PII_ENCRYPTION_KEY", ""),
+			PIIEncryptedFields: getEnv("PII_ENCRYPTED_FIELDS", "email,phone"),
 		},
 		Redis: RedisConfig{
 			Host:        getEnv("REDIS_HOST", "localhost"),
@@ -75,22 +229,256 @@ func Load() *Config {
 			MaxRetries:  getEnvAsInt("REDIS_MAX_RETRIES", 3),
 			IdleTimeout: getEnvAsDuration("REDIS_IDLE_TIMEOUT", 5*time.Minute),
 			CacheExpiry: getEnvAsDuration("CACHE_EXPIRY", 5*time.Minute), // 5 minutes as required
+			MaxCacheTTL: getEnvAsDuration("CACHE_TTL_MAX", time.Hour),
+
+			Backend:    getEnv("CACHE_BACKEND", "redis"),
+			MaxEntries: getEnvAsInt("CACHE_MAX_ENTRIES", 10000),
+
+			FailOnError: getEnvAsBool("FAIL_ON_CACHE_ERROR", false),
+			Compress:    getEnvAsBool("CACHE_COMPRESS", false),
+
+			SingleFlight: getEnvAsBool("CACHE_SINGLEFLIGHT", true),
 		},
 		Server: ServerConfig{
 			Port:         getEnv("SERVER_PORT", "8080"),
-			Mode:         getEnv("GIN_MODE", "debug"),
+			Mode:         ginMode,
 			ReadTimeout:  getEnvAsDuration("SERVER_READ_TIMEOUT", 30*time.Second),
 			WriteTimeout: getEnvAsDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
 			MaxFileSize:  getEnvAsInt64("MAX_FILE_SIZE", 10*1024*1024), // 10MB default
 			MaxWorkers:   getEnvAsInt("MAX_WORKERS", 5),                // 5 workers default
+			PageBase:     getEnvAsInt("PAGE_BASE", 1),                  // one-based pages default
+			MaxPage:      getEnvAsInt("MAX_PAGE", 100000),
+			RoutePrefix:  normalizeRoutePrefix(getEnv("ROUTE_PREFIX", "")),
+			EmptySearch:  getEnv("EMPTY_SEARCH_BEHAVIOR", "all"),
+
+			SkipUnchangedUpdates:   getEnvAsBool("SKIP_UNCHANGED_UPDATES", true),
+			NullableResponseFields: getEnvAsBool("NULLABLE_RESPONSE_FIELDS", false),
+
+			AdminAPIKey: getEnv("ADMIN_API_KEY", ""),
+
+			RequestIDHeader: getEnv("REQUEST_ID_HEADER", "X-Request-ID"),
+
+			RouteAliases: getEnv("ROUTE_ALIASES", ""),
+
+			StrictProd: getEnvAsBool("STRICT_PROD", false),
+
+			RedactPIILogs: getEnvAsBool("REDACT_PII_LOGS", ginMode == "release"),
+		},
+		Import: ImportConfig{
+			MinValidRatio:       getEnvAsFloat("MIN_VALID_RATIO", 0),
+			MinValidRatioStrict: getEnvAsBool("MIN_VALID_RATIO_STRICT", true),
+			Charset:             getEnv("IMPORT_CHARSET", "auto"),
+			BlankRequiredRows:   getEnv("IMPORT_BLANK_REQUIRED_ROWS", "error"),
+			StatusPolicy:        getEnv("IMPORT_STATUS_POLICY", "multi-status"),
+			DBConnFraction:      getEnvAsFloat("IMPORT_DB_CONN_FRACTION", 0.25),
+			MaxDuplicatesShown:  getEnvAsInt("MAX_DUPLICATES_IN_RESPONSE", 10),
+			MaxValidationErrors: getEnvAsInt("MAX_VALIDATION_ERRORS", 5000),
+			RequiredHeaders:     getEnv("IMPORT_REQUIRED_HEADERS", ""),
+			DuplicateHeaders:    getEnv("IMPORT_DUPLICATE_HEADERS", "error"),
+			SplitRules:          getEnv("IMPORT_SPLIT_RULES", ""),
+			Transforms:          getEnv("IMPORT_TRANSFORMS", ""),
+			DefaultCompany:      getEnv("IMPORT_DEFAULT_COMPANY", ""),
+
+			ChunkedUploadDir: getEnv("CHUNKED_UPLOAD_DIR", ""),
+			ChunkedUploadTTL: getEnvAsDuration("CHUNKED_UPLOAD_TTL", time.Hour),
+
+			JobTTL:             getEnvAsDuration("JOB_TTL", 24*time.Hour),
+			JobCleanupInterval: getEnvAsDuration("JOB_CLEANUP_INTERVAL", 5*time.Minute),
+			MaxRetainedJobs:    getEnvAsInt("JOB_MAX_RETAINED", 1000),
+
+			MaxUploadsPerIP: getEnvAsInt("MAX_UPLOADS_PER_IP", 2),
+
+			MemoryLimitMB:       getEnvAsInt("IMPORT_MEMORY_LIMIT_MB", 0),
+			MemoryCheckInterval: getEnvAsDuration("IMPORT_MEMORY_CHECK_INTERVAL", time.Second),
+
+			MaxZipUncompressed: getEnvAsInt64("ZIP_MAX_UNCOMPRESSED_SIZE", 100*1024*1024), // 100MB default
+
+			AllowReplace: getEnvAsBool("IMPORT_ALLOW_REPLACE", false),
+
+			StatusActions: getEnv("IMPORT_STATUS_ACTIONS", "active=upsert,inactive=skip,delete=delete"),
+		},
+		Validation: ValidationConfig{
+			VerifyEmailDomain: getEnv("VERIFY_EMAIL_DOMAIN", "off"),
+			DNSTimeout:        getEnvAsDuration("DNS_LOOKUP_TIMEOUT", 2*time.Second),
+			DNSCacheTTL:       getEnvAsDuration("DNS_CACHE_TTL", 10*time.Minute),
+			AutoFixWebScheme:  getEnvAsBool("AUTO_FIX_WEB_SCHEME", false),
+			NormalizeWeb:      getEnvAsBool("NORMALIZE_WEB", false),
+			WebTrailingSlash:  getEnvAsBool("WEB_STRIP_TRAILING_SLASH", false),
+			EmailMode:         getEnv("EMAIL_VALIDATION", ""),
+			RejectClientID:    getEnvAsBool("REJECT_CLIENT_ID", false),
+			TitleCaseNames:    getEnv("TITLE_CASE_NAMES", "off"),
+			PostalPadWidth:    getEnvAsInt("POSTAL_PAD_WIDTH", 0),
+			AllowedCounties:   getEnv("ALLOWED_COUNTIES", ""),
+			Severity:          getEnv("VALIDATION_SEVERITY", ""),
+		},
+		Export: ExportConfig{
+			ColumnOrder: getEnv("COLUMN_ORDER", ""),
+			StreamFlush: getEnvAsInt("STREAM_FLUSH_ROWS", 100),
+			BackupDir:   getEnv("BACKUP_DIR", ""),
+		},
+		Events: EventsConfig{
+			Enabled:     getEnvAsBool("EVENTS_ENABLED", false),
+			Broker:      getEnv("EVENTS_BROKER", "log"),
+			URL:         getEnv("EVENTS_URL", ""),
+			Timeout:     getEnvAsDuration("EVENTS_TIMEOUT", 5*time.Second),
+			FailOnError: getEnvAsBool("EVENTS_FAIL_ON_ERROR", false),
+			ActorHeader: getEnv("EVENTS_ACTOR_HEADER", "X-Actor"),
+		},
+		Readiness: ReadinessConfig{
+			Required: getEnv("READINESS_REQUIRED", "database,events"),
+			CacheTTL: getEnvAsDuration("READINESS_CACHE_TTL", 2*time.Second),
+			Timeout:  getEnvAsDuration("READINESS_TIMEOUT", 2*time.Second),
 		},
 	}
 }
 
+// InsecureSettings lists settings that are unsafe in production. It is empty
+// outside release mode.
+func (c *Config) InsecureSettings() []string {
+	if c.Server.Mode != "release" {
+		return nil
+	}
+
+	var problems []string
+	if c.Database.Password == "" {
+		problems = append(problems, "DB_PASSWORD is empty")
+	}
+	// "preferred" falls back to plain text when the server offers no TLS
+	if tls := c.Database.TLSParam(); tls == "" || tls == "preferred" {
+		problems = append(problems, fmt.Sprintf("DB_SSL_MODE %q does not require TLS", c.Database.SSLMode))
+	}
+	if c.Redis.Password == "" {
+		problems = append(problems, "REDIS_PASSWORD is empty")
+	}
+	return problems
+}
+
+// CheckProductionSafety reports insecure settings in release mode. With
+// STRICT_PROD they are an error and the server must not start; otherwise
+// each one is logged as a warning.
+func (c *Config) CheckProductionSafety() error {
+	problems := c.InsecureSettings()
+	if len(problems) == 0 {
+		return nil
+	}
+	if c.Server.StrictProd {
+		return fmt.Errorf("insecure settings in release mode: %s", strings.Join(problems, "; "))
+	}
+	for _, problem := range problems {
+		log.Printf("⚠️  Warning: insecure setting in release mode: %s (set STRICT_PROD=true to refuse to start)", problem)
+	}
+	return nil
+}
+
+// redactedSecret replaces a configured secret in Redacted
+const redactedSecret = "[REDACTED]"
+
+// Redacted returns a copy of the configuration that is safe to show: every
+// password and key is replaced whole, never masked in part, so nothing of
+// it can be recovered. An unset secret stays empty, which still tells
+// whether it was configured.
+func (c *Config) Redacted() Config {
+	redacted := *c
+	redacted.Database.Password = redact(c.Database.Password)
+	redacted.Database.PIIEncryptionKey = redact(c.Database.PIIEncryptionKey)
+	redacted.Redis.Password = redact(c.Redis.Password)
+	redacted.Server.AdminAPIKey = redact(c.Server.AdminAPIKey)
+	redacted.Events.URL = redactURL(c.Events.URL)
+	return redacted
+}
+
+// redactURL hides the credentials in a URL's user info, if it has any. A
+// value that does not parse is hidden whole rather than risk showing them.
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return redact(raw)
+	}
+	if parsed.User == nil {
+		return raw
+	}
+	parsed.User = nil
+	return strings.Replace(parsed.String(), "//", "//"+redactedSecret+"@", 1)
+}
+
+// redact hides a secret value, keeping an empty one empty
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedSecret
+}
+
+// ImportConnectionBudget returns how many database connections imports may
+// hold at once: DBConnFraction of MaxOpenConns, at least 1, or 0 for no cap
+func (c *Config) ImportConnectionBudget() int {
+	fraction := c.Import.DBConnFraction
+	if fraction <= 0 || c.Database.MaxOpenConns <= 0 {
+		return 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	budget := int(float64(c.Database.MaxOpenConns) * fraction)
+	if budget < 1 {
+		budget = 1
+	}
+	return budget
+}
+
 // GetDSN returns database connection string
 func (db *DatabaseConfig) GetDSN() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		db.User, db.Password, db.Host, db.Port, db.DBName)
+	return db.dsnFor(db.Host, db.Port)
+}
+
+// GetReplicaDSN returns the read replica's connection string, or "" when no
+// replica is configured
+func (db *DatabaseConfig) GetReplicaDSN() string {
+	if db.ReplicaHost == "" {
+		return ""
+	}
+	return db.dsnFor(db.ReplicaHost, db.ReplicaPort)
+}
+
+// TLSVerifyCA names the TLS config the database package registers with the
+// MySQL driver for DB_SSL_MODE=verify-ca: the CA chain is checked but not
+// the host name
+const TLSVerifyCA = "verify-ca"
+
+// sslModeTLS maps DB_SSL_MODE to the MySQL driver's tls parameter. Any other
+// mode, including "disable", connects without TLS.
+var sslModeTLS = map[string]string{
+	"preferred":   "preferred",
+	"require":     "skip-verify",
+	"verify-ca":   TLSVerifyCA,
+	"verify-full": "true",
+}
+
+// TLSParam returns the driver's tls parameter for DB_SSL_MODE, or "" when
+// connections are not encrypted
+func (db *DatabaseConfig) TLSParam() string {
+	return sslModeTLS[strings.ToLower(db.SSLMode)]
+}
+
+// dsnFor builds a connection string for one MySQL server
+func (db *DatabaseConfig) dsnFor(host string, port int) string {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		db.User, db.Password, host, port, db.DBName)
+
+	if tls := db.TLSParam(); tls != "" {
+		dsn += "&tls=" + tls
+	}
+
+	if db.StatementTimeout > 0 {
+		// readTimeout/writeTimeout stop the driver from waiting forever on a stuck
+		// connection. The execution budget is not set here: a session-wide
+		// max_execution_time would also kill long cursor reads mid-stream.
+		dsn += fmt.Sprintf("&readTimeout=%s&writeTimeout=%s",
+			db.StatementTimeout, db.StatementTimeout)
+	}
+
+	return dsn
 }
 
 // GetRedisAddr returns Redis address
@@ -98,6 +486,15 @@ func (r *RedisConfig) GetRedisAddr() string {
 	return fmt.Sprintf("%s:%d", r.Host, r.Port)
 }
 
+// normalizeRoutePrefix ensures a prefix has a leading slash and no trailing slash
+func normalizeRoutePrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
 // Helper functions to read environment variables with defaults
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -124,6 +521,24 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -34,6 +34,66 @@ func TestGetDSN(t *testing.T) {
 			},
 			expected: "admin:secret123@tcp(db.example.com:3307)/production?charset=utf8mb4&parseTime=True&loc=Local",
 		},
+		{
+			name: "with statement timeout",
+			config: DatabaseConfig{
+				Host:             "localhost",
+				Port:             3306,
+				User:             "testuser",
+				Password:         "testpass",
+				DBName:           "testdb",
+				StatementTimeout: 15 * time.Second,
+			},
+			expected: "testuser:testpass@tcp(localhost:3306)/testdb?charset=utf8mb4&parseTime=True&loc=Local&readTimeout=15s&writeTimeout=15s",
+		},
+		{
+			name: "ssl required",
+			config: DatabaseConfig{
+				Host:     "localhost",
+				Port:     3306,
+				User:     "testuser",
+				Password: "testpass",
+				DBName:   "testdb",
+				SSLMode:  "require",
+			},
+			expected: "testuser:testpass@tcp(localhost:3306)/testdb?charset=utf8mb4&parseTime=True&loc=Local&tls=skip-verify",
+		},
+		{
+			name: "ssl verified",
+			config: DatabaseConfig{
+				Host:     "localhost",
+				Port:     3306,
+				User:     "testuser",
+				Password: "testpass",
+				DBName:   "testdb",
+				SSLMode:  "verify-full",
+			},
+			expected: "testuser:testpass@tcp(localhost:3306)/testdb?charset=utf8mb4&parseTime=True&loc=Local&tls=true",
+		},
+		{
+			name: "ssl with ca check only",
+			config: DatabaseConfig{
+				Host:     "localhost",
+				Port:     3306,
+				User:     "testuser",
+				Password: "testpass",
+				DBName:   "testdb",
+				SSLMode:  "verify-ca",
+			},
+			expected: "testuser:testpass@tcp(localhost:3306)/testdb?charset=utf8mb4&parseTime=True&loc=Local&tls=verify-ca",
+		},
+		{
+			name: "unknown ssl mode",
+			config: DatabaseConfig{
+				Host:     "localhost",
+				Port:     3306,
+				User:     "testuser",
+				Password: "testpass",
+				DBName:   "testdb",
+				SSLMode:  "debug",
+			},
+			expected: "testuser:testpass@tcp(localhost:3306)/testdb?charset=utf8mb4&parseTime=True&loc=Local",
+		},
 	}
 
 	for _, tt := range tests {
@@ -46,6 +106,19 @@ func TestGetDSN(t *testing.T) {
 	}
 }
 
+func TestGetReplicaDSN(t *testing.T) {
+	config := DatabaseConfig{Host: "primary", Port: 3306, User: "u", Password: "p", DBName: "db"}
+	if dsn := config.GetReplicaDSN(); dsn != "" {
+		t.Errorf("Expected no replica DSN without DB_REPLICA_HOST, got %q", dsn)
+	}
+
+	config.ReplicaHost, config.ReplicaPort = "replica", 3307
+	expected := "u:p@tcp(replica:3307)/db?charset=utf8mb4&parseTime=True&loc=Local"
+	if dsn := config.GetReplicaDSN(); dsn != expected {
+		t.Errorf("GetReplicaDSN() = %v, want %v", dsn, expected)
+	}
+}
+
 func TestGetRedisAddr(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -115,6 +188,9 @@ func TestLoad_WithDefaults(t *testing.T) {
 	if config.Database.User != "root" {
 		t.Errorf("Expected DB user 'root', got '%s'", config.Database.User)
 	}
+	if config.Database.StatementTimeout != 30*time.Second {
+		t.Errorf("Expected DB statement timeout 30s, got %v", config.Database.StatementTimeout)
+	}
 
 	// Test Redis defaults
 	if config.Redis.Host != "localhost" {
@@ -145,6 +221,8 @@ func TestLoad_WithEnvironmentVariables(t *testing.T) {
 		"REDIS_HOST":  "redishost",
 		"REDIS_PORT":  "6380",
 		"SERVER_PORT": "9000",
+
+		"DB_STATEMENT_TIMEOUT": "5s",
 	}
 
 	// Store original values and set test values
@@ -186,6 +264,9 @@ func TestLoad_WithEnvironmentVariables(t *testing.T) {
 	if config.Server.Port != "9000" {
 		t.Errorf("Expected server port '9000', got '%s'", config.Server.Port)
 	}
+	if config.Database.StatementTimeout != 5*time.Second {
+		t.Errorf("Expected DB statement timeout 5s, got %v", config.Database.StatementTimeout)
+	}
 }
 
 func TestGetEnvHelpers(t *testing.T) {
@@ -248,3 +329,108 @@ func TestGetEnvHelpers(t *testing.T) {
 		}
 	})
 }
+
+func TestNormalizeRoutePrefix(t *testing.T) {
+	tests := map[string]string{
+		"":               "",
+		"/":              "",
+		"employee-svc":   "/employee-svc",
+		"/employee-svc/": "/employee-svc",
+		" /a/b ":         "/a/b",
+	}
+
+	for input, expected := range tests {
+		if result := normalizeRoutePrefix(input); result != expected {
+			t.Errorf("normalizeRoutePrefix(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestImportConnectionBudget(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxOpenConns int
+		fraction     float64
+		expected     int
+	}{
+		{"quarter of pool", 100, 0.25, 25},
+		{"rounds down", 10, 0.25, 2},
+		{"at least one", 3, 0.1, 1},
+		{"capped at pool", 10, 2, 10},
+		{"disabled", 100, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Database: DatabaseConfig{MaxOpenConns: tt.maxOpenConns},
+				Import:   ImportConfig{DBConnFraction: tt.fraction},
+			}
+			if got := cfg.ImportConnectionBudget(); got != tt.expected {
+				t.Errorf("Expected budget %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckProductionSafety(t *testing.T) {
+	insecure := Config{
+		Server:   ServerConfig{Mode: "release"},
+		Database: DatabaseConfig{SSLMode: "disable"},
+	}
+	secure := Config{
+		Server:   ServerConfig{Mode: "release", StrictProd: true},
+		Database: DatabaseConfig{Password: "secret", SSLMode: "require"},
+		Redis:    RedisConfig{Password: "secret"},
+	}
+
+	if problems := insecure.InsecureSettings(); len(problems) != 3 {
+		t.Errorf("Expected empty passwords and disabled SSL to be flagged, got %v", problems)
+	}
+	if err := insecure.CheckProductionSafety(); err != nil {
+		t.Errorf("Expected only warnings without STRICT_PROD, got %v", err)
+	}
+
+	insecure.Server.StrictProd = true
+	if err := insecure.CheckProductionSafety(); err == nil {
+		t.Error("Expected STRICT_PROD to refuse insecure settings in release mode")
+	}
+
+	if err := secure.CheckProductionSafety(); err != nil {
+		t.Errorf("Expected secure settings to pass, got %v", err)
+	}
+
+	// A mode the driver does not understand connects in plain text
+	secure.Database.SSLMode = "enabled"
+	if problems := secure.InsecureSettings(); len(problems) != 1 {
+		t.Errorf("Expected an unknown DB_SSL_MODE to be flagged, got %v", problems)
+	}
+	secure.Database.SSLMode = "preferred"
+	if problems := secure.InsecureSettings(); len(problems) != 1 {
+		t.Errorf("Expected optional TLS to be flagged, got %v", problems)
+	}
+
+	insecure.Server.Mode = "debug"
+	if err := insecure.CheckProductionSafety(); err != nil {
+		t.Errorf("Expected no check outside release mode, got %v", err)
+	}
+}
+
+func TestLoad_RedactPIILogsDefault(t *testing.T) {
+	t.Setenv("REDACT_PII_LOGS", "")
+
+	t.Setenv("GIN_MODE", "debug")
+	if Load().Server.RedactPIILogs {
+		t.Error("Expected PII in logs to be kept in debug mode by default")
+	}
+
+	t.Setenv("GIN_MODE", "release")
+	if !Load().Server.RedactPIILogs {
+		t.Error("Expected PII in logs to be redacted in release mode by default")
+	}
+
+	t.Setenv("REDACT_PII_LOGS", "false")
+	if Load().Server.RedactPIILogs {
+		t.Error("Expected REDACT_PII_LOGS=false to keep full values for debugging")
+	}
+}
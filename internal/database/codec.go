@@ -0,0 +1,56 @@
+package database
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// compressedPrefix marks gzip'd cache payloads. Plain JSON never starts with
+// it, so entries written before and after CACHE_COMPRESS is toggled both decode.
+const compressedPrefix = "gz:"
+
+// marshal encodes a cache payload as JSON, gzip'd when compression is enabled
+func (r *RedisClient) marshal(v interface{}) ([]byte, error) {
+	return marshalPayload(v, r.compress)
+}
+
+// marshalPayload encodes v as JSON, optionally gzip'd behind compressedPrefix
+func marshalPayload(v interface{}, compress bool) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || !compress {
+		return data, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(compressedPrefix)
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress payload: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress payload: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalPayload decodes a payload written by marshalPayload, compressed or not
+func unmarshalPayload(data []byte, v interface{}) error {
+	if !bytes.HasPrefix(data, []byte(compressedPrefix)) {
+		return json.Unmarshal(data, v)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data[len(compressedPrefix):]))
+	if err != nil {
+		return fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	defer zr.Close()
+
+	decompressed, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	return json.Unmarshal(decompressed, v)
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"bytes"
+	"employee-management/internal/models"
+	"fmt"
+	"testing"
+)
+
+func TestPayload_CompressedRoundTrip(t *testing.T) {
+	listData := EmployeeListData{Total: 200}
+	for i := 1; i <= 200; i++ {
+		listData.Employees = append(listData.Employees, models.Employee{
+			ID: i, FirstName: "John", LastName: "Doe", CompanyName: "Acme Corporation",
+			Email: fmt.Sprintf("john%d@example.com", i),
+		})
+	}
+
+	plain, err := marshalPayload(listData, false)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	compressed, err := marshalPayload(listData, true)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if !bytes.HasPrefix(compressed, []byte(compressedPrefix)) {
+		t.Fatalf("Expected compressed payload to carry the marker")
+	}
+	if len(compressed) >= len(plain) {
+		t.Errorf("Expected compression to shrink the payload, got %d >= %d bytes", len(compressed), len(plain))
+	}
+
+	// Both forms decode regardless of the current setting
+	for name, data := range map[string][]byte{"compressed": compressed, "plain": plain} {
+		var decoded EmployeeListData
+		if err := unmarshalPayload(data, &decoded); err != nil {
+			t.Fatalf("%s: unmarshal failed: %v", name, err)
+		}
+		if decoded.Total != 200 || len(decoded.Employees) != 200 || decoded.Employees[199].Email != "john200@example.com" {
+			t.Errorf("%s: payload did not round-trip: total %d, %d employees", name, decoded.Total, len(decoded.Employees))
+		}
+	}
+}
+
+func TestPayload_CorruptCompressedEntry(t *testing.T) {
+	var employee models.Employee
+	if err := unmarshalPayload([]byte(compressedPrefix+"not gzip"), &employee); err == nil {
+		t.Error("Expected error for corrupt compressed payload")
+	}
+}
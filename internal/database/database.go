@@ -1,21 +1,33 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"employee-management/internal/config"
 	"employee-management/internal/models"
+	"employee-management/internal/pii"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
-// DB holds the database connection
+// DB holds the database connection. Replica, when set, serves read-only
+// repository queries; the embedded connection is the primary.
 type DB struct {
 	*gorm.DB
+	Replica          *gorm.DB
+	ReplicaLagWindow time.Duration
+	SearchIndexHint  string        // USE INDEX list for searches, already validated
+	StatementTimeout time.Duration // MAX_EXECUTION_TIME for list and search queries; 0 disables
 }
 
 // NewDatabase creates a new database connection
@@ -34,10 +46,37 @@ func NewDatabase(cfg *config.DatabaseConfig) (*DB, error) {
 	}
 
 	// Create database connection
-	db, err := gorm.Open(mysql.Open(cfg.GetDSN()), gormConfig)
+	db, err := openPool(cfg.GetDSN(), gormConfig, cfg.MaxOpenConns)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	result := &DB{
+		DB:               db,
+		SearchIndexHint:  parseIndexHint(cfg.SearchIndexHint),
+		StatementTimeout: cfg.StatementTimeout,
+	}
+
+	// Optional read replica with its own pool of the same size
+	if dsn := cfg.GetReplicaDSN(); dsn != "" {
+		replica, err := openPool(dsn, gormConfig, cfg.MaxOpenConns)
+		if err != nil {
+			result.Close()
+			return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+		}
+		result.Replica = replica
+		result.ReplicaLagWindow = cfg.ReplicaLagWindow
+		log.Printf("Read replica %s:%d serves read-only queries", cfg.ReplicaHost, cfg.ReplicaPort)
+	}
+
+	return result, nil
+}
+
+// openPool opens one MySQL server and configures its connection pool
+func openPool(dsn string, gormConfig *gorm.Config, maxOpenConns int) (*gorm.DB, error) {
+	db, err := gorm.Open(mysql.Open(dsn), gormConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get underlying sql.DB to configure connection pool
 	sqlDB, err := db.DB()
@@ -47,19 +86,27 @@ func NewDatabase(cfg *config.DatabaseConfig) (*DB, error) {
 
 	// Configure connection pool
 	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	if maxOpenConns <= 0 {
+		maxOpenConns = 100
+	}
+	sqlDB.SetMaxOpenConns(maxOpenConns)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
-	return &DB{db}, nil
+	return db, nil
 }
 
-// AutoMigrate runs database migrations
+// migratedModels are the tables AutoMigrate creates and keeps up to date
+var migratedModels = []interface{}{
+	&models.Employee{},
+	&models.StagedEmployee{},
+}
+
+// AutoMigrate runs database migrations. It is safe to run on every startup:
+// GORM only creates columns and indexes that do not exist yet.
 func (db *DB) AutoMigrate() error {
 	log.Println("Running database migrations...")
 
-	err := db.DB.AutoMigrate(
-		&models.Employee{},
-	)
+	err := db.DB.AutoMigrate(migratedModels...)
 
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
@@ -69,8 +116,70 @@ func (db *DB) AutoMigrate() error {
 	return nil
 }
 
-// Close closes the database connection
+// The unique indexes behind UNIQUE_PHONE. uniquePhoneIndex is functional: it
+// indexes phone with empty values mapped to NULL, so the many employees
+// without a phone never collide (needs MySQL 8.0.13+). While phone is
+// encrypted its ciphertext is randomized and unique anyway, so
+// uniquePhoneLookupIndex enforces uniqueness on the keyed phone_index instead,
+// which is NULL for empty phones.
+const (
+	uniquePhoneIndex       = "idx_employees_phone_unique"
+	uniquePhoneLookupIndex = "idx_employees_phone_index_unique"
+)
+
+// uniquePhoneIndexColumns maps each unique phone index to its key part
+var uniquePhoneIndexColumns = map[string]string{
+	uniquePhoneIndex:       "(NULLIF(phone, ''))",
+	uniquePhoneLookupIndex: "phone_index",
+}
+
+// SyncUniquePhoneIndex creates the unique phone index matching how phone is
+// stored when enabled and drops any other, so toggling UNIQUE_PHONE or phone
+// encryption only needs a restart. Run it after SyncStoredPII. Creating the
+// index fails while two employees share a non-empty phone; resolve those first.
+func (db *DB) SyncUniquePhoneIndex(enabled bool) error {
+	wanted := ""
+	if enabled {
+		wanted = uniquePhoneIndex
+		if pii.Encrypted("phone") {
+			wanted = uniquePhoneLookupIndex
+		}
+	}
+
+	for _, name := range []string{uniquePhoneIndex, uniquePhoneLookupIndex} {
+		if name == wanted || !db.Migrator().HasIndex(&models.Employee{}, name) {
+			continue
+		}
+		log.Printf("Dropping unique index %s on employees", name)
+		if err := db.Migrator().DropIndex(&models.Employee{}, name); err != nil {
+			return fmt.Errorf("failed to drop unique phone index: %w", err)
+		}
+	}
+
+	if wanted != "" && !db.Migrator().HasIndex(&models.Employee{}, wanted) {
+		columns := uniquePhoneIndexColumns[wanted]
+		log.Printf("Creating unique index %s on employees (%s)", wanted, columns)
+		err := db.Exec("CREATE UNIQUE INDEX " + wanted + " ON employees (" + columns + ")").Error
+		if err != nil {
+			return fmt.Errorf("failed to create unique phone index (are phone numbers already duplicated?): %w", err)
+		}
+	}
+	return nil
+}
+
+// IsDuplicatePhoneError reports whether err is a violation of a unique phone index
+func IsDuplicatePhoneError(err error) bool {
+	return IsDuplicateKeyError(err) &&
+		(strings.Contains(err.Error(), uniquePhoneIndex) || strings.Contains(err.Error(), uniquePhoneLookupIndex))
+}
+
+// Close closes the database connection and the replica's, if any
 func (db *DB) Close() error {
+	if db.Replica != nil {
+		if sqlDB, err := db.Replica.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
 	sqlDB, err := db.DB.DB()
 	if err != nil {
 		return err
@@ -87,69 +196,231 @@ func (db *DB) Health() error {
 	return sqlDB.Ping()
 }
 
+// Ready checks that the database is reachable and migrated: every table and
+// column AutoMigrate creates must exist. It reads the schema, so callers
+// should not run it on every request.
+func (db *DB) Ready(ctx context.Context) error {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return err
+	}
+
+	migrator := db.DB.WithContext(ctx).Migrator()
+	for _, model := range migratedModels {
+		stmt := &gorm.Statement{DB: db.DB}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(table) {
+			return fmt.Errorf("migrations not applied: table %s is missing", table)
+		}
+		columns, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		existing := make(map[string]bool, len(columns))
+		for _, column := range columns {
+			existing[strings.ToLower(column.Name())] = true
+		}
+		for _, name := range stmt.Schema.DBNames {
+			if !existing[strings.ToLower(name)] {
+				return fmt.Errorf("migrations not applied: column %s.%s is missing", table, name)
+			}
+		}
+	}
+	return nil
+}
+
 // Repository interface defines database operations
 type Repository interface {
 	// Employee operations
 	CreateEmployee(employee *models.Employee) error
 	GetEmployeeByID(id int) (*models.Employee, error)
 	GetEmployeeByEmail(email string) (*models.Employee, error)
-	GetAllEmployees(limit, offset int) ([]models.Employee, int64, error)
+	GetEmployeeByPhone(phone string) (*models.Employee, error)
+	GetAllEmployees(limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error)
+	// GetNextEmployee returns the employee with the lowest ID above afterID,
+	// or gorm.ErrRecordNotFound when none remain
+	GetNextEmployee(afterID int) (*models.Employee, error)
+	// GetEmployeesAfterID returns up to limit employees with IDs above
+	// cursorID in ID order, and whether more follow
+	GetEmployeesAfterID(cursorID, limit int) ([]models.Employee, bool, error)
 	UpdateEmployee(employee *models.Employee) error
 	DeleteEmployee(id int) error
+	// GetEmployeesByIDs returns the employees among ids, in ID order
+	GetEmployeesByIDs(ids []int) ([]models.Employee, error)
+	// DeleteEmployeesInBatch deletes the employees with the given IDs and
+	// their dependents in one transaction and returns how many were removed
+	DeleteEmployeesInBatch(ids []int) (int, error)
 
 	// Batch operations for Excel import
 	CreateEmployeesInBatch(employees []models.Employee) error
 	CreateEmployeesInBatchWithResult(employees []models.Employee) (int, int, []string, error)
-	SearchEmployees(query string, limit, offset int) ([]models.Employee, int64, error)
+
+	// Search queries are LIKE patterns matched anywhere in the searchable
+	// columns; use EscapeLike to match user input literally
+	SearchEmployees(query string, limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error)
+	CountEmployees(query string) (int64, error)
+	ExplainSearch(query string, excludeIDs []int, limit, offset int, sort models.SortOptions) ([]map[string]interface{}, error)
+
+	// ListEmployeesExcluding lists employees matching the optional search
+	// query except the given IDs; a limit of 0 only counts them
+	ListEmployeesExcluding(query string, excludeIDs []int, limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error)
+	FilterEmployees(filters map[string]string, query string, excludeIDs []int, limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error)
+
+	// Staging imports: rows wait in employees_staging until their batch is
+	// promoted or discarded. Promote reports gorm.ErrRecordNotFound for an
+	// unknown batch.
+	CreateStagedEmployees(staged []models.StagedEmployee) error
+	GetStagedEmployees(batchID string, limit, offset int) ([]models.StagedEmployee, int64, error)
+	PromoteStagedEmployees(batchID string) (int, int, []string, error)
+	DeleteStagedEmployees(batchID string) (int64, error)
+
+	// SyncEmployees applies a status column import in one transaction:
+	// upserts are matched to employees by email and inserted, or update the
+	// columns the file provides, and the employees with deleteEmails are
+	// removed. Rows clashing with another employee's unique value are skipped
+	// and their emails returned.
+	SyncEmployees(upserts []models.Employee, columns []string, deleteEmails []string) (*models.ImportActionCounts, []string, error)
+
+	// ReplaceEmployees swaps every employee for the given ones in one
+	// transaction and returns how many were removed, inserted and skipped as
+	// duplicates, with the duplicate emails
+	ReplaceEmployees(employees []models.Employee) (int64, int, int, []string, error)
+
+	// Streaming for exports
+	StreamEmployees(query string, fn func(employee *models.Employee) error) error
+
+	// Aggregates for the dashboard
+	GetEmployeeStats(topCompanies int) (*models.EmployeeStats, error)
 }
 
-// EmployeeRepository implements Repository interface
+// EmployeeRepository implements Repository interface. With a replica
+// configured, read-only queries go to it and writes go to the primary.
+// Lookups by email or phone guard writes and always use the primary.
 type EmployeeRepository struct {
-	db *DB
+	db         *DB
+	dependents []DependentCleanup
+	writes     *recentWrites
+}
+
+// DependentCleanup removes rows that reference an employee. Cleanups run inside
+// the DeleteEmployee transaction, before the employee row itself is deleted.
+type DependentCleanup struct {
+	Name   string // Used in error messages, e.g. "audit entries"
+	Delete func(tx *gorm.DB, employeeID int) error
+}
+
+// DeleteByEmployeeID is a DependentCleanup for the common case of a child table
+// with a plain foreign key column
+func DeleteByEmployeeID(table, column string) DependentCleanup {
+	return DependentCleanup{
+		Name: table,
+		Delete: func(tx *gorm.DB, employeeID int) error {
+			return tx.Exec(fmt.Sprintf("DELETE FROM `%s` WHERE `%s` = ?", table, column), employeeID).Error
+		},
+	}
 }
 
 // NewEmployeeRepository creates a new employee repository
 func NewEmployeeRepository(db *DB) *EmployeeRepository {
-	return &EmployeeRepository{db: db}
+	return &EmployeeRepository{db: db, writes: newRecentWrites(db.ReplicaLagWindow)}
+}
+
+// RegisterDependent adds a table that must be cleaned up when an employee is
+// deleted. Call it at startup for every table that references employees.
+func (r *EmployeeRepository) RegisterDependent(cleanup DependentCleanup) {
+	r.dependents = append(r.dependents, cleanup)
 }
 
 // CreateEmployee creates a new employee
 func (r *EmployeeRepository) CreateEmployee(employee *models.Employee) error {
-	return r.db.Create(employee).Error
+	err := r.db.Create(employee).Error
+	r.writes.record(employee.ID)
+	return err
 }
 
 // GetEmployeeByID retrieves an employee by ID
 func (r *EmployeeRepository) GetEmployeeByID(id int) (*models.Employee, error) {
 	var employee models.Employee
-	err := r.db.First(&employee, id).Error
+	err := r.readerFor(id).First(&employee, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// GetNextEmployee retrieves the employee following afterID in ID order
+func (r *EmployeeRepository) GetNextEmployee(afterID int) (*models.Employee, error) {
+	var employee models.Employee
+	err := r.reader().Where("id > ?", afterID).Order("id").Take(&employee).Error
 	if err != nil {
 		return nil, err
 	}
 	return &employee, nil
 }
 
-// GetEmployeeByEmail retrieves an employee by email
+// GetEmployeeByEmail retrieves an employee by email, through its lookup
+// index while emails are encrypted
 func (r *EmployeeRepository) GetEmployeeByEmail(email string) (*models.Employee, error) {
 	var employee models.Employee
-	err := r.db.Where("email = ?", email).First(&employee).Error
+	err := lookupBy(r.db.DB, "email", email).First(&employee).Error
 	if err != nil {
 		return nil, err
 	}
 	return &employee, nil
 }
 
-// GetAllEmployees retrieves all employees with pagination
-func (r *EmployeeRepository) GetAllEmployees(limit, offset int) ([]models.Employee, int64, error) {
+// GetEmployeeByPhone retrieves an employee by phone number, through its
+// lookup index while phones are encrypted
+func (r *EmployeeRepository) GetEmployeeByPhone(phone string) (*models.Employee, error) {
+	var employee models.Employee
+	err := lookupBy(r.db.DB, "phone", phone).First(&employee).Error
+	if err != nil {
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// GetEmployeesAfterID retrieves a page of employees by keyset: the IDs above
+// cursorID walk the primary key, so deep pages cost the same as the first
+// and rows inserted meanwhile never shift a page. One extra row is read to
+// tell whether more follow.
+func (r *EmployeeRepository) GetEmployeesAfterID(cursorID, limit int) ([]models.Employee, bool, error) {
+	var employees []models.Employee
+	err := r.listReader().Where("id > ?", cursorID).Order("id").Limit(limit + 1).Find(&employees).Error
+	if err != nil {
+		return nil, false, err
+	}
+	if len(employees) > limit {
+		return employees[:limit], true, nil
+	}
+	return employees, false, nil
+}
+
+// GetAllEmployees retrieves all employees with pagination in the given order
+func (r *EmployeeRepository) GetAllEmployees(limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error) {
 	var employees []models.Employee
 	var total int64
+	reader := r.listReader()
 
 	// Count total records
-	if err := r.db.Model(&models.Employee{}).Count(&total).Error; err != nil {
+	if err := reader.Model(&models.Employee{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	// Skip the deep-offset query entirely when the page is past the end
+	if int64(offset) >= total {
+		return []models.Employee{}, total, nil
+	}
+
 	// Get paginated records
-	err := r.db.Limit(limit).Offset(offset).Find(&employees).Error
+	err := applySort(reader, sort).Limit(limit).Offset(offset).Find(&employees).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -159,12 +430,75 @@ func (r *EmployeeRepository) GetAllEmployees(limit, offset int) ([]models.Employ
 
 // UpdateEmployee updates an existing employee
 func (r *EmployeeRepository) UpdateEmployee(employee *models.Employee) error {
+	defer r.writes.record(employee.ID)
 	return r.db.Save(employee).Error
 }
 
-// DeleteEmployee deletes an employee by ID
+// DeleteEmployee deletes an employee by ID.
+//
+// The delete cascades: every registered dependent is removed in the same
+// transaction, so either the employee and all of its child rows are gone or
+// nothing is. Cache keys are not transactional and are cleared by the service
+// only after this returns successfully.
 func (r *EmployeeRepository) DeleteEmployee(id int) error {
-	return r.db.Delete(&models.Employee{}, id).Error
+	defer r.writes.record(id)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.deleteDependents(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Employee{}, id).Error
+	})
+}
+
+// GetEmployeesByIDs returns the employees among ids, in ID order. It guards
+// bulk deletes, so it always reads the primary.
+func (r *EmployeeRepository) GetEmployeesByIDs(ids []int) ([]models.Employee, error) {
+	var employees []models.Employee
+	if len(ids) == 0 {
+		return employees, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("id").Find(&employees).Error
+	return employees, err
+}
+
+// DeleteEmployeesInBatch deletes the employees with the given IDs in one
+// transaction, cascading to their dependents like DeleteEmployee, and
+// returns how many employee rows were removed. IDs without an employee are
+// ignored.
+func (r *EmployeeRepository) DeleteEmployeesInBatch(ids []int) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	defer r.writes.record(ids...)
+
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			if err := r.deleteDependents(tx, id); err != nil {
+				return err
+			}
+		}
+		result := tx.Where("id IN ?", ids).Delete(&models.Employee{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted), nil
+}
+
+// deleteDependents removes the registered dependent rows of one employee
+func (r *EmployeeRepository) deleteDependents(tx *gorm.DB, id int) error {
+	for _, dependent := range r.dependents {
+		if err := dependent.Delete(tx, id); err != nil {
+			return fmt.Errorf("failed to delete %s for employee %d: %w", dependent.Name, id, err)
+		}
+	}
+	return nil
 }
 
 // CreateEmployeesInBatch creates multiple employees in a single transaction
@@ -172,6 +506,7 @@ func (r *EmployeeRepository) CreateEmployeesInBatch(employees []models.Employee)
 	if len(employees) == 0 {
 		return nil
 	}
+	defer r.writes.record()
 
 	// Use transaction to ensure data consistency
 	return r.db.Transaction(func(tx *gorm.DB) error {
@@ -194,13 +529,13 @@ func (r *EmployeeRepository) CreateEmployeesInBatch(employees []models.Employee)
 					err := tx.Create(&employee).Error
 					if err != nil {
 						// Skip duplicate email errors, log others
-						if !isDuplicateKeyError(err) {
-							log.Printf("Failed to insert employee %s %s (%s): %v",
-								employee.FirstName, employee.LastName, employee.Email, err)
+						if !IsDuplicateKeyError(err) {
+							log.Printf("Failed to insert employee %s %s (%s): %s",
+								employee.FirstName, employee.LastName, pii.Redact(employee.Email), pii.RedactError(err))
 							return err
 						}
 						// Log duplicate but continue
-						log.Printf("Skipping duplicate email: %s", employee.Email)
+						log.Printf("Skipping duplicate email: %s", pii.Redact(employee.Email))
 					}
 				}
 			}
@@ -219,27 +554,269 @@ func (r *EmployeeRepository) CreateEmployeesInBatchWithResult(employees []models
 	var duplicateEmails []string
 
 	err := r.db.Transaction(func(tx *gorm.DB) error {
-		for _, employee := range employees {
-			err := tx.Create(&employee).Error
+		var err error
+		inserted, skipped, duplicateEmails, err = insertSkippingDuplicates(tx, employees)
+		return err
+	})
+	r.writes.record()
+
+	return inserted, skipped, duplicateEmails, err
+}
+
+// insertSkippingDuplicates inserts employees one by one within tx, counting
+// rows rejected by a unique index as skipped instead of failing
+func insertSkippingDuplicates(tx *gorm.DB, employees []models.Employee) (int, int, []string, error) {
+	var inserted, skipped int
+	var duplicateEmails []string
+
+	for _, employee := range employees {
+		err := tx.Create(&employee).Error
+		if err != nil {
+			if IsDuplicateKeyError(err) {
+				skipped++
+				duplicateEmails = append(duplicateEmails, employee.Email)
+			} else {
+				return 0, 0, nil, err
+			}
+		} else {
+			inserted++
+		}
+	}
+	return inserted, skipped, duplicateEmails, nil
+}
+
+// CreateStagedEmployees stores import rows in the staging table
+func (r *EmployeeRepository) CreateStagedEmployees(staged []models.StagedEmployee) error {
+	if len(staged) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(staged, 100).Error
+}
+
+// GetStagedEmployees returns one page of a staging batch in load order
+func (r *EmployeeRepository) GetStagedEmployees(batchID string, limit, offset int) ([]models.StagedEmployee, int64, error) {
+	var staged []models.StagedEmployee
+	var total int64
+
+	tx := r.db.Model(&models.StagedEmployee{}).Where("batch_id = ?", batchID)
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if limit == 0 || int64(offset) >= total {
+		return []models.StagedEmployee{}, total, nil
+	}
+
+	if err := tx.Order("id").Limit(limit).Offset(offset).Find(&staged).Error; err != nil {
+		return nil, 0, err
+	}
+	return staged, total, nil
+}
+
+// PromoteStagedEmployees moves a staging batch into employees. Rows that hit a
+// unique index are skipped like in a regular import. The inserts and the
+// removal of the batch share one transaction, so a failure leaves the batch
+// staged and employees untouched.
+func (r *EmployeeRepository) PromoteStagedEmployees(batchID string) (int, int, []string, error) {
+	var inserted, skipped int
+	var duplicateEmails []string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var staged []models.StagedEmployee
+		if err := tx.Where("batch_id = ?", batchID).Order("id").Find(&staged).Error; err != nil {
+			return err
+		}
+		if len(staged) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		employees := make([]models.Employee, len(staged))
+		for i := range staged {
+			employees[i] = staged[i].ToEmployee()
+		}
+
+		var err error
+		inserted, skipped, duplicateEmails, err = insertSkippingDuplicates(tx, employees)
+		if err != nil {
+			return err
+		}
+		return tx.Where("batch_id = ?", batchID).Delete(&models.StagedEmployee{}).Error
+	})
+	r.writes.record()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	return inserted, skipped, duplicateEmails, nil
+}
+
+// ReplaceEmployees deletes every employee, cleaning up registered dependents
+// first, and inserts the given ones in the same transaction. Readers keep
+// seeing the old rows until it commits; any failure rolls everything back.
+// Rows that hit a unique index are skipped like in a regular import.
+func (r *EmployeeRepository) ReplaceEmployees(employees []models.Employee) (int64, int, int, []string, error) {
+	var removed int64
+	var inserted, skipped int
+	var duplicateEmails []string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Dependents are cleaned up per employee, as DeleteEmployee does
+		if len(r.dependents) > 0 {
+			var ids []int
+			if err := tx.Model(&models.Employee{}).Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := r.deleteDependents(tx, id); err != nil {
+					return err
+				}
+			}
+		}
+
+		result := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.Employee{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removed = result.RowsAffected
+
+		var err error
+		inserted, skipped, duplicateEmails, err = insertSkippingDuplicates(tx, employees)
+		return err
+	})
+	r.writes.record()
+	if err != nil {
+		return 0, 0, 0, nil, err
+	}
+
+	return removed, inserted, skipped, duplicateEmails, nil
+}
+
+// SyncEmployees applies the upserts and deletes of a status column import.
+// An upsert of an existing employee only writes columns, the ones the file
+// provides, so values of absent columns (such as stored coordinates) are
+// kept. Upserts identical to the stored employee in those columns are not
+// written, so their updated_at stays put. Deleted employees lose their
+// dependent rows too.
+func (r *EmployeeRepository) SyncEmployees(upserts []models.Employee, columns []string, deleteEmails []string) (*models.ImportActionCounts, []string, error) {
+	counts := &models.ImportActionCounts{}
+	var conflicts []string
+	var touched []int
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range upserts {
+			employee := upserts[i]
+			var existing models.Employee
+			err := lookupBy(tx, "email", employee.Email).Take(&existing).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err = tx.Create(&employee).Error; err == nil {
+					counts.Inserted++
+				}
+			case err != nil:
+				return err
+			case sameColumns(&existing, &employee, columns):
+				counts.Unchanged++
+				continue
+			default:
+				employee.ID, employee.CreatedAt = existing.ID, existing.CreatedAt
+				if err = updateColumns(tx, &employee, columns).Error; err == nil {
+					counts.Updated++
+				}
+			}
+
+			// MySQL keeps the transaction open after a duplicate key error
 			if err != nil {
-				if isDuplicateKeyError(err) {
-					skipped++
-					duplicateEmails = append(duplicateEmails, employee.Email)
-				} else {
+				if !IsDuplicateKeyError(err) {
 					return err
 				}
-			} else {
-				inserted++
+				counts.Conflicts++
+				conflicts = append(conflicts, employee.Email)
+				continue
 			}
+			touched = append(touched, employee.ID)
+		}
+
+		for _, email := range deleteEmails {
+			var existing models.Employee
+			err := lookupBy(tx, "email", email).Take(&existing).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				counts.NotFound++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := r.deleteDependents(tx, existing.ID); err != nil {
+				return err
+			}
+			if err := tx.Delete(&models.Employee{}, existing.ID).Error; err != nil {
+				return err
+			}
+			counts.Deleted++
+			touched = append(touched, existing.ID)
 		}
 		return nil
 	})
+	r.writes.record(touched...)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return inserted, skipped, duplicateEmails, err
+	return counts, conflicts, nil
+}
+
+// sameColumns reports whether writing columns of b would leave every stored
+// value of a as it is
+func sameColumns(a, b *models.Employee, columns []string) bool {
+	for _, column := range columns {
+		switch column {
+		case "latitude":
+			if !sameCoordinate(a.Latitude, b.Latitude) {
+				return false
+			}
+		case "longitude":
+			if !sameCoordinate(a.Longitude, b.Longitude) {
+				return false
+			}
+		default:
+			if a.ColumnValue(column) != b.ColumnValue(column) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// updateColumns writes only columns of an existing employee, with the lookup
+// index of each encryptable one, which BeforeSave refreshes alongside it.
+// updated_at is set by GORM.
+func updateColumns(tx *gorm.DB, employee *models.Employee, columns []string) *gorm.DB {
+	selected := append([]string(nil), columns...)
+	for _, column := range models.EncryptableEmployeeColumns() {
+		for _, provided := range columns {
+			if provided == column {
+				selected = append(selected, column+"_index")
+			}
+		}
+	}
+	return tx.Model(employee).Select(selected).Updates(employee)
+}
+
+// sameCoordinate compares two optional coordinates
+func sameCoordinate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// DeleteStagedEmployees discards a staging batch and returns how many rows it held
+func (r *EmployeeRepository) DeleteStagedEmployees(batchID string) (int64, error) {
+	result := r.db.Where("batch_id = ?", batchID).Delete(&models.StagedEmployee{})
+	return result.RowsAffected, result.Error
 }
 
-// isDuplicateKeyError checks if the error is a duplicate key constraint violation
-func isDuplicateKeyError(err error) bool {
+// IsDuplicateKeyError checks if the error is a duplicate key constraint violation
+func IsDuplicateKeyError(err error) bool {
 	if err == nil {
 		return false
 	}
@@ -250,25 +827,319 @@ func isDuplicateKeyError(err error) bool {
 }
 
 // SearchEmployees searches employees by name, email, or company
-func (r *EmployeeRepository) SearchEmployees(query string, limit, offset int) ([]models.Employee, int64, error) {
+func (r *EmployeeRepository) SearchEmployees(query string, limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error) {
 	var employees []models.Employee
 	var total int64
 
 	// Build search query
-	searchQuery := "%" + query + "%"
-	whereClause := r.db.Where("first_name LIKE ? OR last_name LIKE ? OR email LIKE ? OR company_name LIKE ?",
-		searchQuery, searchQuery, searchQuery, searchQuery)
+	whereClause := r.applySearch(r.listReader(), query)
 
 	// Count total matching records
 	if err := whereClause.Model(&models.Employee{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	// Skip the deep-offset query entirely when the page is past the end
+	if int64(offset) >= total {
+		return []models.Employee{}, total, nil
+	}
+
 	// Get paginated matching records
-	err := whereClause.Limit(limit).Offset(offset).Find(&employees).Error
+	err := applySort(whereClause, sort).Limit(limit).Offset(offset).Find(&employees).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	return employees, total, nil
 }
+
+// CountEmployees returns how many employees match the search query, or the
+// total number of employees when the query is empty
+func (r *EmployeeRepository) CountEmployees(query string) (int64, error) {
+	var total int64
+
+	tx := r.listReader().Model(&models.Employee{})
+	if query != "" {
+		tx = r.applySearch(tx, query)
+	}
+	if err := tx.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ListEmployeesExcluding runs a search (or plain listing when query is
+// empty) that leaves out excludeIDs with an id NOT IN clause
+func (r *EmployeeRepository) ListEmployeesExcluding(query string, excludeIDs []int, limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error) {
+	var employees []models.Employee
+	var total int64
+
+	filtered := r.listQuery(r.listReader().Model(&models.Employee{}), query, excludeIDs)
+	if err := filtered.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// limit=0 only wants the total; past the end there is nothing to load
+	if limit <= 0 || int64(offset) >= total {
+		return []models.Employee{}, total, nil
+	}
+
+	if err := applySort(filtered, sort).Limit(limit).Offset(offset).Find(&employees).Error; err != nil {
+		return nil, 0, err
+	}
+	return employees, total, nil
+}
+
+// FilterEmployees lists employees matching every filter, combined with the
+// optional search query and leaving out excludeIDs. Each filter ANDs a LIKE clause matching the value
+// literally anywhere in its column; an encrypted column is compared exactly
+// through its lookup index instead. With limit 0 only the total is computed.
+func (r *EmployeeRepository) FilterEmployees(filters map[string]string, query string, excludeIDs []int, limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error) {
+	var employees []models.Employee
+	var total int64
+
+	filtered := r.listQuery(r.listReader().Model(&models.Employee{}), query, excludeIDs)
+	filtered, err := applyFilters(filtered, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := filtered.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if limit <= 0 || int64(offset) >= total {
+		return []models.Employee{}, total, nil
+	}
+
+	if err := applySort(filtered, sort).Limit(limit).Offset(offset).Find(&employees).Error; err != nil {
+		return nil, 0, err
+	}
+	return employees, total, nil
+}
+
+// applyFilters adds one condition per filter, in column order so equal
+// filters build equal SQL. Only Filterable columns of models.EmployeeFields
+// are spliced into the clause; anything else is an error.
+func applyFilters(tx *gorm.DB, filters map[string]string) (*gorm.DB, error) {
+	columns := make([]string, 0, len(filters))
+	for column := range filters {
+		if err := models.ValidateFilterField(column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		value := filters[column]
+		if pii.Encrypted(column) {
+			tx = lookupBy(tx, column, value)
+			continue
+		}
+		tx = tx.Where(column+" LIKE ?", "%"+EscapeLike(value)+"%")
+	}
+	return tx, nil
+}
+
+// listQuery narrows tx to the optional search query and leaves out excludeIDs
+func (r *EmployeeRepository) listQuery(tx *gorm.DB, query string, excludeIDs []int) *gorm.DB {
+	if query != "" {
+		tx = r.applySearch(tx, query)
+	}
+	if len(excludeIDs) > 0 {
+		tx = tx.Where("id NOT IN ?", excludeIDs)
+	}
+	return tx
+}
+
+// applySort orders a page query. The zero SortOptions adds no ORDER BY,
+// keeping the primary-key order lists have always had. The clause names only
+// columns accepted by models.ParseSortOptions.
+func applySort(tx *gorm.DB, sort models.SortOptions) *gorm.DB {
+	if sort == (models.SortOptions{}) {
+		return tx
+	}
+	return tx.Order(sort.OrderClause())
+}
+
+// listReader is reader for list, search and count queries, which MySQL
+// aborts once they run past DB_STATEMENT_TIMEOUT. Cursor reads behind
+// streams and exports use reader directly: they run as long as the client
+// keeps reading, and cutting them off would truncate a response already sent.
+func (r *EmployeeRepository) listReader() *gorm.DB {
+	reader := r.reader()
+	if r.db.StatementTimeout <= 0 {
+		return reader
+	}
+	return reader.Clauses(executionBudget(r.db.StatementTimeout)).Session(&gorm.Session{})
+}
+
+// executionBudget adds a MAX_EXECUTION_TIME optimizer hint to a SELECT
+type executionBudget time.Duration
+
+func (b executionBudget) ModifyStatement(stmt *gorm.Statement) {
+	selectClause := stmt.Clauses["SELECT"]
+	selectClause.AfterNameExpression = b
+	stmt.Clauses["SELECT"] = selectClause
+}
+
+func (b executionBudget) Build(builder clause.Builder) {
+	builder.WriteString(fmt.Sprintf("/*+ MAX_EXECUTION_TIME(%d) */", time.Duration(b).Milliseconds()))
+}
+
+// ExplainSearch runs EXPLAIN on the page query SearchEmployees (or
+// GetAllEmployees when query is empty, or ListEmployeesExcluding with
+// excludeIDs) would issue and returns the plan rows
+func (r *EmployeeRepository) ExplainSearch(query string, excludeIDs []int, limit, offset int, sort models.SortOptions) ([]map[string]interface{}, error) {
+	reader := r.reader()
+	tx := r.listQuery(reader.Session(&gorm.Session{DryRun: true}).Model(&models.Employee{}), query, excludeIDs)
+	stmt := applySort(tx, sort).Limit(limit).Offset(offset).Find(&[]models.Employee{}).Statement
+
+	var plan []map[string]interface{}
+	if err := reader.Raw("EXPLAIN "+stmt.SQL.String(), stmt.Vars...).Scan(&plan).Error; err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// searchColumns and searchCondition match the query against every searchable
+// column. Column names come from models.EmployeeFields, never from the request.
+var (
+	searchColumns   = models.SearchableEmployeeColumns()
+	searchCondition = strings.Join(searchColumns, " LIKE ? OR ") + " LIKE ?"
+)
+
+// likeEscaper backslash-escapes the LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// EscapeLike escapes term so a LIKE search matches it literally
+func EscapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
+
+// applySearch adds the free-text search condition across the searchable
+// columns, with the configured index hint
+func (r *EmployeeRepository) applySearch(tx *gorm.DB, query string) *gorm.DB {
+	condition, columns := searchClause()
+	searchQuery := "%" + query + "%"
+	args := make([]interface{}, columns)
+	for i := range args {
+		args[i] = searchQuery
+	}
+	if hint := r.db.SearchIndexHint; hint != "" {
+		tx = tx.Table("`employees` USE INDEX (" + hint + ")")
+	}
+	return tx.Where(condition, args...)
+}
+
+// searchClause returns the search condition and how many columns it
+// matches. Encrypted columns are left out: a pattern could match random
+// characters of their ciphertext.
+func searchClause() (string, int) {
+	if pii.Active() == nil {
+		return searchCondition, len(searchColumns)
+	}
+	var columns []string
+	for _, column := range searchColumns {
+		if !pii.Encrypted(column) {
+			columns = append(columns, column)
+		}
+	}
+	return strings.Join(columns, " LIKE ? OR ") + " LIKE ?", len(columns)
+}
+
+// indexNamePattern matches index names that are safe to splice into SQL
+var indexNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// parseIndexHint turns DB_SEARCH_INDEX_HINT into the quoted index list for
+// USE INDEX. Index names are spliced into the SQL, so a list with anything
+// but letters, digits and underscores is logged and ignored.
+func parseIndexHint(raw string) string {
+	var names []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !indexNamePattern.MatchString(name) {
+			log.Printf("Warning: invalid DB_SEARCH_INDEX_HINT %q ignored", raw)
+			return ""
+		}
+		names = append(names, "`"+name+"`")
+	}
+	return strings.Join(names, ", ")
+}
+
+// StreamEmployees walks all employees matching the optional search query in ID
+// order using a database cursor, so memory stays flat regardless of table size
+func (r *EmployeeRepository) StreamEmployees(query string, fn func(employee *models.Employee) error) error {
+	reader := r.reader()
+	tx := reader.Model(&models.Employee{}).Order("id")
+	if query != "" {
+		tx = r.applySearch(tx, query)
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var employee models.Employee
+		if err := reader.ScanRows(rows, &employee); err != nil {
+			return err
+		}
+		if err := fn(&employee); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetEmployeeStats computes aggregate employee figures with a handful of queries
+func (r *EmployeeRepository) GetEmployeeStats(topCompanies int) (*models.EmployeeStats, error) {
+	stats := &models.EmployeeStats{TopCompanies: []models.CompanyCount{}}
+	reader := r.reader()
+
+	if err := reader.Model(&models.Employee{}).Count(&stats.TotalEmployees).Error; err != nil {
+		return nil, err
+	}
+
+	if err := reader.Model(&models.Employee{}).Where("email <> ''").Count(&stats.WithEmail).Error; err != nil {
+		return nil, err
+	}
+	stats.WithoutEmail = stats.TotalEmployees - stats.WithEmail
+
+	err := reader.Model(&models.Employee{}).
+		Select("company_name, COUNT(*) AS count").
+		Where("company_name <> ''").
+		Group("company_name").
+		Order("count DESC, company_name").
+		Limit(topCompanies).
+		Scan(&stats.TopCompanies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var bounds struct {
+		Newest sql.NullTime
+		Oldest sql.NullTime
+	}
+	err = reader.Model(&models.Employee{}).
+		Select("MAX(created_at) AS newest, MIN(created_at) AS oldest").
+		Scan(&bounds).Error
+	if err != nil {
+		return nil, err
+	}
+	if bounds.Newest.Valid {
+		stats.NewestCreatedAt = &bounds.Newest.Time
+	}
+	if bounds.Oldest.Valid {
+		stats.OldestCreatedAt = &bounds.Oldest.Time
+	}
+
+	return stats, nil
+}
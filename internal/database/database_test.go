@@ -0,0 +1,370 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"employee-management/internal/models"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingDriver is a database/sql driver that records statements and
+// transaction boundaries instead of talking to MySQL
+type recordingDriver struct {
+	mu      sync.Mutex
+	log     []string
+	failing string // statements containing this substring fail
+}
+
+func (d *recordingDriver) record(entry string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, entry)
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{driver: c.driver, query: query}, nil
+}
+
+func (c *recordingConn) Close() error { return nil }
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	c.driver.record("BEGIN")
+	return &recordingTx{driver: c.driver}, nil
+}
+
+type recordingTx struct {
+	driver *recordingDriver
+}
+
+func (t *recordingTx) Commit() error {
+	t.driver.record("COMMIT")
+	return nil
+}
+
+func (t *recordingTx) Rollback() error {
+	t.driver.record("ROLLBACK")
+	return nil
+}
+
+type recordingStmt struct {
+	driver *recordingDriver
+	query  string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.driver.failing != "" && strings.Contains(s.query, s.driver.failing) {
+		return nil, errors.New("stub failure")
+	}
+	s.driver.record(s.query)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.record(s.query)
+	return nil, errors.New("queries are not supported by the recording driver")
+}
+
+// connector adapts the driver so each test gets its own instance without
+// registering global driver names
+type connector struct {
+	driver *recordingDriver
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.driver }
+
+func newRecordingRepository(t *testing.T) (*EmployeeRepository, *recordingDriver) {
+	t.Helper()
+
+	stub := &recordingDriver{}
+	return NewEmployeeRepository(&DB{DB: openRecordingDB(t, stub)}), stub
+}
+
+func openRecordingDB(t *testing.T, stub *recordingDriver) *gorm.DB {
+	t.Helper()
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(connector{driver: stub}),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open stub database: %v", err)
+	}
+	return gormDB
+}
+
+func TestDeleteEmployee_CascadesInTransaction(t *testing.T) {
+	repo, stub := newRecordingRepository(t)
+	repo.RegisterDependent(DeleteByEmployeeID("employee_audit_entries", "employee_id"))
+
+	if err := repo.DeleteEmployee(7); err != nil {
+		t.Fatalf("DeleteEmployee failed: %v", err)
+	}
+
+	expected := []string{"BEGIN", "employee_audit_entries", "DELETE FROM `employees`", "COMMIT"}
+	if len(stub.log) != len(expected) {
+		t.Fatalf("Expected %d statements, got %v", len(expected), stub.log)
+	}
+	for i, want := range expected {
+		if !strings.Contains(stub.log[i], want) {
+			t.Errorf("Statement %d: expected %q, got %q", i, want, stub.log[i])
+		}
+	}
+}
+
+func TestDeleteEmployee_ChildFailureRollsBack(t *testing.T) {
+	repo, stub := newRecordingRepository(t)
+	repo.RegisterDependent(DeleteByEmployeeID("employee_audit_entries", "employee_id"))
+	stub.failing = "employee_audit_entries"
+
+	err := repo.DeleteEmployee(7)
+	if err == nil || !strings.Contains(err.Error(), "employee_audit_entries") {
+		t.Fatalf("Expected error naming the dependent, got %v", err)
+	}
+
+	for _, entry := range stub.log {
+		if strings.Contains(entry, "DELETE FROM `employees`") {
+			t.Errorf("Employee row must not be deleted when a dependent fails: %v", stub.log)
+		}
+	}
+	if last := stub.log[len(stub.log)-1]; last != "ROLLBACK" {
+		t.Errorf("Expected transaction to roll back, got %v", stub.log)
+	}
+}
+
+func TestDeleteEmployeesInBatch_SingleTransaction(t *testing.T) {
+	repo, stub := newRecordingRepository(t)
+	repo.RegisterDependent(DeleteByEmployeeID("employee_audit_entries", "employee_id"))
+
+	if _, err := repo.DeleteEmployeesInBatch([]int{3, 7}); err != nil {
+		t.Fatalf("DeleteEmployeesInBatch failed: %v", err)
+	}
+
+	expected := []string{"BEGIN", "employee_audit_entries", "employee_audit_entries", "DELETE FROM `employees` WHERE id IN", "COMMIT"}
+	if len(stub.log) != len(expected) {
+		t.Fatalf("Expected %d statements, got %v", len(expected), stub.log)
+	}
+	for i, want := range expected {
+		if !strings.Contains(stub.log[i], want) {
+			t.Errorf("Statement %d: expected %q, got %q", i, want, stub.log[i])
+		}
+	}
+}
+
+func TestUpdateColumns_OnlyProvidedColumns(t *testing.T) {
+	repo, _ := newRecordingRepository(t)
+	employee := &models.Employee{ID: 4, FirstName: "Jane", CompanyName: "New Co", Email: "jane@example.com"}
+
+	sql := updateColumns(repo.db.Session(&gorm.Session{DryRun: true}), employee, []string{"company_name", "email"}).Statement.SQL.String()
+	if !strings.Contains(sql, "`company_name`=?") || !strings.Contains(sql, "`updated_at`=?") || !strings.Contains(sql, "`id` = ?") {
+		t.Errorf("Expected the provided columns and updated_at to be set on the employee, got %s", sql)
+	}
+	for _, absent := range []string{"latitude", "longitude", "city", "first_name"} {
+		if strings.Contains(sql, "`"+absent+"`") {
+			t.Errorf("Expected %s to be left alone, got %s", absent, sql)
+		}
+	}
+}
+
+func TestSameColumns(t *testing.T) {
+	latitude := 51.5
+	stored := &models.Employee{CompanyName: "Acme", Latitude: &latitude}
+	parsed := &models.Employee{CompanyName: "Acme"}
+
+	if !sameColumns(stored, parsed, []string{"company_name"}) {
+		t.Error("Expected coordinates missing from the file not to count as a change")
+	}
+	if sameColumns(stored, parsed, []string{"company_name", "latitude"}) {
+		t.Error("Expected a cleared coordinate the file provides to count as a change")
+	}
+}
+
+func TestSearchCondition_UsesSearchableFields(t *testing.T) {
+	expected := "first_name LIKE ? OR last_name LIKE ? OR company_name LIKE ? OR email LIKE ?"
+	if searchCondition != expected {
+		t.Errorf("Expected search condition %q, got %q", expected, searchCondition)
+	}
+}
+
+func TestApplyFilters(t *testing.T) {
+	enablePII(t, "email")
+	db := openRecordingDB(t, &recordingDriver{}).Session(&gorm.Session{DryRun: true}).Model(&models.Employee{})
+
+	tx, err := applyFilters(db, map[string]string{"email": "Jo@Example.com", "company_name": "Acme", "city": "50%"})
+	if err != nil {
+		t.Fatalf("applyFilters failed: %v", err)
+	}
+	stmt := tx.Find(&[]models.Employee{}).Statement
+
+	// Columns are ANDed in name order; the encrypted email uses its index
+	expected := "WHERE city LIKE ? AND company_name LIKE ? AND email_index = ?"
+	if sql := stmt.SQL.String(); !strings.Contains(sql, expected) {
+		t.Errorf("Expected %q, got %s", expected, sql)
+	}
+	if len(stmt.Vars) != 3 || stmt.Vars[0] != `%50\%%` || stmt.Vars[1] != "%Acme%" {
+		t.Errorf("Expected literal LIKE patterns, got %v", stmt.Vars)
+	}
+
+	for _, column := range []string{"web", "salary", "city = city OR 1"} {
+		if _, err := applyFilters(db, map[string]string{column: "x"}); err == nil {
+			t.Errorf("Expected %q to be rejected", column)
+		}
+	}
+}
+
+func TestGenerateFilterCacheKey(t *testing.T) {
+	key := func(filters map[string]string) string {
+		return GenerateFilterCacheKey(filters, 10, 0, "", nil, models.SortOptions{}, 0)
+	}
+
+	if key(map[string]string{"city": "Boston", "company_name": "Acme"}) != key(map[string]string{"company_name": "Acme", "city": "Boston"}) {
+		t.Error("Expected equal filters to share a key")
+	}
+
+	// Values that spell out other filters or the list suffix get keys of their own
+	distinct := []map[string]string{
+		{"city": "a", "county": "b"},
+		{"city": "a&county=b"},
+		{"city": "a:all:limit:10:offset:0"},
+		{"city": "a"},
+	}
+	seen := make(map[string]int)
+	for i, filters := range distinct {
+		k := key(filters)
+		if j, ok := seen[k]; ok {
+			t.Errorf("Filters %v and %v share the key %q", distinct[j], filters, k)
+		}
+		seen[k] = i
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"acme": "acme",
+		"50%":  `50\%`,
+		"a_b":  `a\_b`,
+		`c\d`:  `c\\d`,
+		`%_\%`: `\%\_\\\%`,
+		"":     "",
+	}
+	for term, expected := range tests {
+		if got := EscapeLike(term); got != expected {
+			t.Errorf("EscapeLike(%q) = %q, expected %q", term, got, expected)
+		}
+	}
+}
+
+func TestListQuery_ExcludesIDs(t *testing.T) {
+	repo, _ := newRecordingRepository(t)
+	dryRun := func() *gorm.DB { return repo.db.Session(&gorm.Session{DryRun: true}).Model(&models.Employee{}) }
+
+	stmt := repo.listQuery(dryRun(), "%acme%", []int{3, 7}).Limit(10).Offset(20).Find(&[]models.Employee{}).Statement
+	sql := stmt.SQL.String()
+	if !strings.Contains(sql, "first_name LIKE ?") || !strings.Contains(sql, "id NOT IN (?,?)") {
+		t.Fatalf("Expected search and exclusion conditions, got %s", sql)
+	}
+	if !strings.Contains(sql, "LIMIT 10 OFFSET 20") {
+		t.Errorf("Expected pagination to apply, got %s", sql)
+	}
+	if vars := stmt.Vars; len(vars) < 2 || vars[len(vars)-2] != 3 || vars[len(vars)-1] != 7 {
+		t.Errorf("Expected excluded IDs bound as the last vars, got %v", vars)
+	}
+
+	sql = repo.listQuery(dryRun(), "", nil).Find(&[]models.Employee{}).Statement.SQL.String()
+	if strings.Contains(sql, "WHERE") {
+		t.Errorf("Expected no conditions without search or exclusions, got %s", sql)
+	}
+}
+
+func TestGetEmployeesAfterID_Keyset(t *testing.T) {
+	repo, stub := newRecordingRepository(t)
+	repo.GetEmployeesAfterID(40, 10)
+
+	if len(stub.log) != 1 {
+		t.Fatalf("Expected one statement, got %v", stub.log)
+	}
+	sql := stub.log[0]
+	if !strings.Contains(sql, "id > ?") || !strings.Contains(sql, "ORDER BY id LIMIT 11") {
+		t.Errorf("Expected a keyset query reading one extra row, got %s", sql)
+	}
+	if strings.Contains(sql, "OFFSET") || strings.Contains(sql, "COUNT") {
+		t.Errorf("Expected no offset or count, got %s", sql)
+	}
+}
+
+func TestStatementTimeout_ListsOnly(t *testing.T) {
+	repo, stub := newRecordingRepository(t)
+	repo.db.StatementTimeout = 2 * time.Second
+	const hint = "SELECT /*+ MAX_EXECUTION_TIME(2000) */ "
+
+	repo.SearchEmployees("acme", 10, 0, models.SortOptions{})
+	if len(stub.log) != 1 || !strings.HasPrefix(stub.log[0], hint+"count(*)") {
+		t.Fatalf("Expected the search count to carry the budget, got %v", stub.log)
+	}
+	page := repo.listReader().Session(&gorm.Session{DryRun: true}).Limit(10).Find(&[]models.Employee{}).Statement.SQL.String()
+	if !strings.HasPrefix(page, hint+"* FROM `employees`") {
+		t.Errorf("Expected the page query to carry the budget, got %s", page)
+	}
+
+	// A streamed read runs as long as the client keeps reading
+	repo.StreamEmployees("", func(*models.Employee) error { return nil })
+	if len(stub.log) != 2 || strings.Contains(stub.log[1], "MAX_EXECUTION_TIME") {
+		t.Errorf("Expected the cursor read without a budget, got %v", stub.log)
+	}
+}
+
+func TestApplySearch_IndexHint(t *testing.T) {
+	repo, _ := newRecordingRepository(t)
+	dryRun := func() *gorm.DB { return repo.db.Session(&gorm.Session{DryRun: true}).Model(&models.Employee{}) }
+
+	sql := repo.applySearch(dryRun(), "acme").Find(&[]models.Employee{}).Statement.SQL.String()
+	if strings.Contains(sql, "USE INDEX") {
+		t.Errorf("Expected no hint unless configured, got %s", sql)
+	}
+
+	repo.db.SearchIndexHint = parseIndexHint(" idx_name , idx_email")
+	sql = repo.applySearch(dryRun(), "acme").Find(&[]models.Employee{}).Statement.SQL.String()
+	if !strings.Contains(sql, "FROM `employees` USE INDEX (`idx_name`, `idx_email`) WHERE") {
+		t.Errorf("Expected the index hint in the search SQL, got %s", sql)
+	}
+
+	sql = repo.applySearch(dryRun(), "acme").Model(&models.Employee{}).Count(new(int64)).Statement.SQL.String()
+	if !strings.Contains(sql, "USE INDEX (`idx_name`, `idx_email`)") {
+		t.Errorf("Expected the index hint in the count SQL, got %s", sql)
+	}
+}
+
+func TestParseIndexHint(t *testing.T) {
+	tests := map[string]string{
+		"":                     "",
+		"idx_name":             "`idx_name`",
+		"idx_name,,idx_email ": "`idx_name`, `idx_email`",
+		"idx) FORCE INDEX (x":  "",
+		"idx_name,`other`":     "",
+	}
+	for raw, expected := range tests {
+		if got := parseIndexHint(raw); got != expected {
+			t.Errorf("parseIndexHint(%q) = %q, expected %q", raw, got, expected)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package database
+
+import (
+	"container/list"
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-process LRU cache with per-entry TTL. It uses the same
+// key layout as RedisClient so prefix invalidation behaves identically.
+type MemoryCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List // front = most recently used
+	maxEntries int
+	expiry     time.Duration
+	now        func() time.Time
+}
+
+// memoryEntry is a single cached value and its expiry time
+type memoryEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an in-memory cache holding at most maxEntries values
+func NewMemoryCache(maxEntries int, expiry time.Duration) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &MemoryCache{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: maxEntries,
+		expiry:     expiry,
+		now:        time.Now,
+	}
+}
+
+// set stores a value with the default expiry
+func (m *MemoryCache) set(key string, value interface{}) {
+	m.setWithTTL(key, value, m.expiry)
+}
+
+// setWithTTL stores a value, evicting the least recently used entry when full.
+// A zero ttl keeps the entry until it is evicted or overwritten.
+func (m *MemoryCache) setWithTTL(key string, value interface{}, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = m.now().Add(ttl)
+	}
+	if element, ok := m.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for m.order.Len() > m.maxEntries {
+		m.removeElement(m.order.Back())
+	}
+}
+
+// get returns a live value and marks it as recently used
+func (m *MemoryCache) get(key string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && !m.now().Before(entry.expiresAt) {
+		m.removeElement(element)
+		return nil, false
+	}
+
+	m.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// deletePrefix removes every entry whose key starts with prefix
+func (m *MemoryCache) deletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, element := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			m.removeElement(element)
+		}
+	}
+}
+
+func (m *MemoryCache) removeElement(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
+
+// Len returns the number of stored entries, including expired ones not yet evicted
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// SetEmployee caches a single employee
+func (m *MemoryCache) SetEmployee(employee *models.Employee) error {
+	m.set(fmt.Sprintf("employee:%d", employee.ID), *employee)
+	return nil
+}
+
+// GetEmployee retrieves a cached employee
+func (m *MemoryCache) GetEmployee(id int) (*models.Employee, error) {
+	value, ok := m.get(fmt.Sprintf("employee:%d", id))
+	if !ok {
+		return nil, nil // Cache miss
+	}
+
+	employee := value.(models.Employee)
+	return &employee, nil
+}
+
+// DeleteEmployee removes an employee from cache
+func (m *MemoryCache) DeleteEmployee(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[fmt.Sprintf("employee:%d", id)]; ok {
+		m.removeElement(element)
+	}
+	return nil
+}
+
+// SetEmployeeList caches employee list with pagination info
+func (m *MemoryCache) SetEmployeeList(key string, employees []models.Employee, total int64, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = m.expiry
+	}
+	// Copy so later changes to the caller's slice don't leak into the cache
+	m.setWithTTL(fmt.Sprintf("employee_list:%s", key), EmployeeListData{
+		Employees: append([]models.Employee(nil), employees...),
+		Total:     total,
+		CachedAt:  m.now(),
+	}, ttl)
+	return nil
+}
+
+// GetEmployeeList retrieves cached employee list
+func (m *MemoryCache) GetEmployeeList(key string) ([]models.Employee, int64, error) {
+	value, ok := m.get(fmt.Sprintf("employee_list:%s", key))
+	if !ok {
+		return nil, 0, nil // Cache miss
+	}
+
+	// Copy into a non-nil slice so a cached empty page still counts as a hit
+	listData := value.(EmployeeListData)
+	employees := make([]models.Employee, len(listData.Employees))
+	copy(employees, listData.Employees)
+	return employees, listData.Total, nil
+}
+
+// SetEmployeeStats caches aggregate employee stats
+func (m *MemoryCache) SetEmployeeStats(stats *models.EmployeeStats) error {
+	m.set(statsCacheKey, *stats)
+	return nil
+}
+
+// GetEmployeeStats retrieves cached aggregate employee stats
+func (m *MemoryCache) GetEmployeeStats() (*models.EmployeeStats, error) {
+	value, ok := m.get(statsCacheKey)
+	if !ok {
+		return nil, nil // Cache miss
+	}
+
+	stats := value.(models.EmployeeStats)
+	return &stats, nil
+}
+
+// SetLastModified records the time of the latest employee write
+func (m *MemoryCache) SetLastModified(t time.Time) error {
+	m.setWithTTL(lastModifiedKey, t, 0)
+	return nil
+}
+
+// GetLastModified returns the time of the latest employee write
+func (m *MemoryCache) GetLastModified() (time.Time, error) {
+	value, ok := m.get(lastModifiedKey)
+	if !ok {
+		return time.Time{}, nil // Cache miss
+	}
+	return value.(time.Time), nil
+}
+
+// InvalidateEmployeeCache removes all individual employee caches
+func (m *MemoryCache) InvalidateEmployeeCache() error {
+	m.deletePrefix("employee:")
+	return nil
+}
+
+// InvalidateEmployeeListCache removes all employee list caches
+func (m *MemoryCache) InvalidateEmployeeListCache() error {
+	m.deletePrefix("employee_list:")
+	return nil
+}
+
+// Health always succeeds; the cache lives in process
+func (m *MemoryCache) Health() error {
+	return nil
+}
+
+// Close drops all entries
+func (m *MemoryCache) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*list.Element)
+	m.order.Init()
+	return nil
+}
+
+// Inspect returns a live entry as JSON with its remaining TTL, without
+// affecting its LRU position
+func (m *MemoryCache) Inspect(key string) (*CacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	entry := element.Value.(*memoryEntry)
+
+	var ttl time.Duration
+	if !entry.expiresAt.IsZero() {
+		ttl = entry.expiresAt.Sub(m.now())
+		if ttl <= 0 {
+			m.removeElement(element)
+			return nil, nil
+		}
+	}
+
+	value, err := json.Marshal(entry.value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cache key %s: %w", key, err)
+	}
+	return &CacheEntry{Key: key, Value: value, TTL: ttl}, nil
+}
+
+// GetCacheStats counts the live employee and list entries
+func (m *MemoryCache) GetCacheStats() (map[string]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	var employees, lists int
+	for key, element := range m.entries {
+		entry := element.Value.(*memoryEntry)
+		if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(key, "employee:"):
+			employees++
+		case strings.HasPrefix(key, "employee_list:"):
+			lists++
+		}
+	}
+
+	return map[string]interface{}{
+		"backend":               CacheBackendMemory,
+		"cached_employees":      employees,
+		"cached_employee_lists": lists,
+		"cache_expiry_minutes":  m.expiry.Minutes(),
+		"max_entries":           m.maxEntries,
+	}, nil
+}
+
+// NoopCache satisfies CacheInterface without storing anything, so every read
+// goes to the database
+type NoopCache struct{}
+
+func (NoopCache) SetEmployee(*models.Employee) error        { return nil }
+func (NoopCache) GetEmployee(int) (*models.Employee, error) { return nil, nil }
+func (NoopCache) DeleteEmployee(int) error                  { return nil }
+func (NoopCache) SetEmployeeList(string, []models.Employee, int64, time.Duration) error {
+	return nil
+}
+func (NoopCache) GetEmployeeList(string) ([]models.Employee, int64, error) {
+	return nil, 0, nil
+}
+func (NoopCache) SetEmployeeStats(*models.EmployeeStats) error     { return nil }
+func (NoopCache) GetEmployeeStats() (*models.EmployeeStats, error) { return nil, nil }
+func (NoopCache) SetLastModified(time.Time) error                  { return nil }
+func (NoopCache) GetLastModified() (time.Time, error)              { return time.Time{}, nil }
+func (NoopCache) InvalidateEmployeeCache() error                   { return nil }
+func (NoopCache) InvalidateEmployeeListCache() error               { return nil }
+func (NoopCache) Inspect(string) (*CacheEntry, error)              { return nil, nil }
+func (NoopCache) Health() error                                    { return nil }
+func (NoopCache) Close() error                                     { return nil }
+
+// GetCacheStats reports an empty cache
+func (NoopCache) GetCacheStats() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"backend":               CacheBackendNone,
+		"cached_employees":      0,
+		"cached_employee_lists": 0,
+		"cache_expiry_minutes":  0.0,
+	}, nil
+}
+
+// Supported values for CACHE_BACKEND
+const (
+	CacheBackendRedis  = "redis"
+	CacheBackendMemory = "memory"
+	CacheBackendNone   = "none"
+)
+
+// NewCache builds the configured cache backend. When Redis is selected but
+// unreachable, it logs a warning and falls back to the in-memory cache so the
+// service can still start.
+func NewCache(cfg *config.RedisConfig) (CacheInterface, error) {
+	switch strings.ToLower(cfg.Backend) {
+	case "", CacheBackendRedis:
+		client, err := NewRedisClient(cfg)
+		if err != nil {
+			log.Printf("Warning: %v; falling back to in-memory cache", err)
+			return NewMemoryCache(cfg.MaxEntries, cfg.CacheExpiry), nil
+		}
+		return client, nil
+	case CacheBackendMemory:
+		return NewMemoryCache(cfg.MaxEntries, cfg.CacheExpiry), nil
+	case CacheBackendNone:
+		return NoopCache{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported cache backend %q", cfg.Backend)
+	}
+}
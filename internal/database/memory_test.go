@@ -0,0 +1,205 @@
+package database
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	_ CacheInterface = (*MemoryCache)(nil)
+	_ CacheInterface = NoopCache{}
+)
+
+func TestMemoryCache_EmployeeRoundTrip(t *testing.T) {
+	cache := NewMemoryCache(10, time.Minute)
+
+	employee := &models.Employee{ID: 1, FirstName: "John", Email: "john@example.com"}
+	if err := cache.SetEmployee(employee); err != nil {
+		t.Fatalf("SetEmployee failed: %v", err)
+	}
+
+	cached, err := cache.GetEmployee(1)
+	if err != nil || cached == nil || cached.Email != "john@example.com" {
+		t.Fatalf("Expected cached employee, got %+v (err %v)", cached, err)
+	}
+
+	// Mutating the returned value must not affect the cache
+	cached.Email = "changed@example.com"
+	if again, _ := cache.GetEmployee(1); again.Email != "john@example.com" {
+		t.Errorf("Cache entry was mutated through returned pointer")
+	}
+
+	cache.DeleteEmployee(1)
+	if cached, _ := cache.GetEmployee(1); cached != nil {
+		t.Errorf("Expected miss after delete, got %+v", cached)
+	}
+}
+
+func TestMemoryCache_ListAndInvalidation(t *testing.T) {
+	cache := NewMemoryCache(10, time.Minute)
+
+	employees := []models.Employee{{ID: 1}, {ID: 2}}
+	cache.SetEmployeeList("all:limit:10:offset:0", employees, 2, 0)
+	cache.SetEmployeeStats(&models.EmployeeStats{TotalEmployees: 2})
+	cache.SetEmployee(&models.Employee{ID: 1})
+
+	list, total, err := cache.GetEmployeeList("all:limit:10:offset:0")
+	if err != nil || len(list) != 2 || total != 2 {
+		t.Fatalf("Expected cached list of 2, got %d (total %d, err %v)", len(list), total, err)
+	}
+
+	cache.InvalidateEmployeeListCache()
+	if list, _, _ := cache.GetEmployeeList("all:limit:10:offset:0"); list != nil {
+		t.Errorf("Expected list cache to be invalidated")
+	}
+	if stats, _ := cache.GetEmployeeStats(); stats != nil {
+		t.Errorf("Expected stats to be invalidated with the lists")
+	}
+	if employee, _ := cache.GetEmployee(1); employee == nil {
+		t.Errorf("Expected individual employee cache to survive list invalidation")
+	}
+
+	cache.InvalidateEmployeeCache()
+	if employee, _ := cache.GetEmployee(1); employee != nil {
+		t.Errorf("Expected employee cache to be invalidated")
+	}
+}
+
+func TestMemoryCache_TTL(t *testing.T) {
+	cache := NewMemoryCache(10, time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.SetEmployee(&models.Employee{ID: 1})
+
+	now = now.Add(59 * time.Second)
+	if employee, _ := cache.GetEmployee(1); employee == nil {
+		t.Fatal("Expected entry to be live before expiry")
+	}
+
+	now = now.Add(time.Second)
+	if employee, _ := cache.GetEmployee(1); employee != nil {
+		t.Error("Expected entry to expire after TTL")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected expired entry to be removed, %d remain", cache.Len())
+	}
+
+	// The last-modified marker has no TTL
+	modified := now
+	cache.SetLastModified(modified)
+	now = now.Add(24 * time.Hour)
+	if got, _ := cache.GetLastModified(); !got.Equal(modified) {
+		t.Errorf("Expected last modified %v to persist, got %v", modified, got)
+	}
+}
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	cache := NewMemoryCache(2, time.Minute)
+
+	cache.SetEmployee(&models.Employee{ID: 1})
+	cache.SetEmployee(&models.Employee{ID: 2})
+	cache.GetEmployee(1) // 1 is now most recently used
+	cache.SetEmployee(&models.Employee{ID: 3})
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+	if employee, _ := cache.GetEmployee(2); employee != nil {
+		t.Error("Expected least recently used entry to be evicted")
+	}
+	for _, id := range []int{1, 3} {
+		if employee, _ := cache.GetEmployee(id); employee == nil {
+			t.Errorf("Expected employee %d to remain cached", id)
+		}
+	}
+}
+
+func TestNewCache_Backends(t *testing.T) {
+	tests := []struct {
+		backend string
+		check   func(CacheInterface) bool
+	}{
+		{"memory", func(c CacheInterface) bool { _, ok := c.(*MemoryCache); return ok }},
+		{"none", func(c CacheInterface) bool { _, ok := c.(NoopCache); return ok }},
+		// Nothing listens on port 1, so redis falls back to memory
+		{"redis", func(c CacheInterface) bool { _, ok := c.(*MemoryCache); return ok }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			cache, err := NewCache(&config.RedisConfig{
+				Host: "127.0.0.1", Port: 1, Backend: tt.backend, CacheExpiry: time.Minute,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !tt.check(cache) {
+				t.Errorf("Unexpected cache implementation %T", cache)
+			}
+		})
+	}
+
+	if _, err := NewCache(&config.RedisConfig{Backend: "memcached"}); err == nil {
+		t.Error("Expected error for unsupported backend")
+	}
+}
+
+func TestMemoryCache_Inspect(t *testing.T) {
+	cache := NewMemoryCache(10, time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.SetEmployee(&models.Employee{ID: 1, Email: "john@example.com"})
+	cache.SetLastModified(now)
+
+	now = now.Add(20 * time.Second)
+	entry, err := cache.Inspect("employee:1")
+	if err != nil || entry == nil {
+		t.Fatalf("Expected entry, got %+v (err %v)", entry, err)
+	}
+	if entry.TTL != 40*time.Second {
+		t.Errorf("Expected 40s remaining, got %v", entry.TTL)
+	}
+	if !strings.Contains(string(entry.Value), `"email":"john@example.com"`) {
+		t.Errorf("Expected employee JSON, got %s", entry.Value)
+	}
+
+	if entry, _ := cache.Inspect(lastModifiedKey); entry == nil || entry.TTL != 0 {
+		t.Errorf("Expected entry without expiry, got %+v", entry)
+	}
+
+	now = now.Add(time.Minute)
+	if entry, _ := cache.Inspect("employee:1"); entry != nil {
+		t.Errorf("Expected expired entry to be absent, got %+v", entry)
+	}
+	if entry, _ := cache.Inspect("employee:2"); entry != nil {
+		t.Errorf("Expected miss, got %+v", entry)
+	}
+}
+
+func TestMemoryCache_GetCacheStats(t *testing.T) {
+	cache := NewMemoryCache(10, time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.SetEmployee(&models.Employee{ID: 1})
+	cache.SetEmployee(&models.Employee{ID: 2})
+	cache.SetEmployeeList("all:limit:10:offset:0", []models.Employee{{ID: 1}}, 1, 0)
+	cache.SetEmployeeList("all:limit:10:offset:10", nil, 1, 2*time.Minute)
+	cache.SetLastModified(now)
+
+	stats, err := cache.GetCacheStats()
+	if err != nil || stats["cached_employees"] != 2 || stats["cached_employee_lists"] != 2 || stats["cache_expiry_minutes"] != 1.0 {
+		t.Fatalf("Expected 2 employees and 2 lists, got %v (err %v)", stats, err)
+	}
+
+	// Expired entries are not counted
+	now = now.Add(time.Minute)
+	if stats, _ := cache.GetCacheStats(); stats["cached_employees"] != 0 || stats["cached_employee_lists"] != 1 {
+		t.Errorf("Expected only the longer-lived list to remain, got %v", stats)
+	}
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"employee-management/internal/pii"
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ConfigurePII installs the cipher for PII_ENCRYPTION_KEY, or disables
+// encryption when no key is set. Call it at startup, before the database is
+// used. Every PII_ENCRYPTED_FIELDS entry must be an encryptable column.
+func ConfigurePII(cfg *config.DatabaseConfig) error {
+	if strings.TrimSpace(cfg.PIIEncryptionKey) == "" {
+		pii.SetCipher(nil)
+		return nil
+	}
+
+	key, err := pii.ParseKey(cfg.PIIEncryptionKey)
+	if err != nil {
+		return fmt.Errorf("invalid PII_ENCRYPTION_KEY: %w", err)
+	}
+
+	allowed := make(map[string]bool)
+	for _, column := range models.EncryptableEmployeeColumns() {
+		allowed[column] = true
+	}
+	var columns []string
+	for _, name := range strings.Split(cfg.PIIEncryptedFields, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !allowed[name] {
+			return fmt.Errorf("PII_ENCRYPTED_FIELDS: %q cannot be encrypted (allowed: %s)",
+				name, strings.Join(models.EncryptableEmployeeColumns(), ", "))
+		}
+		columns = append(columns, name)
+	}
+
+	cipher, err := pii.NewCipher(key, columns)
+	if err != nil {
+		return fmt.Errorf("invalid PII_ENCRYPTION_KEY: %w", err)
+	}
+	pii.SetCipher(cipher)
+	log.Printf("Encrypting %s at rest", strings.Join(columns, ", "))
+	return nil
+}
+
+// lookupBy narrows tx to rows whose column equals value, comparing the
+// lookup index instead while the column is encrypted
+func lookupBy(tx *gorm.DB, column, value string) *gorm.DB {
+	if index := pii.LookupIndex(column, value); index != nil {
+		return tx.Where(column+"_index = ?", *index)
+	}
+	return tx.Where(column+" = ?", value)
+}
+
+// SyncStoredPII rewrites employees and staged rows whose columns are not
+// stored the way PII_ENCRYPTED_FIELDS asks: plaintext in a designated column
+// is encrypted, ciphertext in a column no longer listed is decrypted. Rows are
+// rewritten in batches without touching updated_at. It is a no-op without a
+// key; rows encrypted earlier then fail to read until the key is restored.
+func (db *DB) SyncStoredPII() error {
+	if pii.Active() == nil {
+		return nil
+	}
+
+	var conditions []string
+	for _, column := range models.EncryptableEmployeeColumns() {
+		if pii.Encrypted(column) {
+			conditions = append(conditions, fmt.Sprintf("(%s <> '' AND %s NOT LIKE '%s%%')", column, column, pii.Prefix))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("%s LIKE '%s%%'", column, pii.Prefix))
+		}
+	}
+	stale := strings.Join(conditions, " OR ")
+
+	var employees []models.Employee
+	updated := 0
+	err := db.Where(stale).FindInBatches(&employees, 100, func(tx *gorm.DB, _ int) error {
+		for i := range employees {
+			if err := db.Omit("updated_at").Save(&employees[i]).Error; err != nil {
+				return fmt.Errorf("failed to rewrite employee %d: %w", employees[i].ID, err)
+			}
+		}
+		updated += len(employees)
+		return nil
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	var staged []models.StagedEmployee
+	err = db.Where(stale).FindInBatches(&staged, 100, func(tx *gorm.DB, _ int) error {
+		for i := range staged {
+			if err := db.Save(&staged[i]).Error; err != nil {
+				return fmt.Errorf("failed to rewrite staged employee %d: %w", staged[i].ID, err)
+			}
+		}
+		updated += len(staged)
+		return nil
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	if updated > 0 {
+		log.Printf("Rewrote personal data of %d stored rows for PII_ENCRYPTED_FIELDS", updated)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"employee-management/internal/pii"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// enablePII installs a cipher for the test and removes it afterwards
+func enablePII(t *testing.T, fields string) {
+	t.Helper()
+	t.Cleanup(func() { pii.SetCipher(nil) })
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, pii.KeySize))
+	if err := ConfigurePII(&config.DatabaseConfig{PIIEncryptionKey: key, PIIEncryptedFields: fields}); err != nil {
+		t.Fatalf("ConfigurePII failed: %v", err)
+	}
+}
+
+func TestConfigurePII(t *testing.T) {
+	t.Cleanup(func() { pii.SetCipher(nil) })
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, pii.KeySize))
+
+	if err := ConfigurePII(&config.DatabaseConfig{PIIEncryptedFields: "email"}); err != nil || pii.Active() != nil {
+		t.Errorf("Expected encryption off without a key, got %v", err)
+	}
+	if err := ConfigurePII(&config.DatabaseConfig{PIIEncryptionKey: "short", PIIEncryptedFields: "email"}); err == nil {
+		t.Error("Expected an invalid key to be rejected")
+	}
+	if err := ConfigurePII(&config.DatabaseConfig{PIIEncryptionKey: key, PIIEncryptedFields: "email,first_name"}); err == nil {
+		t.Error("Expected a column that cannot be encrypted to be rejected")
+	}
+	if err := ConfigurePII(&config.DatabaseConfig{PIIEncryptionKey: key, PIIEncryptedFields: " Phone "}); err != nil ||
+		!pii.Encrypted("phone") || pii.Encrypted("email") {
+		t.Errorf("Expected only phone to be encrypted, got %v", err)
+	}
+}
+
+func TestCreateEmployee_EncryptsPII(t *testing.T) {
+	enablePII(t, "email,phone")
+	db := openRecordingDB(t, &recordingDriver{})
+
+	insert := func(email string) (string, []interface{}) {
+		employee := models.Employee{FirstName: "John", LastName: "Doe", Email: email, Phone: "555-0100", Address: "1 Main St"}
+		stmt := db.Session(&gorm.Session{DryRun: true}).Create(&employee).Statement
+		return stmt.SQL.String(), stmt.Vars
+	}
+
+	sql, vars := insert("John@Example.com")
+	if !strings.Contains(sql, "`email_index`") || !strings.Contains(sql, "`phone_index`") {
+		t.Fatalf("Expected the lookup indexes to be written, got %s", sql)
+	}
+	var stored []string
+	for _, v := range vars {
+		if valuer, ok := v.(driver.Valuer); ok {
+			v, _ = valuer.Value()
+		}
+		if s, ok := v.(string); ok {
+			stored = append(stored, s)
+		}
+	}
+	joined := strings.Join(stored, " ")
+	if strings.Count(joined, pii.Prefix) != 2 || strings.Contains(joined, "John@Example.com") || strings.Contains(joined, "555-0100") || !strings.Contains(joined, "1 Main St") {
+		t.Errorf("Expected email and phone encrypted and other columns plaintext, got %v", stored)
+	}
+
+	// Case variants share an index, so the unique email_index rejects them
+	index := *pii.LookupIndex("email", "john@example.com")
+	_, other := insert("john@example.com")
+	_, different := insert("jane@example.com")
+	if !hasIndexVar(vars, index) || !hasIndexVar(other, index) {
+		t.Error("Expected case variants of an email to get the same lookup index")
+	}
+	if hasIndexVar(different, index) {
+		t.Error("Expected different emails to get different lookup indexes")
+	}
+}
+
+// hasIndexVar reports whether an insert binds the lookup index
+func hasIndexVar(vars []interface{}, index string) bool {
+	for _, v := range vars {
+		if value, ok := v.(*string); ok && value != nil && *value == index {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEmployeeSchema_UniqueEmailIndex(t *testing.T) {
+	stmt := &gorm.Statement{DB: openRecordingDB(t, &recordingDriver{})}
+	if err := stmt.Parse(&models.Employee{}); err != nil {
+		t.Fatalf("Failed to parse Employee: %v", err)
+	}
+	index, ok := stmt.Schema.ParseIndexes()["idx_employees_email_index"]
+	if !ok || index.Class != "UNIQUE" {
+		t.Errorf("Expected a unique index on email_index, got %+v", index)
+	}
+}
+
+func TestLookups_UseIndexWhileEncrypted(t *testing.T) {
+	repo, stub := newRecordingRepository(t)
+	repo.GetEmployeeByEmail("john@example.com")
+	repo.GetEmployeeByPhone("555-0100")
+	if !strings.Contains(stub.log[0], "email = ?") || !strings.Contains(stub.log[1], "phone = ?") {
+		t.Errorf("Expected plaintext lookups without encryption, got %v", stub.log)
+	}
+
+	enablePII(t, "email")
+	stub.log = nil
+	repo.GetEmployeeByEmail("john@example.com")
+	repo.GetEmployeeByPhone("555-0100")
+	if !strings.Contains(stub.log[0], "email_index = ?") || !strings.Contains(stub.log[1], "phone = ?") {
+		t.Errorf("Expected the email lookup through its index, got %v", stub.log)
+	}
+
+	condition, columns := searchClause()
+	if strings.Contains(condition, "email") || columns != len(searchColumns)-1 {
+		t.Errorf("Expected search to skip the encrypted email column, got %q", condition)
+	}
+}
+
+func TestSyncUniquePhoneIndex_FollowsEncryption(t *testing.T) {
+	created := func(t *testing.T) string {
+		t.Helper()
+		stub := &recordingDriver{}
+		db := &DB{DB: openRecordingDB(t, stub)}
+		if err := db.SyncUniquePhoneIndex(true); err != nil {
+			t.Fatalf("SyncUniquePhoneIndex failed: %v", err)
+		}
+		for _, statement := range stub.log {
+			if strings.HasPrefix(statement, "CREATE UNIQUE INDEX") {
+				return statement
+			}
+		}
+		t.Fatalf("Expected a unique index to be created, got %v", stub.log)
+		return ""
+	}
+
+	if got := created(t); got != "CREATE UNIQUE INDEX idx_employees_phone_unique ON employees ((NULLIF(phone, '')))" {
+		t.Errorf("Expected the functional index on plaintext phones, got %s", got)
+	}
+
+	// Ciphertext is randomized, so only the keyed lookup index can enforce uniqueness
+	enablePII(t, "phone")
+	if got := created(t); got != "CREATE UNIQUE INDEX idx_employees_phone_index_unique ON employees (phone_index)" {
+		t.Errorf("Expected the unique index on phone_index while phone is encrypted, got %s", got)
+	}
+
+	err := errors.New("Error 1062 (23000): Duplicate entry 'ab12' for key 'employees.idx_employees_phone_index_unique'")
+	if !IsDuplicatePhoneError(err) {
+		t.Error("Expected a violation of the lookup index to be a duplicate phone")
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"employee-management/internal/models"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -13,9 +16,10 @@ import (
 
 // RedisClient wraps the Redis client
 type RedisClient struct {
-	client *redis.Client
-	ctx    context.Context
-	expiry time.Duration
+	client   *redis.Client
+	ctx      context.Context
+	expiry   time.Duration
+	compress bool
 }
 
 // NewRedisClient creates a new Redis client
@@ -41,9 +45,10 @@ func NewRedisClient(cfg *config.RedisConfig) (*RedisClient, error) {
 	}
 
 	return &RedisClient{
-		client: rdb,
-		ctx:    ctx,
-		expiry: cfg.CacheExpiry, // 5 minutes as required
+		client:   rdb,
+		ctx:      ctx,
+		expiry:   cfg.CacheExpiry, // 5 minutes as required
+		compress: cfg.Compress,
 	}, nil
 }
 
@@ -54,24 +59,82 @@ type CacheInterface interface {
 	GetEmployee(id int) (*models.Employee, error)
 	DeleteEmployee(id int) error
 
-	// Employee list caching
-	SetEmployeeList(key string, employees []models.Employee, total int64) error
+	// Employee list caching; a ttl of zero uses the configured expiry
+	SetEmployeeList(key string, employees []models.Employee, total int64, ttl time.Duration) error
 	GetEmployeeList(key string) ([]models.Employee, int64, error)
 
+	// Aggregate stats caching
+	SetEmployeeStats(stats *models.EmployeeStats) error
+	GetEmployeeStats() (*models.EmployeeStats, error)
+
+	// Time of the most recent write to any employee; zero time on a miss
+	SetLastModified(t time.Time) error
+	GetLastModified() (time.Time, error)
+
 	// Cache invalidation
 	InvalidateEmployeeCache() error
 	InvalidateEmployeeListCache() error
 
+	// Inspect returns the raw entry stored under a full cache key, for
+	// diagnostics; nil when the key is absent or expired
+	Inspect(key string) (*CacheEntry, error)
+
+	// GetCacheStats reports the backend, how many employees and lists are
+	// cached and the configured expiry
+	GetCacheStats() (map[string]interface{}, error)
+
 	// Health check
 	Health() error
 	Close() error
 }
 
+// CacheEntry is a cached value as seen by Inspect
+type CacheEntry struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+	TTL   time.Duration   `json:"-"` // Remaining lifetime; zero when the entry never expires
+}
+
+// InspectableCacheKey reports whether key is an employee or list cache key,
+// the only entries the diagnostics endpoint may read
+func InspectableCacheKey(key string) bool {
+	return strings.HasPrefix(key, "employee:") || strings.HasPrefix(key, "employee_list:")
+}
+
+// Inspect reads a cache entry and its remaining TTL
+func (r *RedisClient) Inspect(key string) (*CacheEntry, error) {
+	pipe := r.client.Pipeline()
+	get := pipe.Get(r.ctx, key)
+	ttl := pipe.TTL(r.ctx, key)
+	if _, err := pipe.Exec(r.ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to inspect cache key %s: %w", key, err)
+	}
+
+	data, err := get.Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to inspect cache key %s: %w", key, err)
+	}
+
+	var value json.RawMessage
+	if err := unmarshalPayload(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode cache key %s: %w", key, err)
+	}
+
+	entry := &CacheEntry{Key: key, Value: value}
+	if remaining := ttl.Val(); remaining > 0 {
+		entry.TTL = remaining
+	}
+	return entry, nil
+}
+
 // SetEmployee caches a single employee
 func (r *RedisClient) SetEmployee(employee *models.Employee) error {
 	key := fmt.Sprintf("employee:%d", employee.ID)
 
-	data, err := json.Marshal(employee)
+	data, err := r.marshal(employee)
 	if err != nil {
 		return fmt.Errorf("failed to marshal employee: %w", err)
 	}
@@ -97,7 +160,7 @@ func (r *RedisClient) GetEmployee(id int) (*models.Employee, error) {
 	}
 
 	var employee models.Employee
-	err = json.Unmarshal([]byte(data), &employee)
+	err = unmarshalPayload([]byte(data), &employee)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal cached employee: %w", err)
 	}
@@ -119,7 +182,7 @@ type EmployeeListData struct {
 }
 
 // SetEmployeeList caches employee list with pagination info
-func (r *RedisClient) SetEmployeeList(key string, employees []models.Employee, total int64) error {
+func (r *RedisClient) SetEmployeeList(key string, employees []models.Employee, total int64, ttl time.Duration) error {
 	cacheKey := fmt.Sprintf("employee_list:%s", key)
 
 	listData := EmployeeListData{
@@ -128,12 +191,15 @@ func (r *RedisClient) SetEmployeeList(key string, employees []models.Employee, t
 		CachedAt:  time.Now(),
 	}
 
-	data, err := json.Marshal(listData)
+	data, err := r.marshal(listData)
 	if err != nil {
 		return fmt.Errorf("failed to marshal employee list: %w", err)
 	}
 
-	err = r.client.Set(r.ctx, cacheKey, data, r.expiry).Err()
+	if ttl <= 0 {
+		ttl = r.expiry
+	}
+	err = r.client.Set(r.ctx, cacheKey, data, ttl).Err()
 	if err != nil {
 		return fmt.Errorf("failed to cache employee list: %w", err)
 	}
@@ -154,7 +220,7 @@ func (r *RedisClient) GetEmployeeList(key string) ([]models.Employee, int64, err
 	}
 
 	var listData EmployeeListData
-	err = json.Unmarshal([]byte(data), &listData)
+	err = unmarshalPayload([]byte(data), &listData)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to unmarshal cached employee list: %w", err)
 	}
@@ -162,6 +228,111 @@ func (r *RedisClient) GetEmployeeList(key string) ([]models.Employee, int64, err
 	return listData.Employees, listData.Total, nil
 }
 
+// statsCacheKey lives under the list prefix so any write that invalidates
+// list caches also drops the cached stats
+const statsCacheKey = "employee_list:stats"
+
+// SetEmployeeStats caches aggregate employee stats
+func (r *RedisClient) SetEmployeeStats(stats *models.EmployeeStats) error {
+	data, err := r.marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal employee stats: %w", err)
+	}
+
+	err = r.client.Set(r.ctx, statsCacheKey, data, r.expiry).Err()
+	if err != nil {
+		return fmt.Errorf("failed to cache employee stats: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmployeeStats retrieves cached aggregate employee stats
+func (r *RedisClient) GetEmployeeStats() (*models.EmployeeStats, error) {
+	data, err := r.client.Get(r.ctx, statsCacheKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get cached employee stats: %w", err)
+	}
+
+	var stats models.EmployeeStats
+	err = unmarshalPayload([]byte(data), &stats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached employee stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// lastModifiedKey sits outside both invalidation prefixes and never expires,
+// since it is updated rather than invalidated on writes
+const lastModifiedKey = "employees:last_modified"
+
+// SetLastModified records the time of the latest employee write
+func (r *RedisClient) SetLastModified(t time.Time) error {
+	err := r.client.Set(r.ctx, lastModifiedKey, t.UTC().Format(time.RFC3339Nano), 0).Err()
+	if err != nil {
+		return fmt.Errorf("failed to cache last modified time: %w", err)
+	}
+	return nil
+}
+
+// GetLastModified returns the time of the latest employee write
+func (r *RedisClient) GetLastModified() (time.Time, error) {
+	data, err := r.client.Get(r.ctx, lastModifiedKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return time.Time{}, nil // Cache miss
+		}
+		return time.Time{}, fmt.Errorf("failed to get last modified time: %w", err)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, data)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse last modified time: %w", err)
+	}
+	return t, nil
+}
+
+// recentWriteKey and recentWriteKey:<id> mark writes within the replica lag
+// window for every instance; they sit outside both invalidation prefixes
+const recentWriteKey = "employees:recent_write"
+
+var _ WriteMarker = (*RedisClient)(nil)
+
+// MarkWrites records a write to the given employees, or to unknown ones when
+// ids is empty, for window
+func (r *RedisClient) MarkWrites(window time.Duration, ids ...int) error {
+	if window <= 0 {
+		return nil
+	}
+	pipe := r.client.Pipeline()
+	pipe.Set(r.ctx, recentWriteKey, 1, window)
+	for _, id := range ids {
+		pipe.Set(r.ctx, fmt.Sprintf("%s:%d", recentWriteKey, id), 1, window)
+	}
+	if _, err := pipe.Exec(r.ctx); err != nil {
+		return fmt.Errorf("failed to mark recent writes: %w", err)
+	}
+	return nil
+}
+
+// RecentWrite reports whether the employee, or any employee for id 0, was
+// marked written within the window
+func (r *RedisClient) RecentWrite(id int) (bool, error) {
+	key := recentWriteKey
+	if id > 0 {
+		key = fmt.Sprintf("%s:%d", recentWriteKey, id)
+	}
+	count, err := r.client.Exists(r.ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check recent writes: %w", err)
+	}
+	return count > 0, nil
+}
+
 // InvalidateEmployeeCache removes all individual employee caches
 func (r *RedisClient) InvalidateEmployeeCache() error {
 	pattern := "employee:*"
@@ -198,12 +369,64 @@ func (r *RedisClient) InvalidateEmployeeListCache() error {
 	return nil
 }
 
-// GenerateListCacheKey creates a cache key for employee lists based on parameters
-func GenerateListCacheKey(limit, offset int, searchQuery string) string {
+// GenerateListCacheKey creates a cache key for employee lists based on parameters.
+// A TTL override is part of the key so requests asking for different lifetimes
+// never serve or extend each other's entries. Excluded IDs are listed as
+// given, so callers pass them sorted for equal lists to share an entry.
+func GenerateListCacheKey(limit, offset int, searchQuery string, excludeIDs []int, sort models.SortOptions, ttl time.Duration) string {
+	key := fmt.Sprintf("all:limit:%d:offset:%d", limit, offset)
 	if searchQuery != "" {
-		return fmt.Sprintf("search:%s:limit:%d:offset:%d", searchQuery, limit, offset)
+		key = fmt.Sprintf("search:%s:limit:%d:offset:%d", searchQuery, limit, offset)
+	}
+	if len(excludeIDs) > 0 {
+		ids := make([]string, len(excludeIDs))
+		for i, id := range excludeIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		key += ":exclude:" + strings.Join(ids, ",")
 	}
-	return fmt.Sprintf("all:limit:%d:offset:%d", limit, offset)
+	if sort != (models.SortOptions{}) {
+		direction := "asc"
+		if sort.Desc {
+			direction = "desc"
+		}
+		key += fmt.Sprintf(":sort:%s:%s", sort.Field, direction)
+		if sort.CaseInsensitive {
+			key += ":ci"
+		}
+	}
+	if ttl > 0 {
+		key += fmt.Sprintf(":ttl:%d", int(ttl.Seconds()))
+	}
+	return key
+}
+
+// GenerateFilterCacheKey creates a cache key for a filtered employee list.
+// The filters are query-encoded in column order, so equal filters share an
+// entry and no value can pass for another filter or the list suffix.
+// Excluded IDs are passed sorted, as for GenerateListCacheKey.
+func GenerateFilterCacheKey(filters map[string]string, limit, offset int, searchQuery string, excludeIDs []int, order models.SortOptions, ttl time.Duration) string {
+	conditions := make(url.Values, len(filters))
+	for column, value := range filters {
+		conditions.Set(column, value)
+	}
+	return "filter:" + conditions.Encode() + ":" + GenerateListCacheKey(limit, offset, searchQuery, excludeIDs, order, ttl)
+}
+
+// GenerateCursorCacheKey creates a cache key for a keyset page of employees,
+// kept apart from offset pages so the two never collide
+func GenerateCursorCacheKey(cursorID, limit int, ttl time.Duration) string {
+	key := fmt.Sprintf("cursor:%d:limit:%d", cursorID, limit)
+	if ttl > 0 {
+		key += fmt.Sprintf(":ttl:%d", int(ttl.Seconds()))
+	}
+	return key
+}
+
+// GenerateNextCacheKey creates the list cache key for the employee following
+// afterID. It lives with the lists so every write invalidates it.
+func GenerateNextCacheKey(afterID int) string {
+	return fmt.Sprintf("next:after:%d", afterID)
 }
 
 // Health checks Redis connectivity
@@ -237,6 +460,7 @@ func (r *RedisClient) GetCacheStats() (map[string]interface{}, error) {
 	}
 
 	stats := map[string]interface{}{
+		"backend":               CacheBackendRedis,
 		"redis_info":            info,
 		"cached_employees":      len(employeeKeys),
 		"cached_employee_lists": len(listKeys),
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WriteMarker shares recent writes between instances. Without it only the
+// instance that wrote keeps its reads off the lagging replica; another one
+// could read a stale page and cache it for everyone.
+type WriteMarker interface {
+	// MarkWrites records a write to the given employees (none for bulk
+	// writes whose IDs are unknown) for window
+	MarkWrites(window time.Duration, ids ...int) error
+	// RecentWrite reports whether the employee, or any employee for id 0,
+	// was marked within the window
+	RecentWrite(id int) (bool, error)
+}
+
+// recentWrites remembers what was written within the replica lag window, so
+// reads that could observe a stale replica are sent to the primary instead
+type recentWrites struct {
+	mu     sync.Mutex
+	window time.Duration
+	now    func() time.Time
+	last   time.Time         // Most recent write of any kind
+	ids    map[int]time.Time // Most recent write per employee
+	shared WriteMarker       // Writes of other instances, when set
+}
+
+func newRecentWrites(window time.Duration) *recentWrites {
+	return &recentWrites{window: window, now: time.Now, ids: make(map[int]time.Time)}
+}
+
+// record notes a write touching the given employees (none for bulk writes
+// whose IDs are unknown), locally and for other instances. Expired entries
+// are dropped on the way.
+func (w *recentWrites) record(ids ...int) {
+	if w.shared != nil {
+		if err := w.shared.MarkWrites(w.window, ids...); err != nil {
+			log.Printf("Warning: Failed to share recent writes with other instances: %v", err)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	w.last = now
+	for id, at := range w.ids {
+		if now.Sub(at) >= w.window {
+			delete(w.ids, id)
+		}
+	}
+	for _, id := range ids {
+		w.ids[id] = now
+	}
+}
+
+// any reports whether anything was written within the window
+func (w *recentWrites) any() bool {
+	w.mu.Lock()
+	local := !w.last.IsZero() && w.now().Sub(w.last) < w.window
+	w.mu.Unlock()
+	return local || w.sharedWrite(0)
+}
+
+// employee reports whether the employee was written within the window
+func (w *recentWrites) employee(id int) bool {
+	w.mu.Lock()
+	at, ok := w.ids[id]
+	local := ok && w.now().Sub(at) < w.window
+	w.mu.Unlock()
+	return local || w.sharedWrite(id)
+}
+
+// sharedWrite asks the shared marker about writes of other instances. When
+// it cannot answer, the read goes to the primary, which is never stale.
+func (w *recentWrites) sharedWrite(id int) bool {
+	if w.shared == nil {
+		return false
+	}
+	written, err := w.shared.RecentWrite(id)
+	if err != nil {
+		log.Printf("Warning: Failed to check recent writes of other instances: %v", err)
+		return true
+	}
+	return written
+}
+
+// ShareWrites makes the repository mark its writes in marker and honour the
+// writes other instances marked there, so no instance reads the replica
+// within the lag window of any write. It only matters with a read replica.
+func (r *EmployeeRepository) ShareWrites(marker WriteMarker) {
+	if r.db.Replica == nil || r.db.ReplicaLagWindow <= 0 {
+		return
+	}
+	r.writes.shared = marker
+}
+
+// reader returns the connection for a read-only query that may span many
+// employees: the replica, unless none is configured or anything was written
+// within the lag window. Lists and counts are cached, so serving them from a
+// replica that has not caught up would keep stale pages around.
+func (r *EmployeeRepository) reader() *gorm.DB {
+	if r.db.Replica == nil || r.writes.any() {
+		return r.db.DB
+	}
+	return r.db.Replica
+}
+
+// readerFor returns the connection for reading one employee: the replica,
+// unless none is configured or that employee was written within the lag window
+func (r *EmployeeRepository) readerFor(id int) *gorm.DB {
+	if r.db.Replica == nil || r.writes.employee(id) {
+		return r.db.DB
+	}
+	return r.db.Replica
+}
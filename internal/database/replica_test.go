@@ -0,0 +1,130 @@
+package database
+
+import (
+	"employee-management/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newReplicatedRepository wires a repository to two recording drivers, one
+// standing in for the primary and one for the replica
+func newReplicatedRepository(t *testing.T) (*EmployeeRepository, *recordingDriver, *recordingDriver, *time.Time) {
+	t.Helper()
+
+	primary, replica := &recordingDriver{}, &recordingDriver{}
+	repo := NewEmployeeRepository(&DB{
+		DB:               openRecordingDB(t, primary),
+		Replica:          openRecordingDB(t, replica),
+		ReplicaLagWindow: 5 * time.Second,
+	})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.writes.now = func() time.Time { return now }
+	return repo, primary, replica, &now
+}
+
+// served reports which driver ran the statements since the last call and
+// clears both logs
+func served(primary, replica *recordingDriver) string {
+	defer func() { primary.log, replica.log = nil, nil }()
+	switch {
+	case len(primary.log) > 0 && len(replica.log) == 0:
+		return "primary"
+	case len(replica.log) > 0 && len(primary.log) == 0:
+		return "replica"
+	default:
+		return "primary: " + strings.Join(primary.log, "; ") + " replica: " + strings.Join(replica.log, "; ")
+	}
+}
+
+func TestReplicaRouting(t *testing.T) {
+	repo, primary, replica, now := newReplicatedRepository(t)
+
+	steps := []struct {
+		name string
+		run  func()
+		want string
+	}{
+		{"get by ID reads the replica", func() { repo.GetEmployeeByID(1) }, "replica"},
+		{"list reads the replica", func() { repo.GetAllEmployees(10, 0, models.SortOptions{}) }, "replica"},
+		{"search reads the replica", func() { repo.SearchEmployees("acme", 10, 0, models.SortOptions{}) }, "replica"},
+		{"count reads the replica", func() { repo.CountEmployees("") }, "replica"},
+		{"email lookup guards writes on the primary", func() { repo.GetEmployeeByEmail("a@b.c") }, "primary"},
+		{"update writes the primary", func() { repo.UpdateEmployee(&models.Employee{ID: 1, FirstName: "John"}) }, "primary"},
+		{"just-updated employee reads the primary", func() { repo.GetEmployeeByID(1) }, "primary"},
+		{"other employees still read the replica", func() { repo.GetEmployeeByID(2) }, "replica"},
+		{"lists read the primary after a write", func() { repo.GetAllEmployees(10, 0, models.SortOptions{}) }, "primary"},
+		{"replica again once the lag window passes", func() {
+			*now = now.Add(5 * time.Second)
+			repo.GetEmployeeByID(1)
+			repo.CountEmployees("acme")
+		}, "replica"},
+	}
+
+	for _, step := range steps {
+		step.run()
+		if got := served(primary, replica); got != step.want {
+			t.Errorf("%s: expected %s, got %s", step.name, step.want, got)
+		}
+	}
+}
+
+// sharedMarker is a WriteMarker shared by several repositories, standing in
+// for Redis; marks never expire
+type sharedMarker struct {
+	marked map[int]bool
+	err    error
+}
+
+func (m *sharedMarker) MarkWrites(_ time.Duration, ids ...int) error {
+	m.marked[0] = true
+	for _, id := range ids {
+		m.marked[id] = true
+	}
+	return m.err
+}
+
+func (m *sharedMarker) RecentWrite(id int) (bool, error) {
+	return m.marked[id], m.err
+}
+
+func TestReplicaRouting_SharedWrites(t *testing.T) {
+	writer, _, _, _ := newReplicatedRepository(t)
+	reader, primary, replica, _ := newReplicatedRepository(t)
+	marker := &sharedMarker{marked: make(map[int]bool)}
+	writer.ShareWrites(marker)
+	reader.ShareWrites(marker)
+
+	// Another instance's write keeps this one off the replica too
+	writer.UpdateEmployee(&models.Employee{ID: 1, FirstName: "John"})
+	reader.GetEmployeeByID(1)
+	if got := served(primary, replica); got != "primary" {
+		t.Errorf("Expected the employee another instance wrote to read the primary, got %s", got)
+	}
+	reader.GetAllEmployees(10, 0, models.SortOptions{})
+	if got := served(primary, replica); got != "primary" {
+		t.Errorf("Expected lists to read the primary after another instance wrote, got %s", got)
+	}
+	reader.GetEmployeeByID(2)
+	if got := served(primary, replica); got != "replica" {
+		t.Errorf("Expected other employees to read the replica, got %s", got)
+	}
+
+	// A marker that cannot answer sends reads to the primary
+	marker.err = errors.New("redis unreachable")
+	reader.GetEmployeeByID(2)
+	if got := served(primary, replica); got != "primary" {
+		t.Errorf("Expected the primary while the marker is unreachable, got %s", got)
+	}
+}
+
+func TestReplicaRouting_NoReplica(t *testing.T) {
+	repo, stub := newRecordingRepository(t)
+
+	repo.GetEmployeeByID(1)
+	repo.GetAllEmployees(10, 0, models.SortOptions{})
+	if len(stub.log) != 2 {
+		t.Errorf("Expected every read on the only database, got %v", stub.log)
+	}
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// issue creates a certificate signed by parent (self-signed when parent is nil)
+func issue(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return certificate, key
+}
+
+func TestVerifyChain_IgnoresHostName(t *testing.T) {
+	ca, caKey := issue(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	// The server certificate names another host than the one dialled
+	leaf, _ := issue(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "mysql.internal"},
+		DNSNames:     []string{"mysql.internal"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+
+	trusted := x509.NewCertPool()
+	trusted.AddCert(ca)
+	if err := verifyChain([]*x509.Certificate{leaf}, trusted); err != nil {
+		t.Errorf("Expected a certificate from a trusted CA to pass whatever its name, got %v", err)
+	}
+	if err := verifyChain([]*x509.Certificate{leaf}, x509.NewCertPool()); err == nil {
+		t.Error("Expected a certificate from an untrusted CA to be rejected")
+	}
+	if err := verifyChain(nil, trusted); err == nil {
+		t.Error("Expected a missing certificate to be rejected")
+	}
+}
@@ -0,0 +1,65 @@
+// Package events publishes domain events about employee writes so other
+// systems can react to them. Publishing is behind EVENTS_ENABLED; brokers
+// without a built-in publisher (Kafka, NATS) can be reached through their HTTP
+// gateways or by implementing Publisher.
+package events
+
+import (
+	"context"
+	"employee-management/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type names what happened to an employee
+type Type string
+
+const (
+	EmployeeCreated   Type = "EmployeeCreated"
+	EmployeeUpdated   Type = "EmployeeUpdated"
+	EmployeeDeleted   Type = "EmployeeDeleted"
+	EmployeesImported Type = "EmployeesImported"
+)
+
+// Actor identifies who caused a write. There are no user accounts, so ID is
+// whatever the caller sent in EVENTS_ACTOR_HEADER and may be empty.
+type Actor struct {
+	ID        string `json:"id,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Event is one domain event. Employee is set for single-record events and
+// Import for EmployeesImported.
+type Event struct {
+	ID         string           `json:"id"` // Unique per event, for consumers to deduplicate retries
+	Type       Type             `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Actor      Actor            `json:"actor"`
+	Employee   *models.Employee `json:"employee,omitempty"`
+	Changed    []string         `json:"changed,omitempty"` // Columns an update changed
+	Import     *ImportSummary   `json:"import,omitempty"`
+}
+
+// ImportSummary describes a bulk insert. Imported rows are not listed one by
+// one; consumers that need them can read the employees created since the event.
+type ImportSummary struct {
+	JobID    string `json:"job_id,omitempty"`   // Set for Excel imports
+	BatchID  string `json:"batch_id,omitempty"` // Set for promoted staging batches
+	Inserted int    `json:"inserted"`
+	Skipped  int    `json:"skipped"`
+	Removed  int64  `json:"removed,omitempty"` // Employees deleted by a replace import
+	Updated  int    `json:"updated,omitempty"` // Employees changed by a status column import
+	Deleted  int    `json:"deleted,omitempty"` // Employees deleted by a status column import
+}
+
+// New returns an event of the given type stamped with a fresh ID and the current time
+func New(eventType Type, actor Actor) Event {
+	return Event{ID: uuid.New().String(), Type: eventType, OccurredAt: time.Now().UTC(), Actor: actor}
+}
+
+// Publisher delivers events to a broker. Publish must be safe for concurrent use.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/pii"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Broker names accepted in EVENTS_BROKER
+const (
+	BrokerLog  = "log"
+	BrokerHTTP = "http"
+)
+
+// NewPublisher builds the publisher configured in cfg: a NoopPublisher while
+// events are disabled, otherwise the one for EVENTS_BROKER. An unusable
+// setting is logged and falls back to the log broker, so events are still
+// visible somewhere.
+func NewPublisher(cfg *config.EventsConfig) Publisher {
+	if !cfg.Enabled {
+		return NoopPublisher{}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Broker)) {
+	case "", BrokerLog:
+		return LogPublisher{}
+	case BrokerHTTP:
+		if cfg.URL == "" {
+			log.Printf("Warning: EVENTS_BROKER=http needs EVENTS_URL; logging events instead")
+			return LogPublisher{}
+		}
+		return NewHTTPPublisher(cfg.URL)
+	default:
+		log.Printf("Warning: unknown EVENTS_BROKER %q; logging events instead", cfg.Broker)
+		return LogPublisher{}
+	}
+}
+
+// Pinger is implemented by publishers that talk to a remote broker, so the
+// readiness probe can check the broker is reachable
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// NoopPublisher drops every event
+type NoopPublisher struct{}
+
+// Publish does nothing
+func (NoopPublisher) Publish(context.Context, Event) error {
+	return nil
+}
+
+// LogPublisher writes each event to the application log as one JSON line,
+// for development and for shipping events with the logs
+type LogPublisher struct{}
+
+// Publish logs the event
+func (LogPublisher) Publish(_ context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	log.Printf("Event %s: %s", event.Type, pii.Redact(string(payload)))
+	return nil
+}
+
+// HTTPPublisher POSTs each event as JSON to a URL, such as a Kafka REST proxy
+// or a NATS HTTP gateway. The event type is also sent in the X-Event-Type
+// header for routing without parsing the body.
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPublisher returns a publisher posting to url. Timeouts come from the
+// context passed to Publish.
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{url: url, client: &http.Client{}}
+}
+
+// Publish posts the event; any response other than 2xx is an error
+func (p *HTTPPublisher) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build event request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Event-Type", string(event.Type))
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to post event: %w", err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("event endpoint answered %s", response.Status)
+	}
+	return nil
+}
+
+// Ping sends a HEAD request to the event URL. Any HTTP answer counts as
+// reachable, since the endpoint may only accept POST.
+func (p *HTTPPublisher) Ping(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, p.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build ping request: %w", err)
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("event endpoint unreachable: %w", err)
+	}
+	response.Body.Close()
+	return nil
+}
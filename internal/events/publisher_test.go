@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPPublisher(t *testing.T) {
+	var received Event
+	var eventType, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		eventType, contentType = r.Header.Get("X-Event-Type"), r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("event body is not JSON: %v", err)
+		}
+		if received.Employee != nil && received.Employee.ID == 13 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	publisher := NewHTTPPublisher(server.URL)
+	event := New(EmployeeCreated, Actor{ID: "alice", IP: "10.0.0.1", RequestID: "req-1"})
+	event.Employee = &models.Employee{ID: 7, FirstName: "John", LastName: "Doe", Email: "john@example.com"}
+
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if eventType != "EmployeeCreated" || contentType != "application/json" {
+		t.Errorf("Unexpected headers: X-Event-Type %q, Content-Type %q", eventType, contentType)
+	}
+	if received.ID != event.ID || received.Type != EmployeeCreated || received.Actor.ID != "alice" || received.Employee.Email != "john@example.com" {
+		t.Errorf("Expected the event to arrive intact, got %+v", received)
+	}
+
+	event.Employee.ID = 13
+	if err := publisher.Publish(context.Background(), event); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected a non-2xx answer to fail, got %v", err)
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.EventsConfig
+		want Publisher
+	}{
+		{"disabled", config.EventsConfig{Enabled: false, Broker: BrokerHTTP, URL: "http://broker"}, NoopPublisher{}},
+		{"log broker", config.EventsConfig{Enabled: true, Broker: BrokerLog}, LogPublisher{}},
+		{"http without a URL falls back to log", config.EventsConfig{Enabled: true, Broker: BrokerHTTP}, LogPublisher{}},
+		{"unknown broker falls back to log", config.EventsConfig{Enabled: true, Broker: "kafka"}, LogPublisher{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPublisher(&tt.cfg); got != tt.want {
+				t.Errorf("Expected %T, got %T", tt.want, got)
+			}
+		})
+	}
+
+	cfg := config.EventsConfig{Enabled: true, Broker: "HTTP", URL: "http://broker"}
+	if _, ok := NewPublisher(&cfg).(*HTTPPublisher); !ok {
+		t.Error("Expected the http broker to build an HTTPPublisher")
+	}
+}
@@ -1,10 +1,24 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"employee-management/internal/middleware"
 	"employee-management/internal/models"
+	"employee-management/internal/pii"
 	"employee-management/internal/services"
+	"errors"
+	"fmt"
+	"log"
+	"net"
 	"net/http"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,19 +27,86 @@ import (
 type EmployeeHandler struct {
 	employeeService *services.EmployeeService
 	excelService    *services.ExcelService
+	config          *config.Config
+	domainVerifier  *services.DomainVerifier
+	readiness       *services.Readiness
 }
 
 // NewEmployeeHandler creates a new employee handler
-func NewEmployeeHandler(employeeService *services.EmployeeService, excelService *services.ExcelService) *EmployeeHandler {
+func NewEmployeeHandler(employeeService *services.EmployeeService, excelService *services.ExcelService, cfg *config.Config) *EmployeeHandler {
 	return &EmployeeHandler{
 		employeeService: employeeService,
 		excelService:    excelService,
+		config:          cfg,
+		domainVerifier: services.NewDomainVerifier(net.DefaultResolver, cfg.Validation.VerifyEmailDomain,
+			cfg.Validation.DNSTimeout, cfg.Validation.DNSCacheTTL),
+		readiness: services.NewReadiness(&cfg.Readiness),
 	}
 }
 
+// SetReadiness replaces the readiness probe, which has no dependencies by
+// default. Set it at startup, before serving requests.
+func (h *EmployeeHandler) SetReadiness(readiness *services.Readiness) {
+	h.readiness = readiness
+}
+
+// pageBase returns the configured number of the first page (0 or 1)
+func (h *EmployeeHandler) pageBase() int {
+	if h.config.Server.PageBase == 0 {
+		return 0
+	}
+	return 1
+}
+
+// importMode reads the mode and replace parameters of an import request,
+// writing the error response and returning false when they are invalid.
+// replace=true is refused with 403 while IMPORT_ALLOW_REPLACE is off.
+func (h *EmployeeHandler) importMode(c *gin.Context) (services.ImportMode, bool) {
+	mode, err := services.ParseImportMode(c.Query("mode"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid import mode",
+			Details: []models.ValidationError{{Field: "mode", Message: err.Error()}},
+		})
+		return "", false
+	}
+
+	mode, err = h.excelService.ParseReplaceMode(mode, c.Query("replace"), c.Query("confirm"))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrReplaceNotAllowed) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, models.ErrorResponse{
+			Error:   "Invalid import mode",
+			Details: []models.ValidationError{{Field: "replace", Message: err.Error()}},
+		})
+		return "", false
+	}
+	return mode, true
+}
+
 // UploadExcel handles Excel file upload and async processing
 // POST /api/employees/upload
+// mode=staging loads the valid rows into a staging batch instead of employees;
+// the finished job reports the batch_id to review and promote.
+// replace=true&confirm=replace swaps every employee for the file's rows in one
+// transaction; it needs IMPORT_ALLOW_REPLACE and a file without invalid rows.
 func (h *EmployeeHandler) UploadExcel(c *gin.Context) {
+	mode, ok := h.importMode(c)
+	if !ok {
+		return
+	}
+
+	// Refuse before the multipart form is read into memory
+	if err := h.excelService.CheckMemory(); err != nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "Failed to start Excel processing",
+			Details: []models.ValidationError{{Field: "file", Message: err.Error()}},
+		})
+		return
+	}
+
 	// Parse multipart form
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -39,9 +120,113 @@ func (h *EmployeeHandler) UploadExcel(c *gin.Context) {
 	}
 
 	// Start async processing
-	jobID, err := h.excelService.StartAsyncExcelProcessing(file)
+	jobID, err := h.excelService.StartAsyncExcelProcessing(file, mode, h.actor(c))
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, services.ErrTooManyUploads):
+			status = http.StatusTooManyRequests
+		case errors.Is(err, services.ErrMemoryPressure):
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, models.ErrorResponse{
+			Error: "Failed to start Excel processing",
+			Details: []models.ValidationError{
+				{Field: "file", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success":    true,
+		"message":    "Excel file processing started",
+		"job_id":     jobID,
+		"status_url": h.config.Server.RoutePrefix + "/api/jobs/" + jobID,
+	})
+}
+
+// initUploadRequest is the body of a chunked upload init request
+type initUploadRequest struct {
+	Filename  string `json:"filename" binding:"required"`
+	TotalSize int64  `json:"total_size" binding:"required"`
+}
+
+// InitChunkedUpload starts a chunked upload for clients on unreliable connections
+// POST /api/employees/upload/init
+func (h *EmployeeHandler) InitChunkedUpload(c *gin.Context) {
+	var request initUploadRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Invalid request data",
+			Details: []models.ValidationError{
+				{Field: "body", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	info, err := h.excelService.InitChunkedUpload(request.Filename, request.TotalSize)
 	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrMemoryPressure) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, models.ErrorResponse{
+			Error: "Failed to start upload",
+			Details: []models.ValidationError{
+				{Field: "file", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    info,
+	})
+}
+
+// PutUploadChunk stores one chunk of a chunked upload; the body is the raw bytes
+// PUT /api/employees/upload/:upload_id/chunk/:n
+func (h *EmployeeHandler) PutUploadChunk(c *gin.Context) {
+	index, err := strconv.Atoi(c.Param("n"))
+	if err != nil || index < 0 {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Invalid chunk number",
+		})
+		return
+	}
+
+	info, err := h.excelService.PutUploadChunk(c.Param("upload_id"), index, c.Request.Body)
+	if err != nil {
+		c.JSON(chunkedUploadStatus(err), models.ErrorResponse{
+			Error: "Failed to store chunk",
+			Details: []models.ValidationError{
+				{Field: "chunk", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    info,
+	})
+}
+
+// CompleteChunkedUpload reassembles a chunked upload and starts processing it
+// POST /api/employees/upload/:upload_id/complete
+// Accepts the same mode and replace parameters as UploadExcel.
+func (h *EmployeeHandler) CompleteChunkedUpload(c *gin.Context) {
+	mode, ok := h.importMode(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := h.excelService.CompleteChunkedUpload(c.Param("upload_id"), mode, h.actor(c))
+	if err != nil {
+		c.JSON(chunkedUploadStatus(err), models.ErrorResponse{
 			Error: "Failed to start Excel processing",
 			Details: []models.ValidationError{
 				{Field: "file", Message: err.Error()},
@@ -54,10 +239,28 @@ func (h *EmployeeHandler) UploadExcel(c *gin.Context) {
 		"success":    true,
 		"message":    "Excel file processing started",
 		"job_id":     jobID,
-		"status_url": "/api/jobs/" + jobID,
+		"status_url": h.config.Server.RoutePrefix + "/api/jobs/" + jobID,
 	})
 }
 
+// chunkedUploadStatus maps chunked upload errors to HTTP status codes
+func chunkedUploadStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUploadNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrChunkOutOfOrder), errors.Is(err, services.ErrUploadIncomplete):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrUploadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrTooManyUploads):
+		return http.StatusTooManyRequests
+	case errors.Is(err, services.ErrMemoryPressure):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // ValidateExcel validates Excel file structure without processing
 // POST /api/employees/validate-excel
 func (h *EmployeeHandler) ValidateExcel(c *gin.Context) {
@@ -83,8 +286,92 @@ func (h *EmployeeHandler) ValidateExcel(c *gin.Context) {
 	})
 }
 
-// GetJobStatus retrieves the status of an async job
-// GET /api/jobs/:id
+// xlsxContentType is the media type of the .xlsx files the API returns
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// AnnotateExcel returns the uploaded workbook with a validation result column
+// POST /api/employees/annotate
+func (h *EmployeeHandler) AnnotateExcel(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "No file uploaded",
+		})
+		return
+	}
+
+	// Build the file in memory first so failures can still be reported as JSON
+	var annotated bytes.Buffer
+	if err := h.excelService.AnnotateExcelFile(file, &annotated); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	filename := strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename)) + "_annotated.xlsx"
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, xlsxContentType, annotated.Bytes())
+}
+
+// Behaviors for a list request whose search parameter is present but empty
+const (
+	EmptySearchAll  = "all"
+	EmptySearchNone = "none"
+)
+
+// Policies for the HTTP status of a finished import job
+const (
+	StatusPolicyMultiStatus = "multi-status"
+	StatusPolicyAlways200   = "always-200"
+)
+
+// importStatusCode picks the HTTP status for a job under the given policy.
+// With multi-status, a finished import returns 200 when every row was
+// inserted, 207 when some rows were inserted and others were invalid or
+// skipped, and 400 when nothing could be inserted. For files with a status
+// column every applied row counts, not only inserts. Jobs still in progress
+// are always 200.
+func importStatusCode(job *services.JobResult, policy string) int {
+	if policy == StatusPolicyAlways200 {
+		return http.StatusOK
+	}
+
+	switch job.Status {
+	case services.JobStatusFailed:
+		return http.StatusBadRequest
+	case services.JobStatusCompleted:
+		result := job.Result
+		if result == nil || result.TotalRecords == 0 {
+			return http.StatusOK
+		}
+		applied := result.InsertedRecords
+		if actions := result.Actions; actions != nil {
+			// A sync succeeds for rows it updated, deleted or found in
+			// the wanted state already, not only for inserts
+			applied = result.ValidRecords - actions.Conflicts
+		}
+		if applied == 0 {
+			return http.StatusBadRequest
+		}
+		if result.InvalidRecords > 0 || result.SkippedRecords > 0 {
+			return http.StatusMultiStatus
+		}
+		return http.StatusOK
+	default:
+		return http.StatusOK
+	}
+}
+
+// importSucceeded reports whether a job has not failed, independent of the
+// status policy: a running job or one that applied at least one row counts.
+func importSucceeded(job *services.JobResult) bool {
+	return importStatusCode(job, StatusPolicyMultiStatus) != http.StatusBadRequest
+}
+
+// GetJobStatus retrieves the status of an async job. The response status
+// reflects the import outcome according to IMPORT_STATUS_POLICY.
+// GET /api/jobs/:id?status_policy=always-200
 func (h *EmployeeHandler) GetJobStatus(c *gin.Context) {
 	jobID := c.Param("id")
 
@@ -99,79 +386,672 @@ func (h *EmployeeHandler) GetJobStatus(c *gin.Context) {
 		return
 	}
 
+	// Clients that only understand 200 can opt out per request
+	policy := c.DefaultQuery("status_policy", h.config.Import.StatusPolicy)
+	status := importStatusCode(jobResult, policy)
+
+	// success follows the job itself, so always-200 still reports failures
+	c.JSON(status, gin.H{
+		"success": importSucceeded(jobResult),
+		"data":    jobResult,
+	})
+}
+
+// DownloadJobErrors returns the invalid rows of a finished import as a
+// workbook matching the import template with an extra errors column
+// GET /api/employees/jobs/:id/errors.xlsx
+func (h *EmployeeHandler) DownloadJobErrors(c *gin.Context) {
+	jobID := c.Param("id")
+
+	var errorsFile bytes.Buffer
+	if err := h.excelService.WriteJobErrors(jobID, &errorsFile); err != nil {
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "job not found":
+			status = http.StatusNotFound
+		case "job has not completed":
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.ErrorResponse{
+			Error: "Failed to build errors file",
+			Details: []models.ValidationError{
+				{Field: "job_id", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", jobID+"_errors.xlsx"))
+	c.Data(http.StatusOK, xlsxContentType, errorsFile.Bytes())
+}
+
+// GetEmployees retrieves all employees with pagination
+// GET /api/employees?page=1&limit=10&search=john
+// Pages are one-based by default; PAGE_BASE=0 switches to zero-based numbering.
+// Pages past the end return an empty list with has_next=false.
+// Responses carry Last-Modified; a request whose If-Modified-Since is not older
+// than the latest write gets 304 Not Modified with no body.
+// explain=true adds the database plan for the page query as data.explain; it
+// is only honoured outside release mode or with a valid X-Admin-Key.
+// exclude_ids=1,2,3 leaves those employees out of the page and the total.
+// cursor=<id> pages by keyset instead of offset: pass 0 for the first page,
+// then each response's pagination.next_cursor until it is absent.
+// sort=<column>&order=asc|desc orders the page by first_name, last_name,
+// company_name, city, email or created_at; ties fall back to ID order.
+// sort_case=insensitive compares text columns in lower case, whatever the
+// database collation.
+// Parameters named after a filterable column, e.g. city=Boston, keep only
+// employees whose column contains the value; they combine with search.
+func (h *EmployeeHandler) GetEmployees(c *gin.Context) {
+	explain, err := h.parseExplain(c)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "not permitted" {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, models.ErrorResponse{
+			Error:   "Invalid explain",
+			Details: []models.ValidationError{{Field: "explain", Message: err.Error()}},
+		})
+		return
+	}
+
+	if !explain && h.notModified(c) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	params, err := parsePagination(c, h.paginationFor("list"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid page",
+			Details: []models.ValidationError{{Field: "page", Message: err.Error()}},
+		})
+		return
+	}
+	page, limit, offset := params.Page, params.Limit, params.Offset
+	search, searchPresent := c.GetQuery("search")
+	search = strings.TrimSpace(search)
+
+	cacheTTL, err := h.parseCacheTTL(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid cache_ttl",
+			Details: []models.ValidationError{{Field: "cache_ttl", Message: err.Error()}},
+		})
+		return
+	}
+
+	wildcards, err := parseWildcards(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid wildcards",
+			Details: []models.ValidationError{{Field: "wildcards", Message: err.Error()}},
+		})
+		return
+	}
+
+	excludeIDs, err := parseExcludeIDs(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid exclude_ids",
+			Details: []models.ValidationError{{Field: "exclude_ids", Message: err.Error()}},
+		})
+		return
+	}
+
+	filters, err := parseFilters(c)
+	if err == nil && len(filters) > 0 && explain {
+		err = fmt.Errorf("field filters cannot be combined with explain")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid filter",
+			Details: []models.ValidationError{{Field: "filter", Message: err.Error()}},
+		})
+		return
+	}
+
+	sortOptions, err := models.ParseSortOptions(c.Query("sort"), c.Query("order"), c.Query("sort_case"))
+	if err != nil {
+		field := "sort"
+		if strings.HasPrefix(err.Error(), "order") {
+			field = "order"
+		} else if strings.HasPrefix(err.Error(), "sort_case") {
+			field = "sort_case"
+		}
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid sort",
+			Details: []models.ValidationError{{Field: field, Message: err.Error()}},
+		})
+		return
+	}
+
+	if cursor, present := c.GetQuery("cursor"); present {
+		sorted := sortOptions != (models.SortOptions{})
+		if _, paged := c.GetQuery("page"); paged || searchPresent || len(excludeIDs) > 0 || explain || params.CountOnly || sorted || len(filters) > 0 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Invalid cursor",
+				Details: []models.ValidationError{{Field: "cursor",
+					Message: "cannot be combined with page, search, exclude_ids, explain, sort, field filters or limit=0"}},
+			})
+			return
+		}
+		h.getEmployeesAfterCursor(c, cursor, limit, cacheTTL)
+		return
+	}
+
+	var employees []models.EmployeeResponse
+	var total int64
+
+	if searchPresent && search == "" && h.config.Server.EmptySearch == EmptySearchNone {
+		// An explicitly empty search matches nothing, unlike an absent one
+		employees = []models.EmployeeResponse{}
+	} else if len(filters) > 0 {
+		// Field filters narrow the search or the plain listing alike, with exclusions
+		if params.CountOnly {
+			limit = 0
+		}
+		empList, totalCount, filterErr := h.employeeService.FilterEmployees(requestContext(c), filters, search, wildcards, excludeIDs, limit, offset, sortOptions, cacheTTL)
+		if filterErr != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to filter employees",
+			})
+			return
+		}
+
+		employees = make([]models.EmployeeResponse, len(empList))
+		for i, emp := range empList {
+			employees[i] = emp.ToResponse()
+		}
+		total = totalCount
+	} else if len(excludeIDs) > 0 {
+		// Exclusions apply to the search and the plain listing alike
+		if params.CountOnly {
+			limit = 0
+		}
+		empList, totalCount, listErr := h.employeeService.ListEmployeesExcluding(requestContext(c), search, wildcards, excludeIDs, limit, offset, sortOptions, cacheTTL)
+		if listErr != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to retrieve employees",
+			})
+			return
+		}
+
+		employees = make([]models.EmployeeResponse, len(empList))
+		for i, emp := range empList {
+			employees[i] = emp.ToResponse()
+		}
+		total = totalCount
+	} else if params.CountOnly {
+		// limit=0: clients only want the total, so skip loading rows
+		total, err = h.employeeService.CountEmployees(search, wildcards)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to count employees",
+			})
+			return
+		}
+		employees = []models.EmployeeResponse{}
+	} else if search != "" {
+		// Search employees
+		empList, totalCount, searchErr := h.employeeService.SearchEmployees(requestContext(c), search, wildcards, limit, offset, sortOptions, cacheTTL)
+		if searchErr != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to search employees",
+			})
+			return
+		}
+
+		// Convert to response format
+		employees = make([]models.EmployeeResponse, len(empList))
+		for i, emp := range empList {
+			employees[i] = emp.ToResponse()
+		}
+		total = totalCount
+	} else {
+		// Get all employees
+		employees, total, err = h.employeeService.GetEmployeeListResponse(requestContext(c), limit, offset, sortOptions, cacheTTL)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to retrieve employees",
+			})
+			return
+		}
+	}
+
+	data := gin.H{
+		"employees":  employees,
+		"pagination": models.NewPagination(page, limit, total, params.Base),
+		"search":     search,
+	}
+	if explain {
+		plan, err := h.employeeService.ExplainSearch(search, wildcards, excludeIDs, limit, offset, sortOptions)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to explain query",
+			})
+			return
+		}
+		data["explain"] = plan
+		middleware.SkipCache(c)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// getEmployeesAfterCursor answers a keyset page of GetEmployees: the
+// employees with IDs above the cursor, in ID order. The pagination block
+// carries next_cursor instead of page numbers and totals.
+func (h *EmployeeHandler) getEmployeesAfterCursor(c *gin.Context, cursor string, limit int, cacheTTL time.Duration) {
+	cursorID, err := strconv.Atoi(cursor)
+	if err != nil || cursorID < 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid cursor",
+			Details: []models.ValidationError{{Field: "cursor", Message: "must be an employee ID, or 0 for the first page"}},
+		})
+		return
+	}
+
+	empList, hasMore, err := h.employeeService.GetEmployeesAfterID(requestContext(c), cursorID, limit, cacheTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to retrieve employees",
+		})
+		return
+	}
+
+	employees := make([]models.EmployeeResponse, len(empList))
+	for i, emp := range empList {
+		employees[i] = emp.ToResponse()
+	}
+	pagination := models.CursorPagination{Limit: limit, HasNext: hasMore}
+	if hasMore {
+		next := empList[len(empList)-1].ID
+		pagination.NextCursor = &next
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"employees":  employees,
+			"pagination": pagination,
+		},
+	})
+}
+
+// parseExplain reads the optional explain query parameter. Query plans expose
+// schema details, so in release mode they require the admin key.
+func (h *EmployeeHandler) parseExplain(c *gin.Context) (bool, error) {
+	raw := c.Query("explain")
+	if raw == "" {
+		return false, nil
+	}
+	explain, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("must be true or false")
+	}
+	if explain && h.config.Server.Mode == gin.ReleaseMode && !middleware.IsAdmin(c, h.config.Server.AdminAPIKey) {
+		return false, fmt.Errorf("not permitted")
+	}
+	return explain, nil
+}
+
+// parseWildcards reads the optional wildcards query parameter. By default the
+// search term is matched literally; wildcards=true lets % and _ act as LIKE
+// wildcards.
+func parseWildcards(c *gin.Context) (bool, error) {
+	raw := c.Query("wildcards")
+	if raw == "" {
+		return false, nil
+	}
+	wildcards, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("must be true or false")
+	}
+	return wildcards, nil
+}
+
+// parseFilters reads the field filters of a list request: every query
+// parameter named after an employee column, e.g. city=Boston. A column that
+// is not filterable, or a filter given twice, is an error; empty values are
+// ignored like absent ones.
+func parseFilters(c *gin.Context) (map[string]string, error) {
+	query := c.Request.URL.Query()
+	filters := make(map[string]string)
+	for _, column := range models.EmployeeColumnNames() {
+		values, present := query[column]
+		if !present {
+			continue
+		}
+		if err := models.ValidateFilterField(column); err != nil {
+			return nil, err
+		}
+		if len(values) > 1 {
+			return nil, fmt.Errorf("field %q may only be filtered once", column)
+		}
+		if value := strings.TrimSpace(values[0]); value != "" {
+			filters[column] = value
+		}
+	}
+	return filters, nil
+}
+
+// maxExcludeIDs caps how many IDs one exclude_ids parameter may list
+const maxExcludeIDs = 200
+
+// parseExcludeIDs reads the optional exclude_ids query parameter, a
+// comma-separated list of employee IDs. The IDs are returned sorted and
+// without duplicates, so equal lists share a cache entry.
+func parseExcludeIDs(c *gin.Context) ([]int, error) {
+	raw := strings.TrimSpace(c.Query("exclude_ids"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	seen := make(map[int]bool)
+	var ids []int
+	for _, part := range strings.Split(raw, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || id < 1 {
+			return nil, fmt.Errorf("%q is not a valid employee ID", strings.TrimSpace(part))
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > maxExcludeIDs {
+		return nil, fmt.Errorf("at most %d IDs may be excluded", maxExcludeIDs)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// parseCacheTTL reads the optional cache_ttl query parameter (seconds) that
+// overrides how long the result is cached. Values above CACHE_TTL_MAX are
+// clamped; without it the default expiry applies.
+func (h *EmployeeHandler) parseCacheTTL(c *gin.Context) (time.Duration, error) {
+	raw := c.Query("cache_ttl")
+	if raw == "" {
+		return 0, nil
+	}
+
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 1 {
+		return 0, fmt.Errorf("must be a positive number of seconds")
+	}
+
+	ttl := time.Duration(seconds) * time.Second
+	if maxTTL := h.config.Redis.MaxCacheTTL; maxTTL > 0 && ttl > maxTTL {
+		ttl = maxTTL
+	}
+	return ttl, nil
+}
+
+// notModified sets Last-Modified and reports whether the client's copy is current.
+// Failures to determine the time only disable the optimization.
+func (h *EmployeeHandler) notModified(c *gin.Context) bool {
+	modified, err := h.employeeService.LastModified(requestContext(c))
+	if err != nil {
+		log.Printf("Warning: Failed to determine last modified time: %v", err)
+		return false
+	}
+	if modified.IsZero() {
+		return false
+	}
+
+	// HTTP dates have second precision
+	modified = modified.UTC().Truncate(time.Second)
+	c.Header("Last-Modified", modified.Format(http.TimeFormat))
+
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// ExportEmployees streams employees matching the optional search as a CSV
+// or .xlsx file. The columns param overrides the configured COLUMN_ORDER for
+// one request; fields exports only the listed columns instead. An .xlsx
+// export puts the rows on the sheet named by the sheet param.
+// GET /api/employees/export?search=acme&format=xlsx&columns=email,first_name
+// GET /api/employees/export?format=xlsx&sheet=Staff&fields=first_name,email
+func (h *EmployeeHandler) ExportEmployees(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+
+	var columns []string
+	var err error
+	if fields, ok := c.GetQuery("fields"); ok {
+		if _, ok := c.GetQuery("columns"); ok {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Invalid column selection",
+				Details: []models.ValidationError{
+					{Field: "fields", Message: "cannot be combined with columns"},
+				},
+			})
+			return
+		}
+		columns, err = services.ParseColumnSubset(fields)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Invalid column selection",
+				Details: []models.ValidationError{
+					{Field: "fields", Message: err.Error()},
+				},
+			})
+			return
+		}
+	} else {
+		columns, err = services.ParseColumnOrder(c.DefaultQuery("columns", h.config.Export.ColumnOrder))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Invalid column order",
+				Details: []models.ValidationError{
+					{Field: "columns", Message: err.Error()},
+				},
+			})
+			return
+		}
+	}
+
+	sheet, hasSheet := c.GetQuery("sheet")
+	if hasSheet {
+		message := ""
+		if format != "xlsx" {
+			message = "only applies to format=xlsx"
+		} else if err := services.ValidateSheetName(sheet); err != nil {
+			message = err.Error()
+		}
+		if message != "" {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid sheet",
+				Details: []models.ValidationError{{Field: "sheet", Message: message}},
+			})
+			return
+		}
+	}
+	wildcards, err := parseWildcards(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid wildcards",
+			Details: []models.ValidationError{{Field: "wildcards", Message: err.Error()}},
+		})
+		return
+	}
+	filter := services.ExportFilter{Search: c.Query("search"), Wildcards: wildcards, Columns: columns, Sheet: sheet}
+
+	switch format {
+	case "csv":
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="employees.csv"`)
+		c.Status(http.StatusOK)
+
+		// Headers are already sent once streaming starts, so failures can only be logged
+		if err := h.excelService.ExportEmployeesCSV(c.Writer, filter); err != nil {
+			log.Printf("Error exporting employees as CSV request_id=%s: %s", middleware.GetRequestID(c), pii.RedactError(err))
+		}
+	case "xlsx":
+		c.Header("Content-Type", xlsxContentType)
+		c.Header("Content-Disposition", `attachment; filename="employees.xlsx"`)
+
+		// The workbook is only written once every row has been read, so a
+		// failure before that can still be reported
+		if err := h.excelService.ExportEmployees(c.Writer, filter); err != nil {
+			log.Printf("Error exporting employees as XLSX request_id=%s: %s", middleware.GetRequestID(c), pii.RedactError(err))
+			if !c.Writer.Written() {
+				c.Writer.Header().Del("Content-Disposition")
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to export employees"})
+			}
+		}
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Unsupported export format",
+			Details: []models.ValidationError{
+				{Field: "format", Message: "Supported formats: csv, xlsx"},
+			},
+		})
+	}
+}
+
+// StreamEmployees streams every matching employee as NDJSON, one JSON object
+// per line, for bulk loads into other systems. Accepts the same search and
+// wildcards parameters as the list endpoint; memory use does not grow with
+// the table because rows come straight from a database cursor.
+// GET /api/employees/stream?search=acme
+func (h *EmployeeHandler) StreamEmployees(c *gin.Context) {
+	wildcards, err := parseWildcards(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid wildcards",
+			Details: []models.ValidationError{{Field: "wildcards", Message: err.Error()}},
+		})
+		return
+	}
+	filter := services.ExportFilter{Search: c.Query("search"), Wildcards: wildcards}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	// Headers are already sent once streaming starts, so failures can only be logged
+	if err := h.excelService.ExportEmployeesNDJSON(c.Writer, filter, h.config.Export.StreamFlush, func(int) { c.Writer.Flush() }); err != nil {
+		log.Printf("Error streaming employees request_id=%s: %s", middleware.GetRequestID(c), pii.RedactError(err))
+	}
+}
+
+// GetEmployeesGeoJSON returns the employees that have coordinates as a bare
+// GeoJSON FeatureCollection, without the usual success/data envelope, so map
+// libraries can load the response directly
+// GET /api/employees/geojson
+func (h *EmployeeHandler) GetEmployeesGeoJSON(c *gin.Context) {
+	collection, err := h.employeeService.GetEmployeesGeoJSON()
+	if err != nil {
+		log.Printf("Error retrieving employee locations request_id=%s: %s", middleware.GetRequestID(c), pii.RedactError(err))
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to retrieve employee locations",
+		})
+		return
+	}
+
+	c.Header("Content-Type", models.GeoJSONContentType)
+	c.JSON(http.StatusOK, collection)
+}
+
+// GetEmployeeStats returns aggregate figures for the dashboard
+// GET /api/employees/stats
+func (h *EmployeeHandler) GetEmployeeStats(c *gin.Context) {
+	stats, err := h.employeeService.GetEmployeeStats(requestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to retrieve employee stats",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    jobResult,
+		"data":    stats,
 	})
 }
 
-// GetEmployees retrieves all employees with pagination
-// GET /api/employees?page=1&limit=10&search=john
-func (h *EmployeeHandler) GetEmployees(c *gin.Context) {
-	// Parse query parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	search := c.Query("search")
-
-	// Validate pagination parameters
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
-	}
-
-	offset := (page - 1) * limit
-
-	var employees []models.EmployeeResponse
-	var total int64
-	var err error
-
-	// Check if search query is provided
-	if search != "" {
-		// Search employees
-		empList, totalCount, searchErr := h.employeeService.SearchEmployees(search, limit, offset)
-		if searchErr != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error: "Failed to search employees",
+// DiffEmployees compares two employees field by field for the merge workflow
+// GET /api/employees/diff?a=1&b=2
+func (h *EmployeeHandler) DiffEmployees(c *gin.Context) {
+	var ids [2]int
+	for i, param := range []string{"a", "b"} {
+		id, err := strconv.Atoi(c.Query(param))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid employee ID",
+				Details: []models.ValidationError{{Field: param, Message: "must be an employee ID"}},
 			})
 			return
 		}
+		ids[i] = id
+	}
 
-		// Convert to response format
-		employees = make([]models.EmployeeResponse, len(empList))
-		for i, emp := range empList {
-			employees[i] = emp.ToResponse()
+	diff, err := h.employeeService.CompareEmployees(requestContext(c), ids[0], ids[1])
+	if err != nil {
+		for _, id := range ids {
+			if err.Error() == fmt.Sprintf("employee with ID %d not found", id) {
+				c.JSON(http.StatusNotFound, models.ErrorResponse{
+					Error: "Employee not found",
+					Details: []models.ValidationError{
+						{Field: "id", Message: err.Error()},
+					},
+				})
+				return
+			}
 		}
-		total = totalCount
-	} else {
-		// Get all employees
-		employees, total, err = h.employeeService.GetEmployeeListResponse(limit, offset)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error: "Failed to retrieve employees",
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to compare employees",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    diff,
+	})
+}
+
+// GetNextEmployee returns the employee with the lowest ID above after, for
+// tools that walk every employee one at a time; 204 when none remain
+// GET /api/employees/next?after=42
+// after defaults to 0, which starts the walk at the first employee.
+func (h *EmployeeHandler) GetNextEmployee(c *gin.Context) {
+	afterID := 0
+	if raw := c.Query("after"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 0 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid cursor",
+				Details: []models.ValidationError{{Field: "after", Message: "must be an employee ID"}},
 			})
 			return
 		}
+		afterID = id
 	}
 
-	// Calculate pagination info
-	totalPages := (total + int64(limit) - 1) / int64(limit)
+	employee, err := h.employeeService.GetNextEmployee(requestContext(c), afterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to retrieve employee",
+		})
+		return
+	}
+	if employee == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data": gin.H{
-			"employees": employees,
-			"pagination": gin.H{
-				"page":        page,
-				"limit":       limit,
-				"total":       total,
-				"total_pages": totalPages,
-				"has_next":    page < int(totalPages),
-				"has_prev":    page > 1,
-			},
-			"search": search,
-		},
+		"data":    employee.ToResponse(),
 	})
 }
 
@@ -189,7 +1069,7 @@ func (h *EmployeeHandler) GetEmployee(c *gin.Context) {
 	}
 
 	// Get employee
-	employee, err := h.employeeService.GetEmployeeResponse(id)
+	employee, err := h.employeeService.GetEmployeeResponse(requestContext(c), id)
 	if err != nil {
 		if err.Error() == "employee with ID "+idStr+" not found" {
 			c.JSON(http.StatusNotFound, models.ErrorResponse{
@@ -225,8 +1105,26 @@ func (h *EmployeeHandler) CreateEmployee(c *gin.Context) {
 		return
 	}
 
-	// Validate employee data
-	validationErrors := h.employeeService.ValidateEmployeeData(&employee)
+	// The database assigns IDs; a client-supplied one is ignored or, if configured, rejected
+	if employee.ID != 0 {
+		if h.config.Validation.RejectClientID {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Invalid request data",
+				Details: []models.ValidationError{
+					{Field: "id", Message: "id is assigned by the server and must not be set on create"},
+				},
+			})
+			return
+		}
+		employee.ID = 0
+	}
+
+	// Apply configured input corrections before validating
+	h.employeeService.NormalizeEmployee(&employee)
+
+	// Validate employee data, localizing messages from Accept-Language
+	locale := services.ResolveLocale(c.GetHeader("Accept-Language"))
+	validationErrors, warnings := h.employeeService.ValidateEmployeeDataForLocale(&employee, locale)
 	if len(validationErrors) > 0 {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "Validation failed",
@@ -235,11 +1133,22 @@ func (h *EmployeeHandler) CreateEmployee(c *gin.Context) {
 		return
 	}
 
+	// Optionally verify the email and web domains exist (create only; imports skip this)
+	domainProblems := h.domainVerifier.Verify(c.Request.Context(), &employee)
+	if len(domainProblems) > 0 && h.domainVerifier.Strict() {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Validation failed",
+			Details: domainProblems,
+		})
+		return
+	}
+
 	// Create employee
-	if err := h.employeeService.CreateEmployee(&employee); err != nil {
-		if err.Error() == "employee with email "+employee.Email+" already exists" {
+	if err := h.employeeService.CreateEmployee(&employee, h.actor(c)); err != nil {
+		var conflict *services.EmployeeConflictError
+		if errors.As(err, &conflict) {
 			c.JSON(http.StatusConflict, models.ErrorResponse{
-				Error: "Employee with this email already exists",
+				Error: "Employee with this " + conflict.Field + " already exists",
 			})
 		} else {
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -251,14 +1160,19 @@ func (h *EmployeeHandler) CreateEmployee(c *gin.Context) {
 
 	// Return created employee
 	response := employee.ToResponse()
-	c.JSON(http.StatusCreated, gin.H{
+	body := gin.H{
 		"success": true,
 		"data":    response,
 		"message": "Employee created successfully",
-	})
+	}
+	if warnings = append(warnings, domainProblems...); len(warnings) > 0 {
+		body["warnings"] = warnings
+	}
+	c.JSON(http.StatusCreated, body)
 }
 
-// UpdateEmployee updates an existing employee
+// UpdateEmployee updates an existing employee. return=changed replaces the
+// full record in data with the id and the columns that changed.
 // PUT /api/employees/:id
 func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 	// Parse employee ID
@@ -271,6 +1185,15 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 		return
 	}
 
+	onlyChanged, err := parseReturnChanged(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid return",
+			Details: []models.ValidationError{{Field: "return", Message: err.Error()}},
+		})
+		return
+	}
+
 	var updateData models.Employee
 
 	// Bind JSON to employee struct
@@ -284,16 +1207,27 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 		return
 	}
 
-	// Update employee
-	updatedEmployee, err := h.employeeService.UpdateEmployee(id, &updateData)
+	// Apply configured input corrections before validating
+	h.employeeService.NormalizeEmployee(&updateData)
+
+	// Update employee, localizing validation messages from Accept-Language
+	locale := services.ResolveLocale(c.GetHeader("Accept-Language"))
+	updatedEmployee, changed, err := h.employeeService.UpdateEmployee(id, &updateData, locale, h.actor(c))
 	if err != nil {
-		if err.Error() == "employee with ID "+idStr+" not found" {
+		var invalid *services.EmployeeValidationError
+		var conflict *services.EmployeeConflictError
+		if errors.As(err, &invalid) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Validation failed",
+				Details: invalid.Details,
+			})
+		} else if err.Error() == "employee with ID "+idStr+" not found" {
 			c.JSON(http.StatusNotFound, models.ErrorResponse{
 				Error: "Employee not found",
 			})
-		} else if err.Error() == "employee with email "+updateData.Email+" already exists" {
+		} else if errors.As(err, &conflict) {
 			c.JSON(http.StatusConflict, models.ErrorResponse{
-				Error: "Employee with this email already exists",
+				Error: "Employee with this " + conflict.Field + " already exists",
 			})
 		} else {
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -303,12 +1237,164 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 		return
 	}
 
-	// Return updated employee
-	response := updatedEmployee.ToResponse()
-	c.JSON(http.StatusOK, gin.H{
+	h.respondUpdated(c, updatedEmployee, changed, onlyChanged, locale)
+}
+
+// respondUpdated answers a successful update with the employee, or just the
+// columns that changed when onlyChanged, plus any validation warnings
+func (h *EmployeeHandler) respondUpdated(c *gin.Context, employee *models.Employee, changed []string, onlyChanged bool, locale string) {
+	var data interface{} = employee.ToResponse()
+	if onlyChanged {
+		changes := gin.H{"id": employee.ID}
+		for _, column := range changed {
+			switch column {
+			case "latitude":
+				changes[column] = employee.Latitude
+			case "longitude":
+				changes[column] = employee.Longitude
+			default:
+				changes[column] = employee.ColumnValue(column)
+			}
+		}
+		data = changes
+	}
+	body := gin.H{
 		"success": true,
-		"data":    response,
+		"data":    data,
 		"message": "Employee updated successfully",
+	}
+	if len(changed) == 0 && h.config.Server.SkipUnchangedUpdates {
+		body["not_modified"] = true
+		body["message"] = "Employee unchanged"
+	}
+	if _, warnings := h.employeeService.ValidateEmployeeDataForLocale(employee, locale); len(warnings) > 0 {
+		body["warnings"] = warnings
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// requestContext is the request's context carrying its request ID, for
+// service calls that log
+func requestContext(c *gin.Context) context.Context {
+	return services.WithRequestID(c.Request.Context(), middleware.GetRequestID(c))
+}
+
+// actor identifies the caller for domain events: the EVENTS_ACTOR_HEADER
+// value, client IP and request ID
+func (h *EmployeeHandler) actor(c *gin.Context) events.Actor {
+	actor := events.Actor{IP: c.ClientIP(), RequestID: middleware.GetRequestID(c)}
+	if header := h.config.Events.ActorHeader; header != "" {
+		actor.ID = strings.TrimSpace(c.GetHeader(header))
+	}
+	return actor
+}
+
+// PatchEmployee changes only the fields present in the JSON body; an empty
+// string or null clears an optional field. Accepts return=changed like PUT.
+// PATCH /api/employees/:id
+func (h *EmployeeHandler) PatchEmployee(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Invalid employee ID",
+		})
+		return
+	}
+
+	onlyChanged, err := parseReturnChanged(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid return",
+			Details: []models.ValidationError{{Field: "return", Message: err.Error()}},
+		})
+		return
+	}
+
+	var fields map[string]any
+	if err := c.ShouldBindJSON(&fields); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Invalid request data",
+			Details: []models.ValidationError{
+				{Field: "body", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	patchedEmployee, changed, err := h.employeeService.PatchEmployee(id, fields, h.actor(c))
+	if err != nil {
+		var invalid *services.EmployeeValidationError
+		var conflict *services.EmployeeConflictError
+		switch {
+		case errors.As(err, &invalid):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Validation failed",
+				Details: invalid.Details,
+			})
+		case err.Error() == fmt.Sprintf("employee with ID %d not found", id):
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Employee not found",
+			})
+		case errors.As(err, &conflict):
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Error: "Employee with this " + conflict.Field + " already exists",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to update employee",
+			})
+		}
+		return
+	}
+
+	h.respondUpdated(c, patchedEmployee, changed, onlyChanged, services.ResolveLocale(c.GetHeader("Accept-Language")))
+}
+
+// parseReturnChanged reads the optional return query parameter of an update:
+// full (the default) or changed.
+func parseReturnChanged(c *gin.Context) (bool, error) {
+	switch c.Query("return") {
+	case "", "full":
+		return false, nil
+	case "changed":
+		return true, nil
+	default:
+		return false, fmt.Errorf("must be full or changed")
+	}
+}
+
+// TouchEmployee marks an employee as changed without altering its data
+// POST /api/employees/:id/touch
+func (h *EmployeeHandler) TouchEmployee(c *gin.Context) {
+	// Parse employee ID
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Invalid employee ID",
+		})
+		return
+	}
+
+	employee, err := h.employeeService.TouchEmployee(id, h.actor(c))
+	if err != nil {
+		if err.Error() == "employee with ID "+idStr+" not found" {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Employee not found",
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to touch employee",
+			})
+		}
+		return
+	}
+
+	// Return the full record so callers see the new updated_at
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    employee,
+		"message": "Employee touched successfully",
 	})
 }
 
@@ -326,7 +1412,7 @@ func (h *EmployeeHandler) DeleteEmployee(c *gin.Context) {
 	}
 
 	// Delete employee
-	deletedEmployee, err := h.employeeService.DeleteEmployee(id)
+	deletedEmployee, err := h.employeeService.DeleteEmployee(id, h.actor(c))
 	if err != nil {
 		if err.Error() == "employee with ID "+idStr+" not found" {
 			c.JSON(http.StatusNotFound, models.ErrorResponse{
@@ -347,12 +1433,221 @@ func (h *EmployeeHandler) DeleteEmployee(c *gin.Context) {
 	})
 }
 
-// HealthCheck checks if the service is healthy
+// maxBulkDeleteIDs caps how many IDs one bulk delete may list
+const maxBulkDeleteIDs = 1000
+
+// bulkDeleteRequest is the body of a bulk delete
+type bulkDeleteRequest struct {
+	IDs []int `json:"ids" binding:"required"`
+}
+
+// BulkDeleteEmployees deletes many employees in one transaction and reports
+// the IDs that matched no employee
+// POST /api/employees/bulk-delete
+func (h *EmployeeHandler) BulkDeleteEmployees(c *gin.Context) {
+	var request bulkDeleteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Invalid request data",
+			Details: []models.ValidationError{
+				{Field: "body", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	seen := make(map[int]bool)
+	var ids []int
+	for _, id := range request.IDs {
+		if id < 1 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid employee ID",
+				Details: []models.ValidationError{{Field: "ids", Message: fmt.Sprintf("%d is not a valid employee ID", id)}},
+			})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 || len(ids) > maxBulkDeleteIDs {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid employee IDs",
+			Details: []models.ValidationError{{Field: "ids", Message: fmt.Sprintf("must list between 1 and %d IDs", maxBulkDeleteIDs)}},
+		})
+		return
+	}
+	sort.Ints(ids)
+
+	result, err := h.employeeService.DeleteEmployees(ids, h.actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to delete employees",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("Deleted %d employees", result.Deleted),
+		"data":    result,
+	})
+}
+
+// StartBackup starts an on-demand dump of every employee to BACKUP_DIR as
+// gzip'd NDJSON. Progress and the file path are reported on the job.
+// POST /api/admin/export
+func (h *EmployeeHandler) StartBackup(c *gin.Context) {
+	jobID, err := h.excelService.StartBackup(middleware.GetRequestID(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrBackupRunning) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.ErrorResponse{
+			Error: "Failed to start backup",
+			Details: []models.ValidationError{
+				{Field: "backup", Message: err.Error()},
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success":    true,
+		"message":    "Backup started",
+		"job_id":     jobID,
+		"status_url": h.config.Server.RoutePrefix + "/api/jobs/" + jobID,
+	})
+}
+
+// GetConfig returns the configuration the instance is running with, to check
+// which settings took effect without shell access. Passwords and keys are
+// redacted; the replica shares the primary's credentials, so no DSN is shown.
+// GET /api/admin/config
+func (h *EmployeeHandler) GetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.config.Redacted(),
+	})
+}
+
+// GetCacheStats reports how many employees and lists are cached and the
+// configured expiry, to check cache behavior. An unreachable cache answers 503.
+// GET /api/cache/stats
+func (h *EmployeeHandler) GetCacheStats(c *gin.Context) {
+	stats, err := h.employeeService.CacheStats()
+	if err != nil {
+		log.Printf("Warning: failed to read cache stats: %v", err)
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error: "Cache unavailable",
+			Details: []models.ValidationError{
+				{Field: "cache", Message: "the cache backend could not be reached; try again later"},
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+// InspectCache shows what is cached under a key and for how long, to debug
+// the cache disagreeing with the database. ttl_seconds is -1 for entries
+// without expiry. The key may contain slashes (search terms), hence *key.
+// GET /api/admin/cache/employee:42
+func (h *EmployeeHandler) InspectCache(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	entry, err := h.employeeService.InspectCache(key)
+	if err != nil {
+		if err.Error() == "unsupported cache key" {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Unsupported cache key",
+				Details: []models.ValidationError{
+					{Field: "key", Message: "expected employee:<id> or employee_list:<key>"},
+				},
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to inspect cache",
+			})
+		}
+		return
+	}
+	if entry == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "Key not cached",
+		})
+		return
+	}
+
+	ttlSeconds := int64(-1)
+	if entry.TTL > 0 {
+		ttlSeconds = int64(entry.TTL.Seconds())
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"key":         entry.Key,
+			"value":       entry.Value,
+			"ttl_seconds": ttlSeconds,
+		},
+	})
+}
+
+// ReadinessCheck reports whether the service can take traffic: 200 when every
+// required dependency is reachable and the database is migrated, 503 otherwise.
+// Unlike the health check it depends on the database, cache and event broker.
+// data.status is up, degraded (an optional dependency is down) or down.
+// GET /api/ready
+func (h *EmployeeHandler) ReadinessCheck(c *gin.Context) {
+	report := h.readiness.Check(c.Request.Context())
+
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{
+		"success": report.Ready,
+		"data":    report,
+	})
+}
+
+// DetailedHealthCheck reports the status of every subsystem from the same
+// evaluation as ReadinessCheck, but is stricter: any dependency that is down,
+// required or not, makes it answer 503
+// GET /api/health/ready
+func (h *EmployeeHandler) DetailedHealthCheck(c *gin.Context) {
+	report := h.readiness.Check(c.Request.Context())
+
+	healthy := report.Status == models.DependencyUp
+	status := http.StatusOK
+	if !healthy {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{
+		"success": healthy,
+		"data":    report,
+	})
+}
+
+// HealthCheck checks if the service is healthy and reports the upload
+// memory guard's latest sample
 // GET /api/health
 func (h *EmployeeHandler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
+	body := gin.H{
 		"status":  "healthy",
 		"message": "Employee Management Service is running",
 		"version": "1.0.0",
-	})
+
+		"cache_write_failures": h.employeeService.CacheWriteFailures(),
+	}
+	if h.excelService != nil {
+		body["memory"] = h.excelService.MemoryStatus()
+	}
+	c.JSON(http.StatusOK, body)
 }
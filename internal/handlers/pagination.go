@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paginationDefaults controls how an endpoint interprets page and limit
+type paginationDefaults struct {
+	DefaultLimit int // Used when limit is missing or out of range
+	MaxLimit     int // Largest limit a client may request
+	Base         int // Number of the first page (0 or 1)
+	MaxPage      int // Highest page a client may request
+}
+
+// defaultMaxPage caps page numbers when MAX_PAGE is not set. Page times limit
+// must not overflow the offset, and pages that deep are better read with a
+// cursor anyway.
+const defaultMaxPage = 100000
+
+// endpointPagination holds the pagination defaults of each paginated endpoint,
+// so new endpoints declare their limits here instead of in the handler
+var endpointPagination = map[string]paginationDefaults{
+	"list":    {DefaultLimit: 20, MaxLimit: 100},
+	"staging": {DefaultLimit: 50, MaxLimit: 500},
+}
+
+// pageParams is the validated result of parsePagination
+type pageParams struct {
+	Page      int
+	Limit     int
+	Offset    int
+	Base      int
+	CountOnly bool // limit=0 was requested: return the total without rows
+}
+
+// paginationFor returns the defaults for an endpoint with the configured page base
+func (h *EmployeeHandler) paginationFor(endpoint string) paginationDefaults {
+	defaults := endpointPagination[endpoint]
+	defaults.Base = h.pageBase()
+	defaults.MaxPage = h.config.Server.MaxPage
+	if defaults.MaxPage <= 0 {
+		defaults.MaxPage = defaultMaxPage
+	}
+	return defaults
+}
+
+// parsePagination reads page and limit from the query string. Pages below the
+// base snap to the first page; pages above the endpoint's MaxPage are an
+// error. An explicit limit=0 asks for the count only; any other missing,
+// malformed or out-of-range limit falls back to the endpoint's default.
+func parsePagination(c *gin.Context, defaults paginationDefaults) (pageParams, error) {
+	base := defaults.Base
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(base)))
+	if err != nil || page < base {
+		page = base
+	}
+	if defaults.MaxPage > 0 && page > defaults.MaxPage {
+		return pageParams{}, fmt.Errorf("must not exceed %d; use cursor to page deeper", defaults.MaxPage)
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaults.DefaultLimit)))
+	if err == nil && limit == 0 {
+		return pageParams{Page: page, Base: base, CountOnly: true}, nil
+	}
+	if err != nil || limit < 1 || limit > defaults.MaxLimit {
+		limit = defaults.DefaultLimit
+	}
+
+	return pageParams{
+		Page:   page,
+		Limit:  limit,
+		Offset: (page - base) * limit,
+		Base:   base,
+	}, nil
+}
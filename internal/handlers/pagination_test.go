@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParsePagination(t *testing.T) {
+	defaults := paginationDefaults{DefaultLimit: 20, MaxLimit: 100, Base: 1}
+
+	tests := []struct {
+		name     string
+		query    string
+		defaults paginationDefaults
+		expected pageParams
+	}{
+		{"defaults", "", defaults, pageParams{Page: 1, Limit: 20, Offset: 0, Base: 1}},
+		{"explicit page and limit", "?page=3&limit=10", defaults, pageParams{Page: 3, Limit: 10, Offset: 20, Base: 1}},
+		{"page below base snaps to first", "?page=-4", defaults, pageParams{Page: 1, Limit: 20, Offset: 0, Base: 1}},
+		{"malformed page", "?page=abc", defaults, pageParams{Page: 1, Limit: 20, Offset: 0, Base: 1}},
+		{"limit above max uses default", "?limit=500", defaults, pageParams{Page: 1, Limit: 20, Offset: 0, Base: 1}},
+		{"negative limit uses default", "?limit=-1", defaults, pageParams{Page: 1, Limit: 20, Offset: 0, Base: 1}},
+		{"zero limit is count only", "?limit=0", defaults, pageParams{Page: 1, Base: 1, CountOnly: true}},
+		{"limit at max", "?limit=100", defaults, pageParams{Page: 1, Limit: 100, Offset: 0, Base: 1}},
+		{"zero-based", "?page=2&limit=5", paginationDefaults{DefaultLimit: 5, MaxLimit: 10, Base: 0},
+			pageParams{Page: 2, Limit: 5, Offset: 10, Base: 0}},
+		{"per-endpoint default", "", paginationDefaults{DefaultLimit: 5, MaxLimit: 10, Base: 1},
+			pageParams{Page: 1, Limit: 5, Offset: 0, Base: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/"+tt.query, nil)
+
+			if got, err := parsePagination(c, tt.defaults); err != nil || got != tt.expected {
+				t.Errorf("Expected %+v, got %+v (%v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	// Pages past MaxPage are rejected before page*limit can overflow
+	capped := paginationDefaults{DefaultLimit: 20, MaxLimit: 100, Base: 1, MaxPage: 1000}
+	for _, query := range []string{"?page=1001", "?page=9223372036854775807&limit=100"} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/"+query, nil)
+		if _, err := parsePagination(c, capped); err == nil {
+			t.Errorf("%s: expected an error", query)
+		}
+	}
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?page=1000&limit=100", nil)
+	if got, err := parsePagination(c, capped); err != nil || got.Offset != 99900 {
+		t.Errorf("Expected the last allowed page to pass, got %+v (%v)", got, err)
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"employee-management/internal/models"
+	"employee-management/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetStagedEmployees lists the rows of a staging batch for review
+// GET /api/employees/staging/:batch?page=1&limit=50
+func (h *EmployeeHandler) GetStagedEmployees(c *gin.Context) {
+	params, err := parsePagination(c, h.paginationFor("staging"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid page",
+			Details: []models.ValidationError{{Field: "page", Message: err.Error()}},
+		})
+		return
+	}
+
+	staged, total, err := h.employeeService.GetStagedEmployees(c.Param("batch"), params.Limit, params.Offset)
+	if err != nil {
+		stagingError(c, err, "Failed to retrieve staged employees")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"batch_id":   c.Param("batch"),
+			"employees":  staged,
+			"pagination": models.NewPagination(params.Page, params.Limit, total, params.Base),
+		},
+	})
+}
+
+// PromoteStagingBatch moves a reviewed staging batch into employees in one
+// transaction. Rows whose email already exists are skipped and reported.
+// POST /api/employees/staging/:batch/promote
+func (h *EmployeeHandler) PromoteStagingBatch(c *gin.Context) {
+	result, err := h.employeeService.PromoteStagingBatch(c.Param("batch"), h.actor(c))
+	if err != nil {
+		stagingError(c, err, "Failed to promote staging batch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Staging batch promoted",
+		"data":    result,
+	})
+}
+
+// DiscardStagingBatch deletes a staging batch without importing it
+// DELETE /api/employees/staging/:batch
+func (h *EmployeeHandler) DiscardStagingBatch(c *gin.Context) {
+	if err := h.employeeService.DiscardStagingBatch(c.Param("batch")); err != nil {
+		stagingError(c, err, "Failed to discard staging batch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Staging batch discarded",
+	})
+}
+
+// stagingError answers 404 for unknown batches and 500 otherwise
+func stagingError(c *gin.Context, err error, message string) {
+	if errors.Is(err, services.ErrStagingBatchNotFound) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "Staging batch not found",
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error: message,
+	})
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"employee-management/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminKeyHeader carries the key required by AdminAuth
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminAuth guards diagnostic endpoints with a shared key sent in
+// X-Admin-Key. With no key configured the endpoints are disabled and answer
+// 404, so they are never exposed by accident.
+func AdminAuth(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key == "" {
+			c.AbortWithStatusJSON(http.StatusNotFound, models.ErrorResponse{Error: "Admin endpoints are disabled"})
+			return
+		}
+
+		if !IsAdmin(c, key) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.ErrorResponse{Error: "Invalid or missing admin key"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// IsAdmin reports whether the request carries the configured admin key. It is
+// always false when no key is configured.
+func IsAdmin(c *gin.Context, key string) bool {
+	if key == "" {
+		return false
+	}
+	provided := c.GetHeader(AdminKeyHeader)
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(key)) == 1
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		provided   string
+		wantStatus int
+	}{
+		{"matching key passes", "s3cret", "s3cret", http.StatusOK},
+		{"wrong key is rejected", "s3cret", "guess", http.StatusUnauthorized},
+		{"missing key is rejected", "s3cret", "", http.StatusUnauthorized},
+		{"no configured key disables the endpoints", "", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/admin", AdminAuth(tt.configured), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.provided != "" {
+				req.Header.Set(AdminKeyHeader, tt.provided)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
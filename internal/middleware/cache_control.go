@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoCacheParam is the query parameter that asks the client to revalidate
+const NoCacheParam = "no_cache"
+
+// noStoreKey marks a response that must not be cached whatever its route
+const noStoreKey = "cacheControlNoStore"
+
+// NoStore marks every response of the routes it guards as not cacheable.
+// Routes that allow caching add CacheControl, which replaces the header.
+func NoStore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.Next()
+	}
+}
+
+// SkipCache marks the current response as not cacheable, for responses that
+// carry data the route does not normally return (such as admin diagnostics)
+func SkipCache(c *gin.Context) {
+	c.Set(noStoreKey, true)
+}
+
+// CacheControl tells the client how long a read may be reused. Successful GET
+// and HEAD responses get "private, max-age" from maxAge (the server-side
+// cache expiry): they carry personal data, so shared caches must not keep
+// them. They get no-cache instead when maxAge is zero or the request sets
+// no_cache=true. Writes, error responses and responses marked with SkipCache
+// get no-store. vary lists request headers the response depends on.
+func CacheControl(maxAge time.Duration, vary ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := "no-store"
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			value = fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
+			if noCache, _ := strconv.ParseBool(c.Query(NoCacheParam)); noCache || maxAge < time.Second {
+				value = "no-cache"
+			}
+		}
+		for _, header := range vary {
+			c.Writer.Header().Add("Vary", header)
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, context: c, value: value}
+		c.Next()
+	}
+}
+
+// cacheControlWriter sets Cache-Control once the status is known, so error
+// responses and SkipCache from any handler are marked no-store. Every path that can send
+// the headers goes through apply.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	context *gin.Context
+	value   string
+}
+
+func (w *cacheControlWriter) apply(code int) {
+	if w.Written() {
+		return
+	}
+	if code >= http.StatusBadRequest || w.context.GetBool(noStoreKey) {
+		w.Header().Set("Cache-Control", "no-store")
+	} else {
+		w.Header().Set("Cache-Control", w.value)
+	}
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	w.apply(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	w.apply(w.Status())
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.apply(w.Status())
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.apply(w.Status())
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *cacheControlWriter) Flush() {
+	w.apply(w.Status())
+	w.ResponseWriter.Flush()
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	employees := router.Group("/employees", NoStore())
+	cacheable := CacheControl(5 * time.Minute)
+	employees.GET("/:id", cacheable, func(c *gin.Context) {
+		switch c.Param("id") {
+		case "missing":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+		case "explained":
+			SkipCache(c)
+			c.JSON(http.StatusOK, gin.H{"success": true, "explain": []string{}})
+		default:
+			c.JSON(http.StatusOK, gin.H{"success": true})
+		}
+	})
+	employees.GET("/export", func(c *gin.Context) {
+		c.String(http.StatusOK, "id,email\n")
+	})
+	employees.POST("", cacheable, func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"success": true})
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+	}{
+		{"GET uses the cache expiry", http.MethodGet, "/employees/1", "private, max-age=300"},
+		{"skipped responses are not stored", http.MethodGet, "/employees/explained", "no-store"},
+		{"routes without CacheControl are not stored", http.MethodGet, "/employees/export", "no-store"},
+		{"no_cache asks to revalidate", http.MethodGet, "/employees/1?no_cache=true", "no-cache"},
+		{"GET errors are not stored", http.MethodGet, "/employees/missing", "no-store"},
+		{"writes are not stored", http.MethodPost, "/employees", "no-store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if got := w.Header().Get("Cache-Control"); got != tt.expected {
+				t.Errorf("Expected Cache-Control %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCacheControl_NoExpiry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/employees", CacheControl(0), func(c *gin.Context) {
+		c.Status(http.StatusNotModified)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/employees", nil))
+	if got := w.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Expected no-cache without a cache expiry, got %q", got)
+	}
+}
+
+func TestCacheControl_Vary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/employees", CacheControl(time.Minute, AdminKeyHeader), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/employees", nil))
+	if got := w.Header().Get("Vary"); got != AdminKeyHeader {
+		t.Errorf("Expected Vary %q, got %q", AdminKeyHeader, got)
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"employee-management/internal/pii"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DefaultRequestIDHeader is the header used when none is configured
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the Gin context key holding the request ID
+const requestIDKey = "request_id"
+
+// maxRequestIDLength bounds client-supplied IDs so they can't bloat log lines
+const maxRequestIDLength = 128
+
+// RequestID reads the request ID from the given header, generating a UUID when
+// it is absent or malformed, stores it in the context and echoes it back
+func RequestID(header string) gin.HandlerFunc {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(header)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Header(header, requestID)
+		c.Next()
+	}
+}
+
+// GetRequestID returns the request ID stored by RequestID, or "" if the
+// middleware is not installed
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// Logger is gin's access log with the request ID appended to every line.
+// Emails and phone numbers in the query string and error are masked while
+// REDACT_PII_LOGS is on.
+func Logger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | request_id=%s\n%s",
+			param.TimeStamp.Format(time.RFC3339),
+			param.StatusCode,
+			param.Latency,
+			param.ClientIP,
+			param.Method,
+			redactQuery(param.Path),
+			param.Keys[requestIDKey],
+			pii.Redact(param.ErrorMessage),
+		)
+	})
+}
+
+// redactQuery masks personal data in the query string of a logged path. The
+// path itself is left alone so IDs in it stay readable.
+func redactQuery(path string) string {
+	route, query, found := strings.Cut(path, "?")
+	if !found {
+		return path
+	}
+	return route + "?" + pii.Redact(query)
+}
+
+// validRequestID accepts short IDs made of URL-safe characters, which keeps
+// client input from injecting newlines or control characters into logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func newTestRouter(header string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RequestID(header))
+	router.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, GetRequestID(c))
+	})
+	return router
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		incoming string
+		wantEcho bool
+	}{
+		{"incoming ID round-trips", "", "abc-123", true},
+		{"custom header round-trips", "X-Correlation-ID", "trace:42", true},
+		{"missing ID is generated", "", "", false},
+		{"malformed ID is replaced", "", "bad\nid", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == "" {
+				header = DefaultRequestIDHeader
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tt.incoming != "" {
+				req.Header.Set(header, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			newTestRouter(tt.header).ServeHTTP(w, req)
+
+			echoed := w.Header().Get(header)
+			if echoed != w.Body.String() {
+				t.Errorf("Expected context ID %q to match response header %q", w.Body.String(), echoed)
+			}
+			if tt.wantEcho {
+				if echoed != tt.incoming {
+					t.Errorf("Expected %q to be echoed, got %q", tt.incoming, echoed)
+				}
+				return
+			}
+			if _, err := uuid.Parse(echoed); err != nil {
+				t.Errorf("Expected a generated UUID, got %q", echoed)
+			}
+		})
+	}
+}
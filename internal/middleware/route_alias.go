@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteAlias maps a deprecated path prefix onto the canonical one, e.g.
+// /api/employee -> /api/employees. Sub-paths follow: /api/employee/7 is
+// served by /api/employees/7.
+type RouteAlias struct {
+	Alias     string
+	Canonical string
+}
+
+// ParseRouteAliases reads a comma-separated list of alias=canonical pairs.
+// Paths are relative to prefix (ROUTE_PREFIX), which is prepended to both
+// sides. Malformed entries are logged and ignored.
+func ParseRouteAliases(spec, prefix string) []RouteAlias {
+	var aliases []RouteAlias
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		alias, canonical, found := strings.Cut(entry, "=")
+		alias, canonical = cleanRoutePath(alias), cleanRoutePath(canonical)
+		if !found || alias == "" || canonical == "" || alias == canonical {
+			log.Printf("Warning: ignoring ROUTE_ALIASES entry %q, expected /alias=/canonical", entry)
+			continue
+		}
+		aliases = append(aliases, RouteAlias{Alias: prefix + alias, Canonical: prefix + canonical})
+	}
+
+	// The most specific alias wins when one is a prefix of another
+	sort.SliceStable(aliases, func(i, j int) bool { return len(aliases[i].Alias) > len(aliases[j].Alias) })
+	return aliases
+}
+
+// cleanRoutePath gives a path a leading slash and no trailing slash, or
+// returns "" when nothing is left
+func cleanRoutePath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return ""
+	}
+	return "/" + path
+}
+
+// RouteAliases serves requests for an alias path from the router's canonical
+// routes and logs each use so remaining old clients can be tracked down.
+// Canonical routes stay primary: an alias that overlaps a registered route is
+// dropped with a warning.
+func RouteAliases(router *gin.Engine, aliases []RouteAlias) http.Handler {
+	var active []RouteAlias
+	for _, alias := range aliases {
+		if shadowed := shadowingRoute(router.Routes(), alias.Alias); shadowed != "" {
+			log.Printf("Warning: route alias %s overlaps route %s and is ignored", alias.Alias, shadowed)
+			continue
+		}
+		active = append(active, alias)
+	}
+	if len(active) == 0 {
+		return router
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, alias := range active {
+			rest, ok := strings.CutPrefix(r.URL.Path, alias.Alias)
+			if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+				continue
+			}
+			log.Printf("Deprecated route alias used: %s %s from %s, use %s", r.Method, r.URL.Path, r.RemoteAddr, alias.Canonical+rest)
+			r.URL.Path = alias.Canonical + rest
+			r.URL.RawPath = ""
+			break
+		}
+		router.ServeHTTP(w, r)
+	})
+}
+
+// shadowingRoute returns a registered route path at or below alias, or ""
+func shadowingRoute(routes gin.RoutesInfo, alias string) string {
+	for _, route := range routes {
+		if route.Path == alias || strings.HasPrefix(route.Path, alias+"/") {
+			return route.Path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRouteAliases(t *testing.T) {
+	aliases := ParseRouteAliases("api/emp=/api/employees, /api/emp/list/=/api/employees/, broken, /same=/same, =/api/x", "/svc")
+
+	want := []RouteAlias{
+		{Alias: "/svc/api/emp/list", Canonical: "/svc/api/employees"},
+		{Alias: "/svc/api/emp", Canonical: "/svc/api/employees"},
+	}
+	if !reflect.DeepEqual(aliases, want) {
+		t.Errorf("Expected %v, got %v", want, aliases)
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+// FieldDiff compares one column of two employees
+type FieldDiff struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+	Equal bool   `json:"equal"`
+}
+
+// EmployeeDiff is a field-by-field comparison of two employees, used to
+// review records before merging them
+type EmployeeDiff struct {
+	A           EmployeeResponse `json:"a"`
+	B           EmployeeResponse `json:"b"`
+	Fields      []FieldDiff      `json:"fields"`
+	Differences int              `json:"differences"`
+}
+
+// DiffEmployees compares every editable column of a and b in template order.
+// Values are compared exactly, so a change in case counts as a difference.
+func DiffEmployees(a, b *Employee) EmployeeDiff {
+	diff := EmployeeDiff{A: a.ToResponse(), B: b.ToResponse()}
+	for _, field := range EmployeeFields {
+		valueA, valueB := a.ColumnValue(field.Column), b.ColumnValue(field.Column)
+		equal := valueA == valueB
+		if !equal {
+			diff.Differences++
+		}
+		diff.Fields = append(diff.Fields, FieldDiff{Field: field.Column, A: valueA, B: valueB, Equal: equal})
+	}
+	return diff
+}
@@ -1,7 +1,10 @@
 package models
 
 import (
+	"employee-management/internal/pii"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // ExcelValidationResponse represents the response for Excel format validation only
@@ -10,7 +13,14 @@ type ExcelValidationResponse struct {
 	TotalRecords int    `json:"total_records"`
 }
 
-// Employee represents the structure of employee data from Excel file
+// Employee represents the structure of employee data from Excel file.
+// CreatedAt and UpdatedAt carry secondary indexes so date-range filters and
+// recency ordering avoid full scans; each costs roughly 8 bytes plus the
+// primary key per row and a little extra work on every insert/update.
+// Latitude and Longitude are nil while an employee's location is unknown.
+// Phone and email may be stored encrypted (see package pii); their columns
+// are sized for the ciphertext and EmailIndex/PhoneIndex hold the keyed
+// hashes used to look them up while encrypted.
 type Employee struct {
 	ID          int       `json:"id" gorm:"primaryKey;autoIncrement"`
 	FirstName   string    `json:"first_name" gorm:"column:first_name;type:varchar(50);not null" validate:"required,min=2,max=50"`
@@ -20,11 +30,15 @@ type Employee struct {
 	City        string    `json:"city" gorm:"column:city;type:varchar(50)" validate:"max=50"`
 	County      string    `json:"county" gorm:"column:county;type:varchar(50)" validate:"max=50"`
 	Postal      string    `json:"postal" gorm:"column:postal;type:varchar(20)" validate:"max=20"`
-	Phone       string    `json:"phone" gorm:"column:phone;type:varchar(20)" validate:"max=20"`
-	Email       string    `json:"email" gorm:"column:email;type:varchar(255);uniqueIndex" validate:"required,email,max=255"`
-	Web         string    `json:"web" gorm:"column:web;type:varchar(255)" validate:"omitempty,url"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	Phone       string    `json:"phone" gorm:"column:phone;type:varchar(71);serializer:pii" validate:"max=20"`
+	Email       string    `json:"email" gorm:"column:email;type:varchar(387);uniqueIndex;serializer:pii" validate:"required,email,max=255"`
+	Web         string    `json:"web" gorm:"column:web;type:varchar(255)" validate:"omitempty,url,max=255"`
+	Latitude    *float64  `json:"latitude,omitempty" gorm:"column:latitude" validate:"omitempty,latitude"`
+	Longitude   *float64  `json:"longitude,omitempty" gorm:"column:longitude" validate:"omitempty,longitude"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime;index"`
+	EmailIndex  *string   `json:"-" gorm:"column:email_index;type:char(64);uniqueIndex"`
+	PhoneIndex  *string   `json:"-" gorm:"column:phone_index;type:char(64);index"`
 }
 
 // TableName specifies the table name for GORM
@@ -32,6 +46,15 @@ func (Employee) TableName() string {
 	return "employees"
 }
 
+// BeforeSave refreshes the lookup indexes of encrypted columns. They stay
+// NULL for columns stored in plaintext, so the unique email index only
+// applies while email is encrypted.
+func (e *Employee) BeforeSave(*gorm.DB) error {
+	e.EmailIndex = pii.LookupIndex("email", e.Email)
+	e.PhoneIndex = pii.LookupIndex("phone", e.Phone)
+	return nil
+}
+
 // EmployeeResponse represents the response structure for API
 type EmployeeResponse struct {
 	ID          int    `json:"id"`
@@ -66,6 +89,59 @@ func (e *Employee) ToResponse() EmployeeResponse {
 	}
 }
 
+// Pagination describes the position of a page within a list result
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int64 `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+	HasPrev    bool  `json:"has_prev"`
+}
+
+// CursorPagination describes a keyset page. NextCursor is the cursor of the
+// following page and is absent on the last one.
+type CursorPagination struct {
+	Limit      int  `json:"limit"`
+	HasNext    bool `json:"has_next"`
+	NextCursor *int `json:"next_cursor,omitempty"`
+}
+
+// NewPagination builds pagination info for a page numbered from base (0 or 1).
+// All arithmetic is done in int64 so huge totals cannot overflow.
+func NewPagination(page, limit int, total int64, base int) Pagination {
+	var totalPages int64
+	if limit > 0 {
+		totalPages = (total + int64(limit) - 1) / int64(limit)
+	}
+
+	position := int64(page - base) // zero-based index of the current page
+	return Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    position+1 < totalPages,
+		HasPrev:    position > 0,
+	}
+}
+
+// CompanyCount is the number of employees at a single company
+type CompanyCount struct {
+	CompanyName string `json:"company_name"`
+	Count       int64  `json:"count"`
+}
+
+// EmployeeStats holds aggregate figures for the admin dashboard
+type EmployeeStats struct {
+	TotalEmployees  int64          `json:"total_employees"`
+	WithEmail       int64          `json:"with_email"`
+	WithoutEmail    int64          `json:"without_email"`
+	TopCompanies    []CompanyCount `json:"top_companies"`
+	NewestCreatedAt *time.Time     `json:"newest_created_at"`
+	OldestCreatedAt *time.Time     `json:"oldest_created_at"`
+}
+
 // ExcelUploadResponse represents the response after Excel upload
 type ExcelUploadResponse struct {
 	Message         string   `json:"message"`
@@ -76,6 +152,49 @@ type ExcelUploadResponse struct {
 	SkippedRecords  int      `json:"skipped_records"`
 	DuplicateEmails []string `json:"duplicate_emails,omitempty"`
 	ProcessingID    string   `json:"processing_id,omitempty"`
+	BatchID         string   `json:"batch_id,omitempty"`        // Set for staging imports; review and promote the batch under this ID
+	RemovedRecords  int64    `json:"removed_records,omitempty"` // Employees deleted by a replace import
+	Warnings        []string `json:"warnings,omitempty"`
+	Truncated       bool     `json:"truncated,omitempty"` // Validation errors stopped being collected at MAX_VALIDATION_ERRORS
+
+	// RowWarnings are problems on imported rows from rules that VALIDATION_SEVERITY downgrades to warnings
+	RowWarnings []ValidationError `json:"row_warnings,omitempty"`
+
+	// Files breaks a ZIP import down by the Excel files it contained
+	Files []FileImportSummary `json:"files,omitempty"`
+
+	// Actions counts what a file with a status column did to each row
+	Actions *ImportActionCounts `json:"actions,omitempty"`
+}
+
+// ImportActionCounts breaks down a sync import, whose status column routes
+// each row to an upsert, a delete or a skip
+type ImportActionCounts struct {
+	Inserted  int `json:"inserted"`  // Upserted rows whose email was new
+	Updated   int `json:"updated"`   // Upserted rows that changed an existing employee
+	Unchanged int `json:"unchanged"` // Upserted rows matching an existing employee exactly
+	Deleted   int `json:"deleted"`   // Delete rows that removed an employee
+	NotFound  int `json:"not_found"` // Delete rows whose email matched no employee
+	Skipped   int `json:"skipped"`   // Rows whose status is configured to be skipped
+	Conflicts int `json:"conflicts"` // Upserted rows clashing with another employee's unique value
+}
+
+// FileImportSummary is one Excel file's share of a ZIP import. Inserted and
+// skipped counts are only known for the import as a whole.
+type FileImportSummary struct {
+	Filename       string `json:"filename"`
+	TotalRecords   int    `json:"total_records"`
+	ValidRecords   int    `json:"valid_records"`
+	InvalidRecords int    `json:"invalid_records"`
+	Error          string `json:"error,omitempty"` // Why the file could not be parsed; its rows were not imported
+}
+
+// BulkDeleteResult reports a bulk delete: how many employees were removed and
+// which requested IDs matched no employee
+type BulkDeleteResult struct {
+	Deleted     int   `json:"deleted"`
+	DeletedIDs  []int `json:"deleted_ids"`
+	NotFoundIDs []int `json:"not_found_ids"`
 }
 
 // ValidationError represents validation errors
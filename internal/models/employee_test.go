@@ -1,9 +1,11 @@
 package models
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm/schema"
 )
 
 func TestEmployeeValidation(t *testing.T) {
@@ -269,6 +271,57 @@ func TestEmployeeBusinessLogic(t *testing.T) {
 	})
 }
 
+func TestEmployeeTimestampIndexes(t *testing.T) {
+	parsed, err := schema.Parse(&Employee{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("Failed to parse Employee schema: %v", err)
+	}
+
+	indexes := parsed.ParseIndexes()
+	for _, name := range []string{"idx_employees_created_at", "idx_employees_updated_at"} {
+		if _, ok := indexes[name]; !ok {
+			t.Errorf("Expected index %s to be declared on Employee", name)
+		}
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	tests := []struct {
+		name           string
+		page, limit    int
+		total          int64
+		base           int
+		wantTotalPages int64
+		wantHasNext    bool
+		wantHasPrev    bool
+	}{
+		{"empty result", 1, 20, 0, 1, 0, false, false},
+		{"exact multiple first page", 1, 10, 30, 1, 3, true, false},
+		{"exact multiple last page", 3, 10, 30, 1, 3, false, true},
+		{"partial last page", 4, 10, 31, 1, 4, false, true},
+		{"single page", 1, 20, 5, 1, 1, false, false},
+		{"zero-based middle page", 1, 10, 30, 0, 3, true, true},
+		{"zero-based last page", 2, 10, 30, 0, 3, false, true},
+		{"page past the end", 50, 10, 30, 1, 3, false, true},
+		{"huge total does not overflow", 1, 100, 100 << 40, 1, 1 << 40, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPagination(tt.page, tt.limit, tt.total, tt.base)
+			if p.TotalPages != tt.wantTotalPages {
+				t.Errorf("Expected total_pages %d, got %d", tt.wantTotalPages, p.TotalPages)
+			}
+			if p.HasNext != tt.wantHasNext {
+				t.Errorf("Expected has_next %v, got %v", tt.wantHasNext, p.HasNext)
+			}
+			if p.HasPrev != tt.wantHasPrev {
+				t.Errorf("Expected has_prev %v, got %v", tt.wantHasPrev, p.HasPrev)
+			}
+		})
+	}
+}
+
 // Benchmark tests for performance awareness
 func BenchmarkEmployeeValidation(b *testing.B) {
 	validate := validator.New()
@@ -0,0 +1,283 @@
+package models
+
+import (
+	"employee-management/internal/pii"
+	"fmt"
+	"strings"
+)
+
+// FieldDefinition describes one employee column. It is the single source for
+// column order, required-ness and maximum length: the Employee struct tags
+// (validate max=N and gorm varchar(ColumnSize)) must agree with it, which
+// TestFieldDefinitionsMatchStructTags enforces.
+//
+// It is also the allowlist for every query feature that names a column:
+// search, sort and filter only accept columns with the matching flag, so the
+// column names that reach SQL always come from this table, never from input.
+type FieldDefinition struct {
+	Column      string // Database column, JSON key and spreadsheet header
+	Required    bool   // Must be present in import files and non-blank in every row
+	MaxLength   int    // Longest accepted value, equal to the varchar size
+	Searchable  bool   // Matched by the free-text search
+	Sortable    bool   // Accepted as a sort key
+	Filterable  bool   // Accepted in structured filters
+	Encryptable bool   // May be encrypted at rest through PII_ENCRYPTED_FIELDS
+
+	// PreserveWhitespace keeps leading and trailing whitespace of imported
+	// values, for columns where it is meaningful; other columns are trimmed
+	PreserveWhitespace bool
+}
+
+// ColumnSize returns the varchar size of the column: MaxLength, or the
+// length of its ciphertext when the column may be encrypted
+func (f FieldDefinition) ColumnSize() int {
+	if f.Encryptable {
+		return pii.CiphertextLength(f.MaxLength)
+	}
+	return f.MaxLength
+}
+
+// EmployeeFields lists the editable employee columns in template/export order
+var EmployeeFields = []FieldDefinition{
+	{Column: "first_name", Required: true, MaxLength: 50, Searchable: true, Sortable: true, Filterable: true},
+	{Column: "last_name", Required: true, MaxLength: 50, Searchable: true, Sortable: true, Filterable: true},
+	{Column: "company_name", MaxLength: 100, Searchable: true, Sortable: true, Filterable: true},
+	{Column: "address", MaxLength: 255},
+	{Column: "city", MaxLength: 50, Sortable: true, Filterable: true},
+	{Column: "county", MaxLength: 50, Sortable: true, Filterable: true},
+	{Column: "postal", MaxLength: 20, Filterable: true},
+	{Column: "phone", MaxLength: 20, Filterable: true, Encryptable: true},
+	{Column: "email", Required: true, MaxLength: 255, Searchable: true, Sortable: true, Filterable: true, Encryptable: true},
+	{Column: "web", MaxLength: 255},
+}
+
+// EmployeeColumnNames returns the column names of EmployeeFields in order
+func EmployeeColumnNames() []string {
+	return employeeColumnsWhere(func(FieldDefinition) bool { return true })
+}
+
+// RequiredEmployeeColumns returns the columns marked Required, in order
+func RequiredEmployeeColumns() []string {
+	return employeeColumnsWhere(func(field FieldDefinition) bool { return field.Required })
+}
+
+// ParseRequiredColumns turns a comma-separated column list into the set of
+// headers an import must provide, in template order. An empty list means the
+// columns marked Required; every listed name must be one of EmployeeFields.
+func ParseRequiredColumns(spec string) ([]string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return RequiredEmployeeColumns(), nil
+	}
+
+	listed := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		column := strings.ToLower(strings.TrimSpace(name))
+		if column == "" {
+			continue
+		}
+		if _, ok := LookupEmployeeField(column); !ok {
+			return nil, fmt.Errorf("unknown field %q", column)
+		}
+		listed[column] = true
+	}
+
+	return employeeColumnsWhere(func(field FieldDefinition) bool { return listed[field.Column] }), nil
+}
+
+// EncryptableEmployeeColumns returns the columns PII_ENCRYPTED_FIELDS may name, in order
+func EncryptableEmployeeColumns() []string {
+	return employeeColumnsWhere(func(field FieldDefinition) bool { return field.Encryptable })
+}
+
+// SearchableEmployeeColumns returns the columns matched by free-text search, in order
+func SearchableEmployeeColumns() []string {
+	return employeeColumnsWhere(func(field FieldDefinition) bool { return field.Searchable })
+}
+
+// employeeColumnsWhere returns the columns of EmployeeFields accepted by keep, in order
+func employeeColumnsWhere(keep func(FieldDefinition) bool) []string {
+	var names []string
+	for _, field := range EmployeeFields {
+		if keep(field) {
+			names = append(names, field.Column)
+		}
+	}
+	return names
+}
+
+// LookupEmployeeField returns the definition of a column
+func LookupEmployeeField(column string) (FieldDefinition, bool) {
+	for _, field := range EmployeeFields {
+		if field.Column == column {
+			return field, true
+		}
+	}
+	return FieldDefinition{}, false
+}
+
+// PreservesWhitespace reports whether imported values of column keep their
+// surrounding whitespace. Columns outside EmployeeFields are trimmed.
+func PreservesWhitespace(column string) bool {
+	field, ok := LookupEmployeeField(column)
+	return ok && field.PreserveWhitespace
+}
+
+// ValidateSortField reports whether column may be used as a sort key
+func ValidateSortField(column string) error {
+	return checkFieldCapability(column, "sortable", func(field FieldDefinition) bool { return field.Sortable })
+}
+
+// SortCreatedAt is the timestamp column accepted as a sort key besides the
+// Sortable fields
+const SortCreatedAt = "created_at"
+
+// Values of the sort_case parameter
+const (
+	SortCaseNatural     = "natural"
+	SortCaseInsensitive = "insensitive"
+)
+
+// SortOptions orders a list. The zero value keeps primary-key order.
+type SortOptions struct {
+	Field           string // A column accepted by ParseSortOptions; empty sorts by id
+	Desc            bool
+	CaseInsensitive bool // Compare LOWER(Field) instead of relying on the column's collation
+}
+
+// ParseSortOptions validates the sort, order and sort_case parameters of a
+// list request. order is asc (the default) or desc; an order without a field
+// applies to id. sort_case is natural (the default, the column's collation)
+// or insensitive, which needs a text column. Columns stored encrypted are
+// rejected, since their ciphertext has no meaningful order.
+func ParseSortOptions(field, order, sortCase string) (SortOptions, error) {
+	var options SortOptions
+	switch strings.ToLower(strings.TrimSpace(order)) {
+	case "", "asc":
+	case "desc":
+		options.Desc = true
+	default:
+		return SortOptions{}, fmt.Errorf("order must be asc or desc")
+	}
+
+	field = strings.ToLower(strings.TrimSpace(field))
+	if field != "" && field != SortCreatedAt {
+		if err := ValidateSortField(field); err != nil {
+			return SortOptions{}, err
+		}
+		if pii.Encrypted(field) {
+			return SortOptions{}, fmt.Errorf("field %q is encrypted at rest and cannot be sorted", field)
+		}
+	}
+	options.Field = field
+
+	switch strings.ToLower(strings.TrimSpace(sortCase)) {
+	case "", SortCaseNatural:
+	case SortCaseInsensitive:
+		if field == "" || field == SortCreatedAt {
+			return SortOptions{}, fmt.Errorf("sort_case=insensitive needs a text column in sort")
+		}
+		options.CaseInsensitive = true
+	default:
+		return SortOptions{}, fmt.Errorf("sort_case must be natural or insensitive")
+	}
+	return options, nil
+}
+
+// OrderClause returns the ORDER BY clause, with id breaking ties so pages
+// stay stable
+func (o SortOptions) OrderClause() string {
+	direction := "ASC"
+	if o.Desc {
+		direction = "DESC"
+	}
+	if o.Field == "" {
+		return "id " + direction
+	}
+	column := o.Field
+	if o.CaseInsensitive {
+		// Defeats a plain index on the column; see the README for a functional one
+		column = "LOWER(" + column + ")"
+	}
+	return column + " " + direction + ", id " + direction
+}
+
+// ValidateFilterField reports whether column may be used in a structured filter
+func ValidateFilterField(column string) error {
+	return checkFieldCapability(column, "filterable", func(field FieldDefinition) bool { return field.Filterable })
+}
+
+// ValidateSearchField reports whether column is matched by free-text search
+func ValidateSearchField(column string) error {
+	return checkFieldCapability(column, "searchable", func(field FieldDefinition) bool { return field.Searchable })
+}
+
+// checkFieldCapability rejects unknown columns and columns without the capability
+func checkFieldCapability(column, capability string, allowed func(FieldDefinition) bool) error {
+	field, ok := LookupEmployeeField(column)
+	if !ok {
+		return fmt.Errorf("unknown field %q", column)
+	}
+	if !allowed(field) {
+		return fmt.Errorf("field %q is not %s", column, capability)
+	}
+	return nil
+}
+
+// ColumnValue returns the employee's value for one of the EmployeeFields
+// columns, or an empty string for any other name
+func (e *Employee) ColumnValue(column string) string {
+	switch column {
+	case "first_name":
+		return e.FirstName
+	case "last_name":
+		return e.LastName
+	case "company_name":
+		return e.CompanyName
+	case "address":
+		return e.Address
+	case "city":
+		return e.City
+	case "county":
+		return e.County
+	case "postal":
+		return e.Postal
+	case "phone":
+		return e.Phone
+	case "email":
+		return e.Email
+	case "web":
+		return e.Web
+	default:
+		return ""
+	}
+}
+
+// SetColumnValue sets the employee's value for one of the EmployeeFields
+// columns, reporting false for any other name
+func (e *Employee) SetColumnValue(column, value string) bool {
+	switch column {
+	case "first_name":
+		e.FirstName = value
+	case "last_name":
+		e.LastName = value
+	case "company_name":
+		e.CompanyName = value
+	case "address":
+		e.Address = value
+	case "city":
+		e.City = value
+	case "county":
+		e.County = value
+	case "postal":
+		e.Postal = value
+	case "phone":
+		e.Phone = value
+	case "email":
+		e.Email = value
+	case "web":
+		e.Web = value
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+package models
+
+import (
+	"bytes"
+	"employee-management/internal/pii"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	columnTagPattern  = regexp.MustCompile(`column:(\w+)`)
+	varcharTagPattern = regexp.MustCompile(`type:varchar\((\d+)\)`)
+	maxTagPattern     = regexp.MustCompile(`(?:^|,)max=(\d+)`)
+)
+
+// structLimits returns column -> (varchar size, validate max) from the Employee tags
+func structLimits(t *testing.T) map[string][2]int {
+	t.Helper()
+
+	limits := make(map[string][2]int)
+	employeeType := reflect.TypeOf(Employee{})
+	for i := 0; i < employeeType.NumField(); i++ {
+		field := employeeType.Field(i)
+		gormTag := field.Tag.Get("gorm")
+
+		column := columnTagPattern.FindStringSubmatch(gormTag)
+		varchar := varcharTagPattern.FindStringSubmatch(gormTag)
+		if column == nil || varchar == nil {
+			continue
+		}
+
+		size, _ := strconv.Atoi(varchar[1])
+		max := 0
+		if match := maxTagPattern.FindStringSubmatch(field.Tag.Get("validate")); match != nil {
+			max, _ = strconv.Atoi(match[1])
+		}
+		limits[column[1]] = [2]int{size, max}
+	}
+	return limits
+}
+
+func TestFieldDefinitionsMatchStructTags(t *testing.T) {
+	limits := structLimits(t)
+
+	if len(limits) != len(EmployeeFields) {
+		t.Errorf("Expected %d varchar columns to match EmployeeFields, found %d", len(EmployeeFields), len(limits))
+	}
+
+	for _, definition := range EmployeeFields {
+		limit, ok := limits[definition.Column]
+		if !ok {
+			t.Errorf("%s: no varchar column on Employee", definition.Column)
+			continue
+		}
+		if limit[0] != definition.ColumnSize() {
+			t.Errorf("%s: varchar(%d) does not match column size %d", definition.Column, limit[0], definition.ColumnSize())
+		}
+		if limit[1] != definition.MaxLength {
+			t.Errorf("%s: validate max=%d does not match MaxLength %d", definition.Column, limit[1], definition.MaxLength)
+		}
+	}
+}
+
+func TestFieldDefinitionsRequired(t *testing.T) {
+	employeeType := reflect.TypeOf(Employee{})
+	for _, definition := range EmployeeFields {
+		for i := 0; i < employeeType.NumField(); i++ {
+			field := employeeType.Field(i)
+			if field.Tag.Get("json") != definition.Column {
+				continue
+			}
+			required := strings.HasPrefix(field.Tag.Get("validate"), "required")
+			if required != definition.Required {
+				t.Errorf("%s: validate required=%v does not match definition %v", definition.Column, required, definition.Required)
+			}
+		}
+	}
+}
+
+func TestWebMaxLengthEnforced(t *testing.T) {
+	employee := Employee{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Web:       "https://example.com/" + strings.Repeat("a", 255),
+	}
+	if err := validator.New().Struct(employee); err == nil {
+		t.Error("Expected a web URL longer than the column to fail validation")
+	}
+}
+
+func TestFieldCapabilities(t *testing.T) {
+	validators := map[string]func(string) error{
+		"search": ValidateSearchField,
+		"sort":   ValidateSortField,
+		"filter": ValidateFilterField,
+	}
+
+	// Unknown names and SQL fragments are rejected by every feature
+	for _, column := range []string{"salary", "", "id; DROP TABLE employees", "FIRST_NAME", "first_name "} {
+		for feature, validate := range validators {
+			err := validate(column)
+			if err == nil || !strings.Contains(err.Error(), "unknown field") {
+				t.Errorf("%s: expected %q to be rejected as unknown, got %v", feature, column, err)
+			}
+		}
+	}
+
+	// Known columns are accepted exactly where their definition allows it
+	for _, field := range EmployeeFields {
+		allowed := map[string]bool{"search": field.Searchable, "sort": field.Sortable, "filter": field.Filterable}
+		for feature, validate := range validators {
+			err := validate(field.Column)
+			if allowed[feature] && err != nil {
+				t.Errorf("%s: expected %s to be accepted, got %v", feature, field.Column, err)
+			}
+			if !allowed[feature] && err == nil {
+				t.Errorf("%s: expected %s to be rejected", feature, field.Column)
+			}
+		}
+	}
+
+	// Every searchable or sortable column can also be filtered, so a field
+	// never ends up sortable but not filterable by accident
+	for _, field := range EmployeeFields {
+		if (field.Searchable || field.Sortable) && !field.Filterable {
+			t.Errorf("%s: searchable or sortable fields must also be filterable", field.Column)
+		}
+	}
+}
+
+func TestParseSortOptions(t *testing.T) {
+	for _, tt := range []struct {
+		field, order, sortCase string
+		want                   SortOptions
+		clause                 string
+	}{
+		{"", "", "", SortOptions{}, "id ASC"},
+		{"", "desc", "", SortOptions{Desc: true}, "id DESC"},
+		{"last_name", "", "", SortOptions{Field: "last_name"}, "last_name ASC, id ASC"},
+		{" City ", "DESC", "", SortOptions{Field: "city", Desc: true}, "city DESC, id DESC"},
+		{"created_at", "asc", "natural", SortOptions{Field: "created_at"}, "created_at ASC, id ASC"},
+		{"last_name", "desc", "Insensitive", SortOptions{Field: "last_name", Desc: true, CaseInsensitive: true}, "LOWER(last_name) DESC, id DESC"},
+	} {
+		got, err := ParseSortOptions(tt.field, tt.order, tt.sortCase)
+		if err != nil || got != tt.want {
+			t.Errorf("%q/%q/%q: expected %+v, got %+v (%v)", tt.field, tt.order, tt.sortCase, tt.want, got, err)
+			continue
+		}
+		if clause := got.OrderClause(); clause != tt.clause {
+			t.Errorf("%q/%q/%q: expected clause %q, got %q", tt.field, tt.order, tt.sortCase, tt.clause, clause)
+		}
+	}
+
+	for _, tt := range []struct{ field, order, sortCase string }{
+		{"salary", "", ""},
+		{"web", "", ""},
+		{"id; DROP TABLE employees", "", ""},
+		{"last_name", "up", ""},
+		{"last_name", "", "upper"},
+		{"", "", "insensitive"},
+		{"created_at", "", "insensitive"},
+	} {
+		if _, err := ParseSortOptions(tt.field, tt.order, tt.sortCase); err == nil {
+			t.Errorf("%q/%q/%q: expected an error", tt.field, tt.order, tt.sortCase)
+		}
+	}
+
+	// Ciphertext has no useful order, so encrypted columns cannot be sorted
+	cipher, err := pii.NewCipher(bytes.Repeat([]byte{9}, pii.KeySize), []string{"email"})
+	if err != nil {
+		t.Fatalf("NewCipher failed: %v", err)
+	}
+	pii.SetCipher(cipher)
+	t.Cleanup(func() { pii.SetCipher(nil) })
+	if _, err := ParseSortOptions("email", "", ""); err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Errorf("Expected encrypted email to be rejected, got %v", err)
+	}
+}
+
+func TestColumnValueCoversAllFields(t *testing.T) {
+	employee := Employee{
+		FirstName: "a", LastName: "b", CompanyName: "c", Address: "d", City: "e",
+		County: "f", Postal: "g", Phone: "h", Email: "i", Web: "j",
+	}
+	seen := make(map[string]bool)
+	for _, column := range EmployeeColumnNames() {
+		value := employee.ColumnValue(column)
+		if value == "" || seen[value] {
+			t.Errorf("%s: expected a distinct value, got %q", column, value)
+		}
+		seen[value] = true
+	}
+}
+
+func TestParseRequiredColumns(t *testing.T) {
+	columns, err := ParseRequiredColumns("")
+	if err != nil || strings.Join(columns, ",") != "first_name,last_name,email" {
+		t.Errorf("Expected the definition's required columns by default, got %v (err %v)", columns, err)
+	}
+
+	columns, err = ParseRequiredColumns(" Email, company_name ,first_name,")
+	if err != nil || strings.Join(columns, ",") != "first_name,company_name,email" {
+		t.Errorf("Expected listed columns in template order, got %v (err %v)", columns, err)
+	}
+
+	if _, err := ParseRequiredColumns("first_name,salary"); err == nil || !strings.Contains(err.Error(), "salary") {
+		t.Errorf("Expected error naming the unknown field, got %v", err)
+	}
+}
+
+func TestPreservesWhitespace(t *testing.T) {
+	for _, field := range EmployeeFields {
+		if PreservesWhitespace(field.Column) {
+			t.Errorf("Expected %s to be trimmed on import by default", field.Column)
+		}
+	}
+	if PreservesWhitespace("latitude") {
+		t.Error("Expected columns outside EmployeeFields to be trimmed")
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+// GeoJSON media type, as registered in RFC 7946
+const GeoJSONContentType = "application/geo+json"
+
+// FeatureCollection is a GeoJSON FeatureCollection (RFC 7946)
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON Feature placing one employee on the map
+type Feature struct {
+	Type       string            `json:"type"`
+	ID         int               `json:"id"`
+	Geometry   Point             `json:"geometry"`
+	Properties FeatureProperties `json:"properties"`
+}
+
+// Point is a GeoJSON Point geometry. Coordinates are [longitude, latitude],
+// the order GeoJSON mandates.
+type Point struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// FeatureProperties are the employee details shown for a map marker
+type FeatureProperties struct {
+	Name        string `json:"name"`
+	CompanyName string `json:"company_name"`
+}
+
+// NewFeatureCollection wraps features in a collection; an empty list still
+// encodes as [] so map clients can iterate it
+func NewFeatureCollection(features []Feature) FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// ToFeature returns the employee as a GeoJSON point feature, or false when
+// either coordinate is missing
+func (e *Employee) ToFeature() (Feature, bool) {
+	if e.Latitude == nil || e.Longitude == nil {
+		return Feature{}, false
+	}
+	return Feature{
+		Type:     "Feature",
+		ID:       e.ID,
+		Geometry: Point{Type: "Point", Coordinates: [2]float64{*e.Longitude, *e.Latitude}},
+		Properties: FeatureProperties{
+			Name:        e.FirstName + " " + e.LastName,
+			CompanyName: e.CompanyName,
+		},
+	}, true
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// nullableResponseFields switches EmployeeResponse to encoding blank optional
+// fields as null; set once at startup from NULLABLE_RESPONSE_FIELDS
+var nullableResponseFields atomic.Bool
+
+// SetNullableResponseFields chooses how blank optional fields are encoded in
+// employee responses: null when enabled, "" (the default) otherwise. Required
+// fields are always encoded as strings.
+func SetNullableResponseFields(enabled bool) {
+	nullableResponseFields.Store(enabled)
+}
+
+// nullableEmployeeResponse is the wire shape of EmployeeResponse with
+// NULLABLE_RESPONSE_FIELDS on; the keys and their order are unchanged
+type nullableEmployeeResponse struct {
+	ID          int     `json:"id"`
+	FirstName   string  `json:"first_name"`
+	LastName    string  `json:"last_name"`
+	CompanyName *string `json:"company_name"`
+	Address     *string `json:"address"`
+	City        *string `json:"city"`
+	County      *string `json:"county"`
+	Postal      *string `json:"postal"`
+	Phone       *string `json:"phone"`
+	Email       string  `json:"email"`
+	Web         *string `json:"web"`
+	FullName    string  `json:"full_name"`
+}
+
+// MarshalJSON encodes the response, with blank optional fields as null when
+// NULLABLE_RESPONSE_FIELDS is on. Decoding needs no counterpart: null decodes
+// to "".
+func (r EmployeeResponse) MarshalJSON() ([]byte, error) {
+	type plain EmployeeResponse // Same fields without this method
+	if !nullableResponseFields.Load() {
+		return json.Marshal(plain(r))
+	}
+	return json.Marshal(nullableEmployeeResponse{
+		ID:          r.ID,
+		FirstName:   r.FirstName,
+		LastName:    r.LastName,
+		CompanyName: nullIfEmpty(r.CompanyName),
+		Address:     nullIfEmpty(r.Address),
+		City:        nullIfEmpty(r.City),
+		County:      nullIfEmpty(r.County),
+		Postal:      nullIfEmpty(r.Postal),
+		Phone:       nullIfEmpty(r.Phone),
+		Email:       r.Email,
+		Web:         nullIfEmpty(r.Web),
+		FullName:    r.FullName,
+	})
+}
+
+// nullIfEmpty returns nil for an empty string, so it encodes as null
+func nullIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,41 @@
+package models
+
+import "employee-management/internal/models/employeepb"
+
+// ToProto converts the API representation of an employee to its Protobuf
+// message (employeepb/employee.proto), so REST and gRPC share one shape
+func (r EmployeeResponse) ToProto() *employeepb.Employee {
+	return &employeepb.Employee{
+		Id:          int64(r.ID),
+		FirstName:   r.FirstName,
+		LastName:    r.LastName,
+		CompanyName: r.CompanyName,
+		Address:     r.Address,
+		City:        r.City,
+		County:      r.County,
+		Postal:      r.Postal,
+		Phone:       r.Phone,
+		Email:       r.Email,
+		Web:         r.Web,
+		FullName:    r.FullName,
+	}
+}
+
+// FromProto converts a Protobuf employee message back to the
+// API representation; a nil message yields the zero value
+func FromProto(message *employeepb.Employee) EmployeeResponse {
+	return EmployeeResponse{
+		ID:          int(message.GetId()),
+		FirstName:   message.GetFirstName(),
+		LastName:    message.GetLastName(),
+		CompanyName: message.GetCompanyName(),
+		Address:     message.GetAddress(),
+		City:        message.GetCity(),
+		County:      message.GetCounty(),
+		Postal:      message.GetPostal(),
+		Phone:       message.GetPhone(),
+		Email:       message.GetEmail(),
+		Web:         message.GetWeb(),
+		FullName:    message.GetFullName(),
+	}
+}
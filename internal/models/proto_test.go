@@ -0,0 +1,53 @@
+package models
+
+import (
+	"employee-management/internal/models/employeepb"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestEmployeeProtoRoundTrip(t *testing.T) {
+	employee := Employee{
+		ID: 42, FirstName: "John", LastName: "Doe", CompanyName: "Acme",
+		Address: "1 Main St", City: "Springfield", County: "Clark", Postal: "12345",
+		Phone: "555-0100", Email: "john@example.com", Web: "https://example.com",
+	}
+	response := employee.ToResponse()
+
+	data, err := proto.Marshal(response.ToProto())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded employeepb.Employee
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if got := FromProto(&decoded); got != response {
+		t.Errorf("Round trip changed the employee:\n got %+v\nwant %+v", got, response)
+	}
+	if got := FromProto(nil); got != (EmployeeResponse{}) {
+		t.Errorf("Expected zero value for a nil message, got %+v", got)
+	}
+}
+
+// TestEmployeeProtoMatchesResponse fails when a field is added to one shape
+// but not the other, so REST and gRPC cannot drift apart
+func TestEmployeeProtoMatchesResponse(t *testing.T) {
+	protoFields := (&employeepb.Employee{}).ProtoReflect().Descriptor().Fields()
+
+	responseType := reflect.TypeOf(EmployeeResponse{})
+	if responseType.NumField() != protoFields.Len() {
+		t.Errorf("EmployeeResponse has %d fields, the Protobuf message %d", responseType.NumField(), protoFields.Len())
+	}
+	for i := 0; i < responseType.NumField(); i++ {
+		name := strings.Split(responseType.Field(i).Tag.Get("json"), ",")[0]
+		if protoFields.ByName(protoreflect.Name(name)) == nil {
+			t.Errorf("Protobuf message has no field for %s", name)
+		}
+	}
+}
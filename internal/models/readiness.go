@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Dependency states reported by the readiness probe. Degraded only applies to
+// the overall status: the service is ready but an optional dependency is down.
+const (
+	DependencyUp       = "up"
+	DependencyDown     = "down"
+	DependencyDegraded = "degraded"
+)
+
+// ReadinessReport is the result of one readiness evaluation
+type ReadinessReport struct {
+	Ready        bool                        `json:"ready"`
+	Status       string                      `json:"status"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// MemoryStatus is the latest memory sample of the upload memory guard.
+// While Overloaded, new uploads are refused until memory falls back below
+// RecoverBytes.
+type MemoryStatus struct {
+	Enabled      bool       `json:"enabled"`
+	UsedBytes    uint64     `json:"used_bytes"`
+	LimitBytes   uint64     `json:"limit_bytes,omitempty"`
+	RecoverBytes uint64     `json:"recover_bytes,omitempty"`
+	Overloaded   bool       `json:"overloaded"`
+	CheckedAt    *time.Time `json:"checked_at,omitempty"`
+}
+
+// DependencyStatus is the outcome of checking one dependency. A dependency
+// that is down only makes the service unready when it is required.
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	Required  bool   `json:"required"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// StagedEmployee is a validated import row held in employees_staging until
+// its batch is promoted into employees or discarded. Email is not unique here;
+// duplicates are resolved when the batch is promoted.
+type StagedEmployee struct {
+	ID          int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	BatchID     string    `json:"batch_id" gorm:"column:batch_id;type:varchar(36);not null;index"`
+	FirstName   string    `json:"first_name" gorm:"column:first_name;type:varchar(50);not null"`
+	LastName    string    `json:"last_name" gorm:"column:last_name;type:varchar(50);not null"`
+	CompanyName string    `json:"company_name" gorm:"column:company_name;type:varchar(100)"`
+	Address     string    `json:"address" gorm:"column:address;type:varchar(255)"`
+	City        string    `json:"city" gorm:"column:city;type:varchar(50)"`
+	County      string    `json:"county" gorm:"column:county;type:varchar(50)"`
+	Postal      string    `json:"postal" gorm:"column:postal;type:varchar(20)"`
+	Phone       string    `json:"phone" gorm:"column:phone;type:varchar(71);serializer:pii"`
+	Email       string    `json:"email" gorm:"column:email;type:varchar(387);serializer:pii"`
+	Web         string    `json:"web" gorm:"column:web;type:varchar(255)"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (StagedEmployee) TableName() string {
+	return "employees_staging"
+}
+
+// NewStagedEmployee copies an employee into a staging row of the given batch
+func NewStagedEmployee(batchID string, e Employee) StagedEmployee {
+	return StagedEmployee{
+		BatchID:     batchID,
+		FirstName:   e.FirstName,
+		LastName:    e.LastName,
+		CompanyName: e.CompanyName,
+		Address:     e.Address,
+		City:        e.City,
+		County:      e.County,
+		Postal:      e.Postal,
+		Phone:       e.Phone,
+		Email:       e.Email,
+		Web:         e.Web,
+	}
+}
+
+// ToEmployee converts a staged row back into a new employee
+func (s *StagedEmployee) ToEmployee() Employee {
+	return Employee{
+		FirstName:   s.FirstName,
+		LastName:    s.LastName,
+		CompanyName: s.CompanyName,
+		Address:     s.Address,
+		City:        s.City,
+		County:      s.County,
+		Postal:      s.Postal,
+		Phone:       s.Phone,
+		Email:       s.Email,
+		Web:         s.Web,
+	}
+}
+
+// PromoteResult reports what happened when a staging batch was promoted
+type PromoteResult struct {
+	BatchID         string   `json:"batch_id"`
+	InsertedRecords int      `json:"inserted_records"`
+	SkippedRecords  int      `json:"skipped_records"`
+	DuplicateEmails []string `json:"duplicate_emails,omitempty"`
+}
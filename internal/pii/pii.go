@@ -0,0 +1,163 @@
+// Package pii encrypts personal data at rest. Designated columns are stored
+// AES-GCM encrypted through the "pii" GORM serializer and decrypted on read;
+// columns that must stay searchable get a keyed HMAC index alongside.
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Prefix marks encrypted values. Values without it are read as plaintext, so
+// rows written before encryption was enabled stay readable.
+const Prefix = "enc:v1:"
+
+// KeySize is the length of the decoded PII_ENCRYPTION_KEY (AES-256)
+const KeySize = 32
+
+const (
+	nonceSize = 12
+	tagSize   = 16
+)
+
+// ErrNoKey is returned when an encrypted value is read without a key
+var ErrNoKey = errors.New("value is encrypted but PII_ENCRYPTION_KEY is not set")
+
+// Cipher encrypts the designated columns and computes their lookup indexes.
+// Separate keys for encryption and indexing are derived from the configured
+// key, so an index never reveals anything about the ciphertext.
+type Cipher struct {
+	aead     cipher.AEAD
+	indexKey []byte
+	columns  map[string]bool
+}
+
+// ParseKey decodes a base64 PII_ENCRYPTION_KEY of KeySize bytes
+func ParseKey(raw string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// NewCipher returns a cipher encrypting the given columns with key
+func NewCipher(key []byte, columns []string) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(derive(key, "encryption"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cipher{aead: aead, indexKey: derive(key, "index"), columns: make(map[string]bool)}
+	for _, column := range columns {
+		c.columns[column] = true
+	}
+	return c, nil
+}
+
+// derive returns a purpose-specific subkey of key
+func derive(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("employee-management/pii/" + purpose))
+	return mac.Sum(nil)
+}
+
+// Encrypts reports whether column is stored encrypted
+func (c *Cipher) Encrypts(column string) bool {
+	return c.columns[column]
+}
+
+// Encrypt returns the prefixed ciphertext of value with a random nonce, so
+// equal values never produce equal ciphertexts. Empty values stay empty.
+func (c *Cipher) Encrypt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of an encrypted value; other values are
+// returned unchanged
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, Prefix) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value[len(Prefix):])
+	if err != nil || len(sealed) < nonceSize+tagSize {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value (wrong key?): %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// Index returns the deterministic lookup index of value: a hex HMAC of the
+// trimmed, lowercased value, matching MySQL's case-insensitive comparison
+func (c *Cipher) Index(value string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CiphertextLength returns the stored length of an encrypted value whose
+// plaintext is at most n bytes, for sizing encrypted columns
+func CiphertextLength(n int) int {
+	return len(Prefix) + base64.StdEncoding.EncodedLen(nonceSize+n+tagSize)
+}
+
+// active is the cipher used by the serializer and lookups; nil disables encryption
+var active atomic.Pointer[Cipher]
+
+// SetCipher installs the cipher at startup, before the database is used.
+// nil disables encryption; encrypted values then fail to read.
+func SetCipher(c *Cipher) {
+	active.Store(c)
+}
+
+// Active returns the installed cipher, or nil when encryption is disabled
+func Active() *Cipher {
+	return active.Load()
+}
+
+// Encrypted reports whether column is currently stored encrypted
+func Encrypted(column string) bool {
+	c := Active()
+	return c != nil && c.Encrypts(column)
+}
+
+// LookupIndex returns the index for a value of column, or nil when the
+// column is not encrypted (or the value is empty) and is queried directly
+func LookupIndex(column, value string) *string {
+	c := Active()
+	if c == nil || !c.Encrypts(column) || strings.TrimSpace(value) == "" {
+		return nil
+	}
+	index := c.Index(value)
+	return &index
+}
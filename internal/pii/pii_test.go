@@ -0,0 +1,138 @@
+package pii
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+func testCipher(t *testing.T, fill byte, columns ...string) *Cipher {
+	t.Helper()
+	c, err := NewCipher(bytes.Repeat([]byte{fill}, KeySize), columns)
+	if err != nil {
+		t.Fatalf("NewCipher failed: %v", err)
+	}
+	return c
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	c := testCipher(t, 1, "email")
+
+	for _, value := range []string{"john@example.com", "jöhn@exämple.com", strings.Repeat("a", 255)} {
+		first, err := c.Encrypt(value)
+		if err != nil {
+			t.Fatalf("Encrypt failed: %v", err)
+		}
+		second, _ := c.Encrypt(value)
+		if !strings.HasPrefix(first, Prefix) || strings.Contains(first, value) || first == second {
+			t.Errorf("Expected distinct prefixed ciphertexts hiding the value, got %q and %q", first, second)
+		}
+		if plaintext, err := c.Decrypt(first); err != nil || plaintext != value {
+			t.Errorf("Decrypt(Encrypt(%q)) = %q, %v", value, plaintext, err)
+		}
+	}
+
+	if empty, _ := c.Encrypt(""); empty != "" {
+		t.Errorf("Expected an empty value to stay empty, got %q", empty)
+	}
+	if plaintext, err := c.Decrypt("legacy@example.com"); err != nil || plaintext != "legacy@example.com" {
+		t.Errorf("Expected plaintext written before encryption to pass through, got %q, %v", plaintext, err)
+	}
+}
+
+func TestCipher_RejectsWrongKeyAndTampering(t *testing.T) {
+	ciphertext, _ := testCipher(t, 1).Encrypt("555-0100")
+
+	if _, err := testCipher(t, 2).Decrypt(ciphertext); err == nil {
+		t.Error("Expected decryption with another key to fail")
+	}
+
+	sealed, _ := base64.StdEncoding.DecodeString(ciphertext[len(Prefix):])
+	sealed[len(sealed)-1] ^= 1
+	if _, err := testCipher(t, 1).Decrypt(Prefix + base64.StdEncoding.EncodeToString(sealed)); err == nil {
+		t.Error("Expected a tampered ciphertext to fail")
+	}
+	if _, err := testCipher(t, 1).Decrypt(Prefix + "short"); err == nil {
+		t.Error("Expected a malformed ciphertext to fail")
+	}
+}
+
+func TestCipher_Index(t *testing.T) {
+	c := testCipher(t, 1, "email")
+
+	if c.Index("John@Example.com ") != c.Index("john@example.com") {
+		t.Error("Expected the index to ignore case and surrounding spaces, like MySQL's comparison")
+	}
+	if c.Index("john@example.com") == c.Index("jane@example.com") {
+		t.Error("Expected different values to get different indexes")
+	}
+	if c.Index("john@example.com") == testCipher(t, 2).Index("john@example.com") {
+		t.Error("Expected the index to depend on the key")
+	}
+}
+
+func TestCiphertextLength(t *testing.T) {
+	c := testCipher(t, 1)
+	for _, n := range []int{1, 20, 255} {
+		ciphertext, _ := c.Encrypt(strings.Repeat("x", n))
+		if len(ciphertext) > CiphertextLength(n) {
+			t.Errorf("Ciphertext of %d bytes is %d long, more than CiphertextLength %d", n, len(ciphertext), CiphertextLength(n))
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	valid := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, KeySize))
+	if key, err := ParseKey(" " + valid + "\n"); err != nil || len(key) != KeySize {
+		t.Errorf("Expected a valid key to parse, got %v", err)
+	}
+	for _, raw := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("too short"))} {
+		if _, err := ParseKey(raw); err == nil {
+			t.Errorf("Expected %q to be rejected", raw)
+		}
+	}
+}
+
+type record struct {
+	Email string `gorm:"column:email;serializer:pii"`
+	Phone string `gorm:"column:phone;serializer:pii"`
+}
+
+func TestSerializer(t *testing.T) {
+	t.Cleanup(func() { SetCipher(nil) })
+	parsed, err := schema.Parse(&record{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	email, phone := parsed.LookUpField("email"), parsed.LookUpField("phone")
+	ctx := context.Background()
+
+	SetCipher(testCipher(t, 1, "email"))
+	stored, err := Serializer{}.Value(ctx, email, reflect.Value{}, "john@example.com")
+	if err != nil || !strings.HasPrefix(stored.(string), Prefix) {
+		t.Fatalf("Expected the designated column to be encrypted, got %v, %v", stored, err)
+	}
+	if plain, _ := (Serializer{}).Value(ctx, phone, reflect.Value{}, "555-0100"); plain != "555-0100" {
+		t.Errorf("Expected other columns to stay plaintext, got %v", plain)
+	}
+
+	var loaded record
+	dst := reflect.ValueOf(&loaded).Elem()
+	if err := (Serializer{}).Scan(ctx, email, dst, []byte(stored.(string))); err != nil || loaded.Email != "john@example.com" {
+		t.Errorf("Expected the value decrypted on read, got %q, %v", loaded.Email, err)
+	}
+	if err := (Serializer{}).Scan(ctx, phone, dst, "555-0100"); err != nil || loaded.Phone != "555-0100" {
+		t.Errorf("Expected plaintext read as is, got %q, %v", loaded.Phone, err)
+	}
+
+	SetCipher(nil)
+	if err := (Serializer{}).Scan(ctx, email, dst, stored); err == nil || !strings.Contains(err.Error(), "PII_ENCRYPTION_KEY") {
+		t.Errorf("Expected reading ciphertext without a key to fail, got %v", err)
+	}
+}
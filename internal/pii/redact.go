@@ -0,0 +1,87 @@
+package pii
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	// emailPattern also matches the URL-encoded form jane%40example.com
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+(?:@|%40)[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// phonePattern matches 7 to 15 digits, optionally led by + and separated
+	// by up to two spaces, dots, dashes or parentheses
+	phonePattern = regexp.MustCompile(`\+?\(?\d(?:[\s().\-]{0,2}\d){6,14}`)
+)
+
+// redactLogs masks personal data in log output; see SetLogRedaction
+var redactLogs atomic.Bool
+
+// SetLogRedaction turns masking of emails and phone numbers in log output on
+// or off. Call it at startup from REDACT_PII_LOGS.
+func SetLogRedaction(on bool) {
+	redactLogs.Store(on)
+}
+
+// LogRedaction reports whether log output is masked
+func LogRedaction() bool {
+	return redactLogs.Load()
+}
+
+// MaskEmail keeps the first character of the local part and the domain, so
+// logs stay useful for spotting a bad feed: jane@example.com becomes
+// j***@example.com
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if encoded := strings.LastIndex(email, "%40"); encoded > at {
+		at = encoded
+	}
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// MaskPhone keeps only the last two digits: 504-621-8927 becomes ***27
+func MaskPhone(phone string) string {
+	var digits []byte
+	for i := 0; i < len(phone); i++ {
+		if phone[i] >= '0' && phone[i] <= '9' {
+			digits = append(digits, phone[i])
+		}
+	}
+	if len(digits) < 2 {
+		return "***"
+	}
+	return "***" + string(digits[len(digits)-2:])
+}
+
+// Redact masks every email and phone number in text for logging. It returns
+// text unchanged while log redaction is off.
+func Redact(text string) string {
+	if !LogRedaction() {
+		return text
+	}
+	text = emailPattern.ReplaceAllStringFunc(text, MaskEmail)
+	return phonePattern.ReplaceAllStringFunc(text, MaskPhone)
+}
+
+// RedactAll applies Redact to each value
+func RedactAll(values []string) []string {
+	if !LogRedaction() {
+		return values
+	}
+	redacted := make([]string, len(values))
+	for i, value := range values {
+		redacted[i] = Redact(value)
+	}
+	return redacted
+}
+
+// RedactError returns the redacted message of err for logging, or "<nil>"
+func RedactError(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return Redact(err.Error())
+}
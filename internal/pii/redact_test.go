@@ -0,0 +1,28 @@
+package pii
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	SetLogRedaction(true)
+	t.Cleanup(func() { SetLogRedaction(false) })
+
+	tests := []struct {
+		in, want string
+	}{
+		{"jane.doe@example.com", "j***@example.com"},
+		{"search=jane%40example.com&limit=10", "search=j***%40example.com&limit=10"},
+		{"Duplicate entry 'bob@corp.io' for key 'idx_email'", "Duplicate entry 'b***@corp.io' for key 'idx_email'"},
+		{"call 504-621-8927 or +1 (555) 010-2030", "call ***27 or ***30"},
+		{"page 3 of 12, limit 100", "page 3 of 12, limit 100"},
+	}
+	for _, tt := range tests {
+		if got := Redact(tt.in); got != tt.want {
+			t.Errorf("Redact(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	SetLogRedaction(false)
+	if got := Redact("jane@example.com"); got != "jane@example.com" {
+		t.Errorf("Expected values to pass through with redaction off, got %q", got)
+	}
+}
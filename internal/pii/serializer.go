@@ -0,0 +1,59 @@
+package pii
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("pii", Serializer{})
+}
+
+// Serializer is the "pii" GORM serializer for string columns that may hold
+// personal data. It encrypts on write when the column is designated in
+// PII_ENCRYPTED_FIELDS and decrypts encrypted values on read; other values
+// pass through unchanged.
+type Serializer struct{}
+
+// Scan decrypts the stored value into the field
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("unsupported value %T for column %s", dbValue, field.DBName)
+	}
+
+	if strings.HasPrefix(value, Prefix) {
+		c := Active()
+		if c == nil {
+			return fmt.Errorf("column %s: %w", field.DBName, ErrNoKey)
+		}
+		plaintext, err := c.Decrypt(value)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", field.DBName, err)
+		}
+		value = plaintext
+	}
+	return field.Set(ctx, dst, value)
+}
+
+// Value encrypts the field when its column is designated
+func (Serializer) Value(_ context.Context, field *schema.Field, _ reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("column %s: the pii serializer only supports strings", field.DBName)
+	}
+	if c := Active(); c != nil && c.Encrypts(field.DBName) {
+		return c.Encrypt(value)
+	}
+	return value, nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// annotationHeader names the column appended by AnnotateExcelFile
+const annotationHeader = "validation_result"
+
+// annotationOK marks rows that passed validation
+const annotationOK = "OK"
+
+// AnnotateExcelFile validates every row of the upload and writes the original
+// workbook to w with one extra column on the first sheet describing each row's
+// validation result, so users can fix problems in place and re-upload.
+// Nothing is written to the database.
+func (s *ExcelService) AnnotateExcelFile(file *multipart.FileHeader, w io.Writer) error {
+	if err := s.validateExcelFile(file); err != nil {
+		return fmt.Errorf("file validation failed: %w", err)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("failed to read file content: %w", err)
+	}
+
+	xlFile, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer xlFile.Close()
+
+	sheetName := xlFile.GetSheetName(0)
+	if sheetName == "" {
+		return fmt.Errorf("Excel file has no sheets")
+	}
+
+	rows, err := xlFile.GetRows(sheetName)
+	if err != nil {
+		return fmt.Errorf("failed to read Excel sheet: %w", err)
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("Excel file appears to be empty")
+	}
+
+	headerMap, err := s.validateAndMapHeaders(rows[0], employeeColumns)
+	if err != nil {
+		return fmt.Errorf("header validation failed: %w", err)
+	}
+
+	// Append after the widest row so no existing cell is overwritten
+	column := 0
+	for _, row := range rows {
+		if len(row) > column {
+			column = len(row)
+		}
+	}
+	column++
+
+	annotations := map[int]string{1: annotationHeader}
+	for rowIndex := 1; rowIndex < len(rows); rowIndex++ {
+		row := rows[rowIndex]
+		if s.isRowEmpty(row) {
+			continue
+		}
+		annotations[rowIndex+1] = s.annotateRow(row, headerMap, rowIndex+1)
+	}
+
+	for rowNumber, text := range annotations {
+		cell, err := excelize.CoordinatesToCellName(column, rowNumber)
+		if err != nil {
+			return fmt.Errorf("failed to address annotation cell: %w", err)
+		}
+		if err := xlFile.SetCellStr(sheetName, cell, text); err != nil {
+			return fmt.Errorf("failed to write annotation: %w", err)
+		}
+	}
+
+	if err := xlFile.Write(w); err != nil {
+		return fmt.Errorf("failed to write annotated file: %w", err)
+	}
+	return nil
+}
+
+// annotateRow returns the validation result text for one data row
+func (s *ExcelService) annotateRow(row []string, headerMap map[string]int, rowNumber int) string {
+	if s.requiredCellsBlank(row, headerMap) {
+		return fmt.Sprintf("Row has no values for required fields (%s)", strings.Join(s.requiredColumns, ", "))
+	}
+
+	_, rowErrors, _ := s.parseEmployeeFromRow(row, headerMap, rowNumber)
+	if len(rowErrors) == 0 {
+		return annotationOK
+	}
+	return rowErrorText(rowErrors, rowNumber)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"bytes"
+	"employee-management/internal/config"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestAnnotateExcelFile(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+	rows := [][]string{
+		importHeaders,
+		{"John", "Doe", "Acme", "", "", "", "", "", "john@example.com", ""},
+		{"J", "Smith", "", "", "", "", "", "", "not-an-email", ""},
+		{},
+		{" ", "", "Acme", "", "", "", "", "", "", ""},
+	}
+	file := newFileHeader(t, "staff.xlsx", buildWorkbook(t, rows))
+
+	var out bytes.Buffer
+	if err := service.AnnotateExcelFile(file, &out); err != nil {
+		t.Fatalf("AnnotateExcelFile failed: %v", err)
+	}
+	if repo.Count() != 0 {
+		t.Errorf("Expected nothing to be imported, got %d employees", repo.Count())
+	}
+
+	annotated, err := excelize.OpenReader(&out)
+	if err != nil {
+		t.Fatalf("Failed to open annotated file: %v", err)
+	}
+	defer annotated.Close()
+
+	got, err := annotated.GetRows(annotated.GetSheetName(0))
+	if err != nil {
+		t.Fatalf("Failed to read annotated rows: %v", err)
+	}
+
+	resultColumn := len(importHeaders)
+	if got[0][resultColumn] != annotationHeader {
+		t.Errorf("Expected %q header, got %v", annotationHeader, got[0])
+	}
+	if got[1][resultColumn] != annotationOK {
+		t.Errorf("Expected valid row to be marked OK, got %q", got[1][resultColumn])
+	}
+	if result := got[2][resultColumn]; !strings.Contains(result, "FirstName") || !strings.Contains(result, "Invalid email format") {
+		t.Errorf("Expected both errors for the bad row, got %q", result)
+	}
+	if len(got[3]) > resultColumn && got[3][resultColumn] != "" {
+		t.Errorf("Expected empty row to stay unannotated, got %q", got[3][resultColumn])
+	}
+	if result := got[4][resultColumn]; !strings.Contains(result, "no values for required fields") {
+		t.Errorf("Expected blank-row message, got %q", result)
+	}
+
+	// Original cells are untouched
+	for i, value := range rows[1] {
+		if got[1][i] != value {
+			t.Errorf("Expected original cell %d to be %q, got %q", i, value, got[1][i])
+		}
+	}
+}
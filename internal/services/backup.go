@@ -0,0 +1,129 @@
+package services
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrBackupRunning is returned when a backup is requested while one is in progress
+var ErrBackupRunning = errors.New("a backup is already running")
+
+// backupProgressRows is how often a running backup reports progress on its job
+const backupProgressRows = 1000
+
+// BackupResult describes a finished backup dump
+type BackupResult struct {
+	Path string `json:"path"`
+	Rows int    `json:"rows"`
+}
+
+// backupDir returns the configured backup directory
+func (s *ExcelService) backupDir() string {
+	if s.config.Export.BackupDir != "" {
+		return s.config.Export.BackupDir
+	}
+	return filepath.Join(os.TempDir(), "employee-backups")
+}
+
+// StartBackup dumps every employee to BACKUP_DIR as gzip'd NDJSON in the
+// background and returns the job ID to poll for progress
+func (s *ExcelService) StartBackup(requestID string) (string, error) {
+	if !s.backupRunning.CompareAndSwap(false, true) {
+		return "", ErrBackupRunning
+	}
+	s.expireJobs()
+
+	jobID := uuid.New().String()
+	s.mu.Lock()
+	s.jobs[jobID] = &JobResult{
+		ID:        jobID,
+		Status:    JobStatusPending,
+		RequestID: requestID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	s.mu.Unlock()
+
+	go func() {
+		defer s.backupRunning.Store(false)
+		s.updateJobStatus(jobID, JobStatusRunning, nil, "")
+
+		result, err := s.writeBackup(time.Now(), func(rows int) { s.setJobProgress(jobID, rows) })
+		if err != nil {
+			log.Printf("Backup job %s failed request_id=%s: %v", jobID, requestID, err)
+			s.updateJobStatus(jobID, JobStatusFailed, nil, err.Error())
+			return
+		}
+
+		log.Printf("Backup job %s wrote %d employees to %s request_id=%s", jobID, result.Rows, result.Path, requestID)
+		s.mu.Lock()
+		if job, exists := s.jobs[jobID]; exists {
+			job.Status = JobStatusCompleted
+			job.Backup = result
+			job.Progress = result.Rows
+			job.UpdatedAt = time.Now()
+		}
+		s.mu.Unlock()
+	}()
+
+	return jobID, nil
+}
+
+// writeBackup streams all employees into a timestamped .ndjson.gz file. The
+// dump is written under a temporary name and renamed once complete, so a
+// file with the final name is never partial.
+func (s *ExcelService) writeBackup(now time.Time, progress func(rows int)) (*BackupResult, error) {
+	dir := s.backupDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	path := filepath.Join(dir, "employees-"+now.UTC().Format("20060102T150405Z")+".ndjson.gz")
+	partial := path + ".part"
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(partial) // No-op once renamed
+
+	rows := 0
+	gz := gzip.NewWriter(file)
+	err = s.ExportEmployeesNDJSON(gz, ExportFilter{}, 1, func(written int) {
+		rows = written
+		if written%backupProgressRows == 0 {
+			progress(written)
+		}
+	})
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	if err := os.Rename(partial, path); err != nil {
+		return nil, fmt.Errorf("failed to finalize backup: %w", err)
+	}
+	return &BackupResult{Path: path, Rows: rows}, nil
+}
+
+// setJobProgress records how many rows a running job has processed
+func (s *ExcelService) setJobProgress(jobID string, rows int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, exists := s.jobs[jobID]; exists {
+		job.Progress = rows
+		job.UpdatedAt = time.Now()
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"bufio"
+	"compress/gzip"
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStartBackup_WritesGzipNDJSON(t *testing.T) {
+	dir := t.TempDir()
+	service, repo := newTestExcelService(&config.Config{Export: config.ExportConfig{BackupDir: dir}})
+	for _, email := range []string{"john@example.com", "jane@example.com", "bob@example.com"} {
+		repo.Seed(models.Employee{FirstName: "F", LastName: "L", Email: email})
+	}
+
+	jobID, err := service.StartBackup("req-1")
+	if err != nil {
+		t.Fatalf("StartBackup failed: %v", err)
+	}
+
+	var job JobResult
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		current, err := service.GetJobStatus(jobID)
+		if err != nil {
+			t.Fatalf("GetJobStatus failed: %v", err)
+		}
+		service.mu.RLock()
+		job = *current
+		service.mu.RUnlock()
+		if job.Status == JobStatusCompleted || job.Status == JobStatusFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("backup did not finish, last status %s", job.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if job.Status != JobStatusCompleted || job.Backup == nil || job.Backup.Rows != 3 {
+		t.Fatalf("Expected a completed backup of 3 rows, got %+v", job)
+	}
+	if filepath.Dir(job.Backup.Path) != dir {
+		t.Errorf("Expected the backup in %s, got %s", dir, job.Backup.Path)
+	}
+
+	file, err := os.Open(job.Backup.Path)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("backup is not gzip: %v", err)
+	}
+
+	var emails []string
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var employee models.Employee
+		if err := json.Unmarshal(scanner.Bytes(), &employee); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		emails = append(emails, employee.Email)
+	}
+	if len(emails) != 3 || emails[0] != "john@example.com" {
+		t.Errorf("Expected 3 employees in ID order, got %v", emails)
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(dir, "*.part"))
+	if len(leftovers) != 0 {
+		t.Errorf("Expected no partial files, got %v", leftovers)
+	}
+}
+
+func TestStartBackup_OneAtATime(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{Export: config.ExportConfig{BackupDir: t.TempDir()}})
+	service.backupRunning.Store(true)
+
+	if _, err := service.StartBackup(""); !errors.Is(err, ErrBackupRunning) {
+		t.Errorf("Expected ErrBackupRunning while a backup runs, got %v", err)
+	}
+}
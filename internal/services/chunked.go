@@ -0,0 +1,276 @@
+package services
+
+import (
+	"employee-management/internal/events"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned by the chunked upload operations
+var (
+	ErrUploadNotFound   = errors.New("upload not found or expired")
+	ErrChunkOutOfOrder  = errors.New("chunk out of order")
+	ErrUploadTooLarge   = errors.New("upload exceeds declared size")
+	ErrUploadIncomplete = errors.New("upload incomplete")
+)
+
+// ChunkedUploadInfo reports the progress of a chunked upload
+type ChunkedUploadInfo struct {
+	UploadID      string    `json:"upload_id"`
+	Filename      string    `json:"filename"`
+	TotalSize     int64     `json:"total_size"`
+	ReceivedBytes int64     `json:"received_bytes"`
+	NextChunk     int       `json:"next_chunk"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// chunkedUploads tracks in-progress chunked uploads. Each upload is appended
+// to its own file in dir; uploads idle for longer than ttl are removed the
+// next time any chunked upload operation runs.
+type chunkedUploads struct {
+	mu      sync.Mutex
+	dir     string
+	ttl     time.Duration
+	uploads map[string]*chunkedUpload
+	now     func() time.Time
+}
+
+// chunkedUpload is the state of one upload
+type chunkedUpload struct {
+	info           ChunkedUploadInfo
+	path           string
+	lastChunkStart int64 // offset where the most recent chunk began, so it can be resent
+}
+
+// newChunkedUploads creates an upload store writing into dir
+func newChunkedUploads(dir string, ttl time.Duration) *chunkedUploads {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "employee-uploads")
+	}
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return &chunkedUploads{
+		dir:     dir,
+		ttl:     ttl,
+		uploads: make(map[string]*chunkedUpload),
+		now:     time.Now,
+	}
+}
+
+// InitChunkedUpload starts a chunked upload of a file with the given name and
+// total size, which are validated like a regular upload
+func (s *ExcelService) InitChunkedUpload(filename string, totalSize int64) (*ChunkedUploadInfo, error) {
+	if totalSize <= 0 {
+		return nil, fmt.Errorf("total_size must be positive")
+	}
+	if err := s.validateImportUpload(filename, totalSize); err != nil {
+		return nil, fmt.Errorf("file validation failed: %w", err)
+	}
+	if err := s.CheckMemory(); err != nil {
+		return nil, err
+	}
+	return s.uploads.init(filepath.Base(filename), totalSize)
+}
+
+// PutUploadChunk appends chunk number index (starting at 0) to an upload.
+// Chunks must arrive in order; resending the most recent chunk replaces it,
+// so a client that lost the response can safely retry.
+func (s *ExcelService) PutUploadChunk(uploadID string, index int, chunk io.Reader) (*ChunkedUploadInfo, error) {
+	return s.uploads.put(uploadID, index, chunk)
+}
+
+// CompleteChunkedUpload checks that every byte has arrived and queues the
+// reassembled file for import like a regular upload, returning the job ID.
+// When the job is refused (upload limit, memory pressure, full queue) the
+// upload is kept, so the client can retry completing it without resending.
+func (s *ExcelService) CompleteChunkedUpload(uploadID string, mode ImportMode, actor events.Actor) (string, error) {
+	upload, err := s.uploads.take(uploadID)
+	if err != nil {
+		return "", err
+	}
+	jobID, err := s.enqueueExcelJob(upload.source(), mode, actor)
+	if err != nil {
+		s.uploads.restore(upload)
+		return "", err
+	}
+	return jobID, nil
+}
+
+func (u *chunkedUploads) init(filename string, totalSize int64) (*ChunkedUploadInfo, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.expireStale()
+
+	if err := os.MkdirAll(u.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	id := uuid.New().String()
+	upload := &chunkedUpload{
+		info: ChunkedUploadInfo{
+			UploadID:  id,
+			Filename:  filename,
+			TotalSize: totalSize,
+			ExpiresAt: u.now().Add(u.ttl),
+		},
+		path: filepath.Join(u.dir, id+".part"),
+	}
+
+	file, err := os.OpenFile(upload.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	file.Close()
+
+	u.uploads[id] = upload
+	info := upload.info
+	return &info, nil
+}
+
+func (u *chunkedUploads) put(uploadID string, index int, chunk io.Reader) (*ChunkedUploadInfo, error) {
+	if index < 0 {
+		return nil, fmt.Errorf("%w: chunk numbers start at 0", ErrChunkOutOfOrder)
+	}
+
+	u.mu.Lock()
+	upload, ok := u.uploads[uploadID]
+	var totalSize int64
+	if ok {
+		totalSize = upload.info.TotalSize
+	}
+	u.mu.Unlock()
+	if !ok {
+		return nil, ErrUploadNotFound
+	}
+
+	// Read the body before taking the lock so a slow client only holds up itself.
+	// Reading one byte past the declared size is enough to detect an oversized chunk.
+	data, err := io.ReadAll(io.LimitReader(chunk, totalSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chunk: %w", err)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.expireStale()
+
+	// The upload may have expired or completed while the body was read
+	upload, ok = u.uploads[uploadID]
+	if !ok {
+		return nil, ErrUploadNotFound
+	}
+
+	offset := upload.info.ReceivedBytes
+	switch index {
+	case upload.info.NextChunk:
+	case upload.info.NextChunk - 1:
+		offset = upload.lastChunkStart
+	default:
+		return nil, fmt.Errorf("%w: expected chunk %d, got %d", ErrChunkOutOfOrder, upload.info.NextChunk, index)
+	}
+	if offset+int64(len(data)) > upload.info.TotalSize {
+		return nil, fmt.Errorf("%w: %d bytes would exceed total_size %d",
+			ErrUploadTooLarge, offset+int64(len(data)), upload.info.TotalSize)
+	}
+
+	if err := writeChunkAt(upload.path, offset, data); err != nil {
+		return nil, err
+	}
+
+	upload.lastChunkStart = offset
+	upload.info.ReceivedBytes = offset + int64(len(data))
+	upload.info.NextChunk = index + 1
+	upload.info.ExpiresAt = u.now().Add(u.ttl)
+
+	info := upload.info
+	return &info, nil
+}
+
+// writeChunkAt writes data at offset and drops anything after it, which
+// discards the previous attempt when a chunk is resent
+func writeChunkAt(path string, offset int64, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer file.Close()
+
+	if err := file.Truncate(offset); err != nil {
+		return fmt.Errorf("failed to write chunk: %w", err)
+	}
+	if _, err := file.WriteAt(data, offset); err != nil {
+		return fmt.Errorf("failed to write chunk: %w", err)
+	}
+	return nil
+}
+
+// take removes a fully received upload from the store, so it is imported at
+// most once even when completed twice concurrently
+func (u *chunkedUploads) take(uploadID string) (*chunkedUpload, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.expireStale()
+
+	upload, ok := u.uploads[uploadID]
+	if !ok {
+		return nil, ErrUploadNotFound
+	}
+	if upload.info.ReceivedBytes != upload.info.TotalSize {
+		return nil, fmt.Errorf("%w: received %d of %d bytes",
+			ErrUploadIncomplete, upload.info.ReceivedBytes, upload.info.TotalSize)
+	}
+	delete(u.uploads, uploadID)
+	return upload, nil
+}
+
+// restore puts back an upload whose import could not be queued, with a fresh
+// expiry
+func (u *chunkedUploads) restore(upload *chunkedUpload) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	upload.info.ExpiresAt = u.now().Add(u.ttl)
+	u.uploads[upload.info.UploadID] = upload
+}
+
+// source returns the upload as an import source that deletes the file once
+// processed
+func (upload *chunkedUpload) source() excelSource {
+	path := upload.path
+	return excelSource{
+		Filename: upload.info.Filename,
+		Size:     upload.info.TotalSize,
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+		Cleanup: func() {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Printf("Warning: Failed to remove upload file %s: %v", path, err)
+			}
+		},
+	}
+}
+
+// expireStale drops uploads idle past their expiry; callers hold u.mu
+func (u *chunkedUploads) expireStale() {
+	now := u.now()
+	for id, upload := range u.uploads {
+		if now.Before(upload.info.ExpiresAt) {
+			continue
+		}
+		delete(u.uploads, id)
+		if err := os.Remove(upload.path); err != nil && !os.IsNotExist(err) {
+			log.Printf("Warning: Failed to remove expired upload file %s: %v", upload.path, err)
+		}
+		log.Printf("Expired incomplete upload %s (%d of %d bytes)", id, upload.info.ReceivedBytes, upload.info.TotalSize)
+	}
+}
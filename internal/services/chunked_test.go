@@ -0,0 +1,228 @@
+package services
+
+import (
+	"bytes"
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newChunkedTestService returns an ExcelService whose uploads live in a temp dir
+func newChunkedTestService(t *testing.T) *ExcelService {
+	t.Helper()
+	service, _ := newTestExcelService(&config.Config{})
+	service.uploads = newChunkedUploads(t.TempDir(), time.Hour)
+	return service
+}
+
+// readSource returns the full content of an import source
+func readSource(t *testing.T, source excelSource) []byte {
+	t.Helper()
+	src, err := source.Open()
+	if err != nil {
+		t.Fatalf("failed to open source: %v", err)
+	}
+	defer src.Close()
+	content, err := io.ReadAll(src)
+	if err != nil {
+		t.Fatalf("failed to read source: %v", err)
+	}
+	return content
+}
+
+func TestChunkedUpload_Reassembly(t *testing.T) {
+	service := newChunkedTestService(t)
+	content := buildWorkbook(t, [][]string{importHeaders, {"John", "Doe", "", "", "", "", "", "", "john@example.com", ""}})
+	third := len(content) / 3
+	chunks := [][]byte{content[:third], content[third : 2*third], content[2*third:]}
+
+	info, err := service.InitChunkedUpload("employees.xlsx", int64(len(content)))
+	if err != nil {
+		t.Fatalf("InitChunkedUpload failed: %v", err)
+	}
+	id := info.UploadID
+
+	if _, err := service.PutUploadChunk(id, 0, bytes.NewReader(chunks[0])); err != nil {
+		t.Fatalf("chunk 0 failed: %v", err)
+	}
+	// Skipping ahead is rejected
+	if _, err := service.PutUploadChunk(id, 2, bytes.NewReader(chunks[2])); !errors.Is(err, ErrChunkOutOfOrder) {
+		t.Errorf("Expected out-of-order error for chunk 2, got %v", err)
+	}
+	// A partial first attempt at chunk 1 is replaced by the retry
+	if _, err := service.PutUploadChunk(id, 1, bytes.NewReader(chunks[1][:10])); err != nil {
+		t.Fatalf("chunk 1 failed: %v", err)
+	}
+	if _, err := service.PutUploadChunk(id, 1, bytes.NewReader(chunks[1])); err != nil {
+		t.Fatalf("chunk 1 retry failed: %v", err)
+	}
+	// Chunks older than the last one cannot be resent
+	if _, err := service.PutUploadChunk(id, 0, bytes.NewReader(chunks[0])); !errors.Is(err, ErrChunkOutOfOrder) {
+		t.Errorf("Expected out-of-order error for chunk 0, got %v", err)
+	}
+
+	if _, err := service.uploads.take(id); !errors.Is(err, ErrUploadIncomplete) {
+		t.Errorf("Expected incomplete error before the last chunk, got %v", err)
+	}
+
+	progress, err := service.PutUploadChunk(id, 2, bytes.NewReader(chunks[2]))
+	if err != nil {
+		t.Fatalf("chunk 2 failed: %v", err)
+	}
+	if progress.ReceivedBytes != int64(len(content)) || progress.NextChunk != 3 {
+		t.Errorf("Expected all %d bytes over 3 chunks, got %+v", len(content), progress)
+	}
+
+	upload, err := service.uploads.take(id)
+	if err != nil {
+		t.Fatalf("take failed: %v", err)
+	}
+	source := upload.source()
+	if !bytes.Equal(readSource(t, source), content) {
+		t.Fatal("Reassembled file does not match the original")
+	}
+
+	// The reassembled file goes through the regular import pipeline
+	response, _, err := service.processExcelSource(source, ImportModeLive)
+	if err != nil || response.InsertedRecords != 1 {
+		t.Errorf("Expected reassembled file to import 1 record, got %+v (err %v)", response, err)
+	}
+
+	source.Cleanup()
+	if _, err := os.Stat(filepath.Join(service.uploads.dir, id+".part")); !os.IsNotExist(err) {
+		t.Errorf("Expected upload file to be removed after processing, got %v", err)
+	}
+	if _, err := service.PutUploadChunk(id, 3, bytes.NewReader(nil)); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected completed upload to be gone, got %v", err)
+	}
+}
+
+func TestChunkedUpload_SizeLimits(t *testing.T) {
+	service := newChunkedTestService(t)
+	service.config.Server.MaxFileSize = 100
+
+	if _, err := service.InitChunkedUpload("employees.xlsx", 101); err == nil {
+		t.Error("Expected declared size above MAX_FILE_SIZE to be rejected")
+	}
+	if _, err := service.InitChunkedUpload("employees.txt", 10); err == nil {
+		t.Error("Expected unsupported extension to be rejected")
+	}
+
+	info, err := service.InitChunkedUpload("employees.xlsx", 10)
+	if err != nil {
+		t.Fatalf("InitChunkedUpload failed: %v", err)
+	}
+	if _, err := service.PutUploadChunk(info.UploadID, 0, bytes.NewReader(make([]byte, 6))); err != nil {
+		t.Fatalf("chunk 0 failed: %v", err)
+	}
+	if _, err := service.PutUploadChunk(info.UploadID, 1, bytes.NewReader(make([]byte, 5))); !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected chunk past total_size to be rejected, got %v", err)
+	}
+}
+
+func TestChunkedUpload_Expiry(t *testing.T) {
+	service := newChunkedTestService(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	service.uploads.now = func() time.Time { return now }
+
+	info, err := service.InitChunkedUpload("employees.xlsx", 10)
+	if err != nil {
+		t.Fatalf("InitChunkedUpload failed: %v", err)
+	}
+
+	// Activity extends the deadline
+	now = now.Add(50 * time.Minute)
+	if _, err := service.PutUploadChunk(info.UploadID, 0, bytes.NewReader(make([]byte, 5))); err != nil {
+		t.Fatalf("chunk 0 failed: %v", err)
+	}
+	now = now.Add(50 * time.Minute)
+	if _, err := service.PutUploadChunk(info.UploadID, 1, bytes.NewReader(make([]byte, 5))); err != nil {
+		t.Fatalf("Expected upload to still be live after activity, got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := service.uploads.take(info.UploadID); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected idle upload to expire, got %v", err)
+	}
+	entries, _ := os.ReadDir(service.uploads.dir)
+	if len(entries) != 0 {
+		t.Errorf("Expected expired upload file to be removed, found %d files", len(entries))
+	}
+}
+
+// uploadFile sends content as a single chunk and returns the upload ID
+func uploadFile(t *testing.T, service *ExcelService, content []byte) string {
+	t.Helper()
+	info, err := service.InitChunkedUpload("employees.xlsx", int64(len(content)))
+	if err != nil {
+		t.Fatalf("InitChunkedUpload failed: %v", err)
+	}
+	if _, err := service.PutUploadChunk(info.UploadID, 0, bytes.NewReader(content)); err != nil {
+		t.Fatalf("chunk 0 failed: %v", err)
+	}
+	return info.UploadID
+}
+
+func TestCompleteChunkedUpload_RefusedKeepsUpload(t *testing.T) {
+	service := newChunkedTestService(t)
+	service.uploadGate = newUploadGate(1)
+	service.jobQueue = make(chan *JobRequest, 10) // No workers: jobs stay queued
+	content := buildWorkbook(t, [][]string{importHeaders, {"John", "Doe", "", "", "", "", "", "", "john@example.com", ""}})
+	id := uploadFile(t, service, content)
+
+	// The client's only slot is taken, so completing is refused
+	if !service.uploadGate.acquire("10.0.0.1") {
+		t.Fatal("Expected to take the upload slot")
+	}
+	if _, err := service.CompleteChunkedUpload(id, ImportModeLive, events.Actor{IP: "10.0.0.1"}); !errors.Is(err, ErrTooManyUploads) {
+		t.Fatalf("Expected the upload limit to refuse the job, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(service.uploads.dir, id+".part")); err != nil {
+		t.Fatalf("Expected the refused upload's file to be kept, got %v", err)
+	}
+
+	// Once a slot frees up, the same upload completes without resending
+	service.uploadGate.release("10.0.0.1")
+	if _, err := service.CompleteChunkedUpload(id, ImportModeLive, events.Actor{IP: "10.0.0.1"}); err != nil {
+		t.Fatalf("Expected the retried completion to be queued, got %v", err)
+	}
+	job := <-service.jobQueue
+	if !bytes.Equal(readSource(t, job.Source), content) {
+		t.Error("Expected the queued job to read the uploaded file")
+	}
+	if _, err := service.uploads.take(id); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected a queued upload to leave the store, got %v", err)
+	}
+}
+
+func TestDispatch_TimeoutRemovesUploadFile(t *testing.T) {
+	service := newChunkedTestService(t)
+	service.jobQueue = make(chan *JobRequest, 10)
+	service.workerPool = make(chan chan *JobRequest) // No worker ever frees up
+	service.quit = make(chan bool)
+	service.workerWait = 10 * time.Millisecond
+	go service.dispatch()
+	defer close(service.quit)
+
+	id := uploadFile(t, service, buildWorkbook(t, [][]string{importHeaders}))
+	if _, err := service.CompleteChunkedUpload(id, ImportModeLive, events.Actor{IP: "10.0.0.1"}); err != nil {
+		t.Fatalf("CompleteChunkedUpload failed: %v", err)
+	}
+
+	path := filepath.Join(service.uploads.dir, id+".part")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the abandoned job's upload file to be removed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"fmt"
+	"strings"
+)
+
+// maxCountiesInMessage caps how many allowed counties an error message lists
+const maxCountiesInMessage = 10
+
+// allowedValues is a controlled vocabulary matched case-insensitively
+type allowedValues struct {
+	names []string        // In configured order, for messages
+	keys  map[string]bool // Lower-cased names
+}
+
+// parseAllowedValues reads a comma-separated list; an empty list allows anything
+func parseAllowedValues(spec string) allowedValues {
+	values := allowedValues{keys: make(map[string]bool)}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || values.keys[key] {
+			continue
+		}
+		values.names = append(values.names, name)
+		values.keys[key] = true
+	}
+	return values
+}
+
+// allows reports whether value is in the list. Empty values and empty lists
+// always pass; required-ness is the struct validator's job.
+func (v allowedValues) allows(value string) bool {
+	value = strings.TrimSpace(value)
+	return len(v.names) == 0 || value == "" || v.keys[strings.ToLower(value)]
+}
+
+// describe lists the allowed values for an error message, truncated when long
+func (v allowedValues) describe() string {
+	if len(v.names) <= maxCountiesInMessage {
+		return strings.Join(v.names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(v.names[:maxCountiesInMessage], ", "),
+		len(v.names)-maxCountiesInMessage)
+}
+
+// CheckCounty validates county against ALLOWED_COUNTIES and returns the
+// problem in the given locale, or nil when the value is accepted
+func (s *EmployeeService) CheckCounty(county, locale string) *models.ValidationError {
+	if s.allowedCounties.allows(county) {
+		return nil
+	}
+	return &models.ValidationError{
+		Field:   "County",
+		Message: localizedMessage(locale, "county", "County", s.allowedCounties.describe()),
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateEmployeeData_AllowedCounties(t *testing.T) {
+	cfg := &config.Config{Validation: config.ValidationConfig{AllowedCounties: "Kent, Essex,Surrey"}}
+	service := NewEmployeeService(nil, nil, cfg)
+
+	tests := []struct {
+		county string
+		valid  bool
+	}{
+		{"Kent", true},
+		{"essex", true},
+		{" SURREY ", true},
+		{"", true},
+		{"Devon", false},
+	}
+	for _, tt := range tests {
+		employee := &models.Employee{FirstName: "John", LastName: "Doe", Email: "john@example.com", County: tt.county}
+		errs, _ := service.ValidateEmployeeData(employee)
+		if tt.valid && len(errs) != 0 {
+			t.Errorf("county %q: expected valid, got %v", tt.county, errs)
+		}
+		if !tt.valid && (len(errs) != 1 || errs[0].Message != "County must be one of: Kent, Essex, Surrey") {
+			t.Errorf("county %q: expected the allowed list in the error, got %v", tt.county, errs)
+		}
+	}
+
+	// Without a list any county is accepted
+	unrestricted := NewEmployeeService(nil, nil, &config.Config{})
+	if problem := unrestricted.CheckCounty("Anywhere", DefaultLocale); problem != nil {
+		t.Errorf("Expected no check without ALLOWED_COUNTIES, got %v", problem)
+	}
+}
+
+func TestCheckCounty_TruncatesLongLists(t *testing.T) {
+	names := make([]string, 15)
+	for i := range names {
+		names[i] = fmt.Sprintf("County%d", i+1)
+	}
+	service := NewEmployeeService(nil, nil, &config.Config{Validation: config.ValidationConfig{AllowedCounties: strings.Join(names, ",")}})
+
+	problem := service.CheckCounty("Elsewhere", DefaultLocale)
+	if problem == nil || !strings.HasSuffix(problem.Message, "County10 and 5 more") || strings.Contains(problem.Message, "County11") {
+		t.Errorf("Expected the first 10 counties and a count of the rest, got %v", problem)
+	}
+}
+
+func TestProcessExcelFile_AllowedCounties(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Validation: config.ValidationConfig{AllowedCounties: "Kent"}})
+
+	rows := [][]string{
+		{"first_name", "last_name", "email", "county"},
+		{"John", "Doe", "john@example.com", "kent"},
+		{"Jane", "Roe", "jane@example.com", "Devon"},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if response.InsertedRecords != 1 || response.InvalidRecords != 1 || repo.Count() != 1 {
+		t.Errorf("Expected the out-of-list row to be rejected, got %+v", response)
+	}
+}
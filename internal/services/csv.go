@@ -0,0 +1,33 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// isCSVFilename reports whether an upload is a CSV file
+func isCSVFilename(filename string) bool {
+	return strings.HasSuffix(strings.ToLower(filename), ".csv")
+}
+
+// readCSVRows parses CSV content into rows, the same shape the Excel reader
+// returns, so headers and rows go through the same mapping and validation.
+// The content is decoded per IMPORT_CHARSET, dropping any byte order mark.
+// Quoted fields may hold commas, quotes and line breaks, and rows may have
+// fewer or more fields than the header.
+func (s *ExcelService) readCSVRows(content []byte) ([][]string, error) {
+	decoded, err := decodeImportText(content, s.config.Import.Charset)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(decoded))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV file: %w", err)
+	}
+	return rows, nil
+}
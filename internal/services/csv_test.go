@@ -0,0 +1,84 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"testing"
+)
+
+func TestProcessExcelFile_CSV(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+	content := []byte("\xEF\xBB\xBFfirst_name,last_name,company_name,address,city,county,postal,phone,email,web\r\n" +
+		"John,Doe,\"Acme, Inc.\",\"1 Main St, Suite 2\",Springfield,,,,john@example.com,\r\n" +
+		"Jane,Roe,Globex,,,,,,jane@example.com\r\n")
+
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.csv", content))
+	if err != nil {
+		t.Fatalf("Expected CSV import to succeed, got: %v", err)
+	}
+	if response.TotalRecords != 2 || response.ValidRecords != 2 || response.InvalidRecords != 0 {
+		t.Fatalf("Expected 2 valid records, got %+v", response)
+	}
+
+	employee, err := repo.GetEmployeeByEmail("john@example.com")
+	if err != nil {
+		t.Fatalf("Expected john to be imported, got: %v", err)
+	}
+	if employee.FirstName != "John" {
+		t.Errorf("Expected BOM stripped from first header, got first name %q", employee.FirstName)
+	}
+	if employee.CompanyName != "Acme, Inc." || employee.Address != "1 Main St, Suite 2" {
+		t.Errorf("Expected quoted commas kept, got company %q address %q", employee.CompanyName, employee.Address)
+	}
+}
+
+func TestProcessExcelFile_Latin1CSV(t *testing.T) {
+	content := []byte("first_name,last_name,company_name,city,email\n" +
+		"Zo\xEB,M\xFCller,Caf\xE9 Cr\xE8me,K\xF6ln,zoe@example.com\n")
+
+	for _, charset := range []string{CharsetISO88591, CharsetAuto} {
+		t.Run(charset, func(t *testing.T) {
+			service, repo := newTestExcelService(&config.Config{Import: config.ImportConfig{Charset: charset}})
+			if _, err := service.ProcessExcelFile(newFileHeader(t, "employees.csv", content)); err != nil {
+				t.Fatalf("Expected Latin-1 CSV import to succeed, got: %v", err)
+			}
+
+			employee, err := repo.GetEmployeeByEmail("zoe@example.com")
+			if err != nil {
+				t.Fatalf("Expected zoe to be imported, got: %v", err)
+			}
+			if employee.FirstName != "Zoë" || employee.LastName != "Müller" ||
+				employee.CompanyName != "Café Crème" || employee.City != "Köln" {
+				t.Errorf("Expected the text decoded to UTF-8, got %q %q %q %q",
+					employee.FirstName, employee.LastName, employee.CompanyName, employee.City)
+			}
+		})
+	}
+
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{Charset: CharsetUTF8}})
+	if _, err := service.ProcessExcelFile(newFileHeader(t, "employees.csv", content)); err == nil {
+		t.Error("Expected a Latin-1 file to be rejected when IMPORT_CHARSET is utf-8")
+	}
+}
+
+func TestProcessExcelFile_MalformedCSV(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+	content := []byte("first_name,last_name,email\nJohn,\"Doe,john@example.com\n")
+
+	if _, err := service.ProcessExcelFile(newFileHeader(t, "employees.csv", content)); err == nil {
+		t.Fatal("Expected unterminated quote to be rejected")
+	}
+	if repo.Count() != 0 {
+		t.Errorf("Expected no inserts, got %d", repo.Count())
+	}
+}
+
+func TestValidateExcelUpload_AcceptsCSV(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{})
+
+	if err := service.validateExcelUpload("employees.CSV", 6); err != nil {
+		t.Errorf("Expected .csv to be accepted, got: %v", err)
+	}
+	if err := service.validateExcelUpload("employees.txt", 6); err == nil {
+		t.Error("Expected .txt to be rejected")
+	}
+}
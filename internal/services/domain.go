@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"employee-management/internal/models"
+	"errors"
+	"log"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported values for VERIFY_EMAIL_DOMAIN
+const (
+	DomainCheckOff   = "off"
+	DomainCheckWarn  = "warn"
+	DomainCheckError = "error"
+)
+
+// DomainResolver is the subset of *net.Resolver used for domain checks,
+// extracted so tests can substitute canned answers
+type DomainResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DomainVerifier checks that email domains accept mail and web hosts resolve.
+// Results are cached briefly so repeated creates for one company don't hit DNS.
+type DomainVerifier struct {
+	resolver DomainResolver
+	mode     string
+	timeout  time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]domainLookup
+}
+
+// domainLookup is a cached DNS answer
+type domainLookup struct {
+	resolvable bool
+	expiresAt  time.Time
+}
+
+// NewDomainVerifier creates a verifier; an empty or "off" mode disables all lookups
+func NewDomainVerifier(resolver DomainResolver, mode string, timeout, cacheTTL time.Duration) *DomainVerifier {
+	mode = strings.ToLower(mode)
+	if mode == "" {
+		mode = DomainCheckOff
+	}
+	return &DomainVerifier{
+		resolver: resolver,
+		mode:     mode,
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+		cache:    make(map[string]domainLookup),
+	}
+}
+
+// Enabled reports whether lookups are performed at all
+func (v *DomainVerifier) Enabled() bool {
+	return v != nil && v.mode != DomainCheckOff
+}
+
+// Strict reports whether unresolvable domains should reject the record
+func (v *DomainVerifier) Strict() bool {
+	return v.Enabled() && v.mode == DomainCheckError
+}
+
+// Verify looks up the email and web domains concurrently and returns one
+// problem per field whose domain does not exist. Lookup failures other than
+// "not found" (timeouts, resolver outages) are logged and never reported.
+func (v *DomainVerifier) Verify(ctx context.Context, employee *models.Employee) []models.ValidationError {
+	if !v.Enabled() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		problems []models.ValidationError
+	)
+	check := func(field, host string, lookup func(context.Context, string) (bool, error)) {
+		defer wg.Done()
+		if !v.resolvable(ctx, field, host, lookup) {
+			mu.Lock()
+			problems = append(problems, models.ValidationError{
+				Field:   field,
+				Message: "Domain " + host + " does not resolve",
+			})
+			mu.Unlock()
+		}
+	}
+
+	if host := emailDomain(employee.Email); host != "" {
+		wg.Add(1)
+		go check("email", host, v.lookupMail)
+	}
+	if host := webHost(employee.Web); host != "" {
+		wg.Add(1)
+		go check("web", host, v.lookupHost)
+	}
+	wg.Wait()
+
+	// Keep output order stable regardless of which lookup finished first
+	if len(problems) == 2 && problems[0].Field != "email" {
+		problems[0], problems[1] = problems[1], problems[0]
+	}
+	return problems
+}
+
+// resolvable consults the cache before asking DNS
+func (v *DomainVerifier) resolvable(ctx context.Context, kind, host string, lookup func(context.Context, string) (bool, error)) bool {
+	key := kind + ":" + strings.ToLower(host)
+
+	v.mu.Lock()
+	cached, ok := v.cache[key]
+	v.mu.Unlock()
+	if ok && v.now().Before(cached.expiresAt) {
+		return cached.resolvable
+	}
+
+	resolvable, err := lookup(ctx, host)
+	if err != nil {
+		log.Printf("Warning: DNS lookup for %s failed: %v", host, err)
+		return true
+	}
+
+	v.mu.Lock()
+	v.cache[key] = domainLookup{resolvable: resolvable, expiresAt: v.now().Add(v.cacheTTL)}
+	v.mu.Unlock()
+	return resolvable
+}
+
+// lookupMail checks for MX records, falling back to an address lookup since
+// mail servers deliver to the domain's A record when no MX exists (RFC 5321)
+func (v *DomainVerifier) lookupMail(ctx context.Context, host string) (bool, error) {
+	records, err := v.resolver.LookupMX(ctx, host)
+	if err == nil && len(records) > 0 {
+		return true, nil
+	}
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+	return v.lookupHost(ctx, host)
+}
+
+func (v *DomainVerifier) lookupHost(ctx context.Context, host string) (bool, error) {
+	addrs, err := v.resolver.LookupHost(ctx, host)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(addrs) > 0, nil
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// emailDomain returns the part after the last "@"
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return email[at+1:]
+}
+
+// webHost extracts the host from a URL, tolerating a missing scheme
+func webHost(web string) string {
+	if web == "" {
+		return ""
+	}
+	if !strings.Contains(web, "://") {
+		web = "http://" + web
+	}
+	parsed, err := url.Parse(web)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"employee-management/internal/models"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers from fixed tables and counts lookups
+type fakeResolver struct {
+	mu      sync.Mutex
+	mx      map[string][]*net.MX
+	hosts   map[string][]string
+	failing map[string]bool
+	calls   int
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	if r.failing[name] {
+		return nil, errors.New("i/o timeout")
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	if r.failing[host] {
+		return nil, errors.New("i/o timeout")
+	}
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func newFakeResolver() *fakeResolver {
+	return &fakeResolver{
+		mx:      map[string][]*net.MX{"gmail.com": {{Host: "mx.gmail.com."}}},
+		hosts:   map[string][]string{"acme.com": {"192.0.2.1"}, "www.acme.com": {"192.0.2.2"}},
+		failing: map[string]bool{"slow.example": true},
+	}
+}
+
+func TestDomainVerifier_Verify(t *testing.T) {
+	tests := []struct {
+		name       string
+		email, web string
+		wantFields []string
+	}{
+		{"mx record", "john@gmail.com", "", nil},
+		{"implicit mx via address record", "john@acme.com", "https://www.acme.com/about", nil},
+		{"typo domain", "john@gmial.com", "", []string{"email"}},
+		{"unresolvable web without scheme", "john@gmail.com", "www.acme.co", []string{"web"}},
+		{"both bad", "john@gmial.com", "http://nope.invalid", []string{"email", "web"}},
+		{"lookup failure is not reported", "john@slow.example", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewDomainVerifier(newFakeResolver(), DomainCheckWarn, time.Second, time.Minute)
+
+			problems := verifier.Verify(context.Background(), &models.Employee{Email: tt.email, Web: tt.web})
+			if len(problems) != len(tt.wantFields) {
+				t.Fatalf("Expected problems for %v, got %+v", tt.wantFields, problems)
+			}
+			for i, field := range tt.wantFields {
+				if problems[i].Field != field {
+					t.Errorf("Expected problem %d on %s, got %s", i, field, problems[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestDomainVerifier_Disabled(t *testing.T) {
+	resolver := newFakeResolver()
+	verifier := NewDomainVerifier(resolver, "", time.Second, time.Minute)
+
+	if problems := verifier.Verify(context.Background(), &models.Employee{Email: "john@gmial.com"}); problems != nil {
+		t.Errorf("Expected no problems when disabled, got %+v", problems)
+	}
+	if resolver.calls != 0 {
+		t.Errorf("Expected no DNS lookups when disabled, got %d", resolver.calls)
+	}
+}
+
+func TestDomainVerifier_CachesResults(t *testing.T) {
+	resolver := newFakeResolver()
+	verifier := NewDomainVerifier(resolver, DomainCheckError, time.Second, time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	verifier.now = func() time.Time { return now }
+
+	employee := &models.Employee{Email: "john@gmail.com"}
+	verifier.Verify(context.Background(), employee)
+	verifier.Verify(context.Background(), employee)
+	if resolver.calls != 1 {
+		t.Errorf("Expected cached answer to be reused, got %d lookups", resolver.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	verifier.Verify(context.Background(), employee)
+	if resolver.calls != 2 {
+		t.Errorf("Expected lookup after cache expiry, got %d lookups", resolver.calls)
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"log"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Email syntax checks selectable with EMAIL_VALIDATION. An empty mode keeps
+// the validator's built-in email rule.
+const (
+	EmailValidationSimple = "simple" // anything shaped like local@domain.tld
+	EmailValidationRFC    = "rfc"    // any RFC 5322 addr-spec, including quoted local parts
+	EmailValidationStrict = "strict" // plain ASCII addresses with a dotted, letter-only TLD
+)
+
+var (
+	simpleEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	strictEmailPattern = regexp.MustCompile(
+		`^[A-Za-z0-9_%+-]+(\.[A-Za-z0-9_%+-]+)*@([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)+[A-Za-z]{2,}$`)
+)
+
+// emailValidators holds the replacement "email" rule for each mode
+var emailValidators = map[string]validator.Func{
+	EmailValidationSimple: func(fl validator.FieldLevel) bool {
+		return simpleEmailPattern.MatchString(fl.Field().String())
+	},
+	EmailValidationRFC: func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		address, err := mail.ParseAddress(value)
+		// ParseAddress also accepts "Name <addr>" and surrounding whitespace;
+		// only a bare addr-spec is valid here
+		return err == nil && address.Name == "" && !strings.Contains(value, "<") && value == strings.TrimSpace(value)
+	},
+	EmailValidationStrict: func(fl validator.FieldLevel) bool {
+		return strictEmailPattern.MatchString(fl.Field().String())
+	},
+}
+
+// newValidator builds the struct validator with the configured email rule. It
+// is shared by API writes and imports so both accept the same addresses.
+func newValidator(emailMode string) *validator.Validate {
+	validate := validator.New()
+	if emailMode == "" {
+		return validate
+	}
+
+	rule, ok := emailValidators[emailMode]
+	if !ok {
+		log.Printf("Warning: unknown EMAIL_VALIDATION %q, using the built-in email check", emailMode)
+		return validate
+	}
+	if err := validate.RegisterValidation("email", rule); err != nil {
+		log.Printf("Warning: failed to register %s email validation: %v", emailMode, err)
+	}
+	return validate
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"employee-management/internal/testutil"
+	"testing"
+)
+
+func TestEmailValidationModes(t *testing.T) {
+	tests := []struct {
+		email  string
+		simple bool
+		rfc    bool
+		strict bool
+	}{
+		{"john@example.com", true, true, true},
+		{"john.doe+tag@mail.example.co", true, true, true},
+		{`"john doe"@example.com`, false, true, false},
+		{"john@localhost", false, true, false},
+		{"john..doe@example.com", true, false, false},
+		{"john@example.c0m", true, true, false},
+		{"John Doe <john@example.com>", false, false, false},
+		{"not-an-email", false, false, false},
+	}
+
+	services := map[string]*EmployeeService{}
+	for _, mode := range []string{EmailValidationSimple, EmailValidationRFC, EmailValidationStrict} {
+		services[mode] = NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{
+			Validation: config.ValidationConfig{EmailMode: mode},
+		})
+	}
+
+	for _, tt := range tests {
+		expected := map[string]bool{
+			EmailValidationSimple: tt.simple,
+			EmailValidationRFC:    tt.rfc,
+			EmailValidationStrict: tt.strict,
+		}
+		for mode, service := range services {
+			errors, _ := service.ValidateEmployeeData(&models.Employee{FirstName: "John", LastName: "Doe", Email: tt.email})
+			if valid := len(errors) == 0; valid != expected[mode] {
+				t.Errorf("%s: expected %q valid=%v, got errors %+v", mode, tt.email, expected[mode], errors)
+			}
+		}
+	}
+}
+
+func TestEmailValidationMessages(t *testing.T) {
+	employee := &models.Employee{FirstName: "John", LastName: "Doe", Email: "john@localhost"}
+
+	strict := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{
+		Validation: config.ValidationConfig{EmailMode: EmailValidationStrict},
+	})
+	errors, _ := strict.ValidateEmployeeData(employee)
+	if len(errors) != 1 || errors[0].Message != validationMessages["en"]["email:strict"] {
+		t.Errorf("Expected the strict-mode message, got %+v", errors)
+	}
+
+	// Unknown modes keep the built-in rule and message
+	fallback := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{
+		Validation: config.ValidationConfig{EmailMode: "bogus"},
+	})
+	errors, _ = fallback.ValidateEmployeeDataForLocale(&models.Employee{FirstName: "John", LastName: "Doe", Email: "invalid"}, "es")
+	if len(errors) != 1 || errors[0].Message != "Formato de correo electrónico no válido" {
+		t.Errorf("Expected the generic email message, got %+v", errors)
+	}
+}
+
+func TestEmailValidation_ImportUsesConfiguredMode(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{
+		Validation: config.ValidationConfig{EmailMode: EmailValidationRFC},
+	})
+
+	rows := [][]string{
+		importHeaders,
+		{"John", "Doe", "", "", "", "", "", "", `"john doe"@example.com`, ""},
+		{"Jane", "Roe", "", "", "", "", "", "", "jane..roe@example.com", ""},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.InsertedRecords != 1 || response.InvalidRecords != 1 || repo.Count() != 1 {
+		t.Errorf("Expected the quoted address to import and the double-dot one to fail, got %+v", response)
+	}
+}
@@ -1,14 +1,21 @@
 package services
 
 import (
+	"context"
+	"employee-management/internal/config"
 	"employee-management/internal/database"
+	"employee-management/internal/events"
 	"employee-management/internal/models"
+	"employee-management/internal/pii"
 	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 )
 
@@ -16,23 +23,44 @@ import (
 type EmployeeService struct {
 	repo     database.Repository
 	cache    database.CacheInterface
+	config   *config.Config
 	validate *validator.Validate
+
+	// allowedCounties restricts county to ALLOWED_COUNTIES when configured
+	allowedCounties allowedValues
+	// severity downgrades configured validation rules to warnings
+	severity severityRules
+
+	// cacheWriteFailures counts failed cache writes, exposed on the health endpoint
+	cacheWriteFailures atomic.Int64
+
+	// publisher receives an event after every successful write
+	publisher events.Publisher
+
+	// loads coalesces concurrent cache misses on the same key; nil when
+	// CACHE_SINGLEFLIGHT is off
+	loads *singleflight.Group
 }
 
 // NewEmployeeService creates a new employee service
-func NewEmployeeService(repo database.Repository, cache database.CacheInterface) *EmployeeService {
+func NewEmployeeService(repo database.Repository, cache database.CacheInterface, cfg *config.Config) *EmployeeService {
 	return &EmployeeService{
-		repo:     repo,
-		cache:    cache,
-		validate: validator.New(),
+		repo:            repo,
+		cache:           cache,
+		config:          cfg,
+		validate:        newValidator(cfg.Validation.EmailMode),
+		allowedCounties: parseAllowedValues(cfg.Validation.AllowedCounties),
+		severity:        parseSeverityRules(cfg.Validation.Severity),
+		publisher:       events.NewPublisher(&cfg.Events),
+		loads:           newLoadGroup(cfg.Redis.SingleFlight),
 	}
 }
 
-// CreateEmployee creates a new employee
-func (s *EmployeeService) CreateEmployee(employee *models.Employee) error {
-	// Validate the employee data
-	if err := s.validate.Struct(employee); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+// CreateEmployee creates a new employee on behalf of actor
+func (s *EmployeeService) CreateEmployee(employee *models.Employee, actor events.Actor) error {
+	// Validate the employee data; rules configured as warnings do not block
+	if err := s.blockingError(employee); err != nil {
+		return err
 	}
 
 	// Check if email already exists
@@ -41,104 +69,212 @@ func (s *EmployeeService) CreateEmployee(employee *models.Employee) error {
 		return fmt.Errorf("failed to check existing employee: %w", err)
 	}
 	if existingEmployee != nil {
-		return fmt.Errorf("employee with email %s already exists", employee.Email)
+		return &EmployeeConflictError{Field: "email", Value: employee.Email}
+	}
+	if err := s.checkPhoneAvailable(employee.Phone, 0); err != nil {
+		return err
 	}
 
-	// Create employee in database
+	// Create employee in database. A concurrent create can still win the race
+	// after the check above, so the unique index is the final word on duplicates.
 	if err := s.repo.CreateEmployee(employee); err != nil {
+		if database.IsDuplicatePhoneError(err) {
+			return &EmployeeConflictError{Field: "phone", Value: employee.Phone}
+		}
+		if database.IsDuplicateKeyError(err) {
+			return &EmployeeConflictError{Field: "email", Value: employee.Email}
+		}
 		return fmt.Errorf("failed to create employee: %w", err)
 	}
 
 	// Cache the employee
 	if err := s.cache.SetEmployee(employee); err != nil {
-		log.Printf("Warning: Failed to cache employee %d: %v", employee.ID, err)
+		s.invalidationFailed(err, "Failed to cache employee %d", employee.ID)
 	}
 
 	// Invalidate list caches since we added a new employee
 	if err := s.cache.InvalidateEmployeeListCache(); err != nil {
-		log.Printf("Warning: Failed to invalidate employee list cache: %v", err)
+		s.invalidationFailed(err, "Failed to invalidate employee list cache")
 	}
+	s.touchLastModified()
 
-	return nil
+	return s.publishEvent(employeeEvent(events.EmployeeCreated, employee, actor))
 }
 
 // GetEmployeeByID retrieves an employee by ID (cache-first strategy)
-func (s *EmployeeService) GetEmployeeByID(id int) (*models.Employee, error) {
+func (s *EmployeeService) GetEmployeeByID(ctx context.Context, id int) (*models.Employee, error) {
 	// Try cache first
 	employee, err := s.cache.GetEmployee(id)
 	if err != nil {
-		log.Printf("Warning: Cache error for employee %d: %v", id, err)
+		log.Printf("Warning: Cache error for employee %d: %v request_id=%s", id, err, requestID(ctx))
 	} else if employee != nil {
-		log.Printf("Cache hit for employee %d", id)
+		log.Printf("Cache hit for employee %d request_id=%s", id, requestID(ctx))
 		return employee, nil
 	}
 
 	// Cache miss, get from database
-	log.Printf("Cache miss for employee %d, fetching from database", id)
-	employee, err = s.repo.GetEmployeeByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("employee with ID %d not found", id)
+	log.Printf("Cache miss for employee %d, fetching from database request_id=%s", id, requestID(ctx))
+	return loadEmployeeShared(s, fmt.Sprintf("employee:%d", id), func() (*models.Employee, error) {
+		employee, err := s.repo.GetEmployeeByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("employee with ID %d not found", id)
+			}
+			return nil, fmt.Errorf("failed to get employee: %w", err)
 		}
-		return nil, fmt.Errorf("failed to get employee: %w", err)
-	}
 
-	// Cache the result
-	if err := s.cache.SetEmployee(employee); err != nil {
-		log.Printf("Warning: Failed to cache employee %d: %v", id, err)
-	}
+		// Cache the result
+		if err := s.cache.SetEmployee(employee); err != nil {
+			if err := s.cacheWriteFailed(ctx, err, "Failed to cache employee %d", id); err != nil {
+				return nil, err
+			}
+		}
 
-	return employee, nil
+		return employee, nil
+	})
 }
 
-// GetAllEmployees retrieves all employees with pagination (cache-first strategy)
-func (s *EmployeeService) GetAllEmployees(limit, offset int) ([]models.Employee, int64, error) {
+// GetAllEmployees retrieves all employees with pagination in the given order
+// (cache-first strategy). A positive cacheTTL overrides the default expiry of
+// the cached page.
+func (s *EmployeeService) GetAllEmployees(ctx context.Context, limit, offset int, sort models.SortOptions, cacheTTL time.Duration) ([]models.Employee, int64, error) {
 	// Generate cache key
-	cacheKey := database.GenerateListCacheKey(limit, offset, "")
+	cacheKey := database.GenerateListCacheKey(limit, offset, "", nil, sort, cacheTTL)
 
 	// Try cache first
 	employees, total, err := s.cache.GetEmployeeList(cacheKey)
 	if err != nil {
-		log.Printf("Warning: Cache error for employee list: %v", err)
+		log.Printf("Warning: Cache error for employee list: %v request_id=%s", err, requestID(ctx))
 	} else if employees != nil {
-		log.Printf("Cache hit for employee list (limit: %d, offset: %d)", limit, offset)
+		log.Printf("Cache hit for employee list (limit: %d, offset: %d) request_id=%s", limit, offset, requestID(ctx))
 		return employees, total, nil
 	}
 
 	// Cache miss, get from database
-	log.Printf("Cache miss for employee list, fetching from database (limit: %d, offset: %d)", limit, offset)
-	employees, total, err = s.repo.GetAllEmployees(limit, offset)
+	log.Printf("Cache miss for employee list, fetching from database (limit: %d, offset: %d) request_id=%s", limit, offset, requestID(ctx))
+	return loadListShared(s, cacheKey, func() (listResult, error) {
+		employees, total, err := s.repo.GetAllEmployees(limit, offset, sort)
+		if err != nil {
+			return listResult{}, fmt.Errorf("failed to get employees: %w", err)
+		}
+
+		// Cache the result
+		if err := s.cache.SetEmployeeList(cacheKey, employees, total, cacheTTL); err != nil {
+			if err := s.cacheWriteFailed(ctx, err, "Failed to cache employee list"); err != nil {
+				return listResult{}, err
+			}
+		}
+
+		return listResult{employees, total}, nil
+	})
+}
+
+// GetEmployeesAfterID returns a keyset page of up to limit employees with IDs
+// above cursorID and whether more follow (cache-first strategy). A positive
+// cacheTTL overrides the default expiry of the cached page.
+func (s *EmployeeService) GetEmployeesAfterID(ctx context.Context, cursorID, limit int, cacheTTL time.Duration) ([]models.Employee, bool, error) {
+	cacheKey := database.GenerateCursorCacheKey(cursorID, limit, cacheTTL)
+
+	// The cached total counts one extra row when more follow the page
+	employees, total, err := s.cache.GetEmployeeList(cacheKey)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get employees: %w", err)
+		log.Printf("Warning: Cache error for employee page: %v request_id=%s", err, requestID(ctx))
+	} else if employees != nil {
+		log.Printf("Cache hit for employee page (cursor: %d, limit: %d) request_id=%s", cursorID, limit, requestID(ctx))
+		return employees, total > int64(len(employees)), nil
 	}
 
-	// Cache the result
-	if err := s.cache.SetEmployeeList(cacheKey, employees, total); err != nil {
-		log.Printf("Warning: Failed to cache employee list: %v", err)
+	log.Printf("Cache miss for employee page, fetching from database (cursor: %d, limit: %d) request_id=%s", cursorID, limit, requestID(ctx))
+	employees, total, err = loadListShared(s, cacheKey, func() (listResult, error) {
+		employees, hasMore, err := s.repo.GetEmployeesAfterID(cursorID, limit)
+		if err != nil {
+			return listResult{}, fmt.Errorf("failed to get employees: %w", err)
+		}
+
+		total := int64(len(employees))
+		if hasMore {
+			total++
+		}
+		if err := s.cache.SetEmployeeList(cacheKey, employees, total, cacheTTL); err != nil {
+			if err := s.cacheWriteFailed(ctx, err, "Failed to cache employee page"); err != nil {
+				return listResult{}, err
+			}
+		}
+
+		return listResult{employees, total}, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return employees, total > int64(len(employees)), nil
+}
+
+// GetNextEmployee returns the employee with the lowest ID above afterID, or
+// nil when none remain (cache-first strategy). The answer is cached with the
+// lists, which every write invalidates.
+func (s *EmployeeService) GetNextEmployee(ctx context.Context, afterID int) (*models.Employee, error) {
+	cacheKey := database.GenerateNextCacheKey(afterID)
+
+	// Try cache first; an empty cached list means none remain
+	employees, _, err := s.cache.GetEmployeeList(cacheKey)
+	if err != nil {
+		log.Printf("Warning: Cache error for next employee: %v request_id=%s", err, requestID(ctx))
+	} else if employees != nil {
+		log.Printf("Cache hit for next employee after %d request_id=%s", afterID, requestID(ctx))
+		if len(employees) == 0 {
+			return nil, nil
+		}
+		return &employees[0], nil
+	}
+
+	log.Printf("Cache miss for next employee after %d, fetching from database request_id=%s", afterID, requestID(ctx))
+	employee, err := s.repo.GetNextEmployee(afterID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get next employee: %w", err)
 	}
 
-	return employees, total, nil
+	employees = []models.Employee{}
+	if employee != nil {
+		employees = append(employees, *employee)
+	}
+	if err := s.cache.SetEmployeeList(cacheKey, employees, int64(len(employees)), 0); err != nil {
+		if err := s.cacheWriteFailed(ctx, err, "Failed to cache next employee after %d", afterID); err != nil {
+			return nil, err
+		}
+	}
+
+	return employee, nil
 }
 
-// UpdateEmployee updates an existing employee
-func (s *EmployeeService) UpdateEmployee(id int, updateData *models.Employee) (*models.Employee, error) {
+// UpdateEmployee updates an existing employee and returns the columns whose
+// value changed. When none did and SKIP_UNCHANGED_UPDATES is on, the write,
+// cache invalidation, updated_at bump and event are skipped.
+func (s *EmployeeService) UpdateEmployee(id int, updateData *models.Employee, locale string, actor events.Actor) (employee *models.Employee, changed []string, err error) {
 	// Get existing employee
 	existingEmployee, err := s.repo.GetEmployeeByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("employee with ID %d not found", id)
+			return nil, nil, fmt.Errorf("employee with ID %d not found", id)
 		}
-		return nil, fmt.Errorf("failed to get employee: %w", err)
+		return nil, nil, fmt.Errorf("failed to get employee: %w", err)
 	}
 
 	// Check if email is being changed and if new email already exists
 	if updateData.Email != "" && updateData.Email != existingEmployee.Email {
 		emailEmployee, err := s.repo.GetEmployeeByEmail(updateData.Email)
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("failed to check existing email: %w", err)
+			return nil, nil, fmt.Errorf("failed to check existing email: %w", err)
 		}
 		if emailEmployee != nil {
-			return nil, fmt.Errorf("employee with email %s already exists", updateData.Email)
+			return nil, nil, &EmployeeConflictError{Field: "email", Value: updateData.Email}
+		}
+	}
+
+	original := *existingEmployee
+
+	if updateData.Phone != "" && updateData.Phone != existingEmployee.Phone {
+		if err := s.checkPhoneAvailable(updateData.Phone, id); err != nil {
+			return nil, nil, err
 		}
 	}
 
@@ -173,32 +309,153 @@ func (s *EmployeeService) UpdateEmployee(id int, updateData *models.Employee) (*
 	if updateData.Web != "" {
 		existingEmployee.Web = updateData.Web
 	}
+	if updateData.Latitude != nil {
+		existingEmployee.Latitude = updateData.Latitude
+	}
+	if updateData.Longitude != nil {
+		existingEmployee.Longitude = updateData.Longitude
+	}
 
-	// Validate updated employee
-	if err := s.validate.Struct(existingEmployee); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+	changed = changedColumns(&original, existingEmployee)
+	if s.config.Server.SkipUnchangedUpdates && len(changed) == 0 {
+		return existingEmployee, changed, nil
 	}
 
+	// Validate the updated employee; rules configured as warnings do not
+	// block. Only a supplied county is checked, so a legacy one can stay.
+	problems := s.structProblems(existingEmployee, locale)
+	if updateData.County != "" {
+		if problem := s.CheckCounty(existingEmployee.County, locale); problem != nil {
+			problems = append(problems, validationProblem{column: "county", rule: "county", ValidationError: *problem})
+		}
+	}
+	if errs, _ := s.severity.split(problems); len(errs) > 0 {
+		return nil, nil, &EmployeeValidationError{Details: errs}
+	}
+
+	if err := s.storeUpdate(existingEmployee, changed, actor); err != nil {
+		return nil, nil, err
+	}
+	return existingEmployee, changed, nil
+}
+
+// storeUpdate saves a validated, updated employee and publishes its update
+// event listing the changed columns
+func (s *EmployeeService) storeUpdate(employee *models.Employee, changed []string, actor events.Actor) error {
+	if err := s.saveEmployee(employee); err != nil {
+		// A concurrent write can take the email or phone after the checks
+		if database.IsDuplicatePhoneError(err) {
+			return &EmployeeConflictError{Field: "phone", Value: employee.Phone}
+		}
+		if database.IsDuplicateKeyError(err) {
+			return &EmployeeConflictError{Field: "email", Value: employee.Email}
+		}
+		return err
+	}
+
+	event := employeeEvent(events.EmployeeUpdated, employee, actor)
+	event.Changed = changed
+	return s.publishEvent(event)
+}
+
+// EmployeeConflictError reports that another employee already holds a
+// unique value, the email or (with UNIQUE_PHONE) the phone
+type EmployeeConflictError struct {
+	Field string
+	Value string
+}
+
+func (e *EmployeeConflictError) Error() string {
+	return fmt.Sprintf("employee with %s %s already exists", e.Field, e.Value)
+}
+
+// checkPhoneAvailable rejects a non-empty phone already used by another
+// employee than exceptID when UNIQUE_PHONE is on; empty phones are exempt
+func (s *EmployeeService) checkPhoneAvailable(phone string, exceptID int) error {
+	if !s.config.Database.UniquePhone || phone == "" {
+		return nil
+	}
+	existing, err := s.repo.GetEmployeeByPhone(phone)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check existing phone: %w", err)
+	}
+	if existing != nil && existing.ID != exceptID {
+		return &EmployeeConflictError{Field: "phone", Value: phone}
+	}
+	return nil
+}
+
+// changedColumns lists the editable columns on which two employees differ,
+// coordinates included
+func changedColumns(a, b *models.Employee) []string {
+	var changed []string
+	for _, column := range models.EmployeeColumnNames() {
+		if a.ColumnValue(column) != b.ColumnValue(column) {
+			changed = append(changed, column)
+		}
+	}
+	if !sameCoordinate(a.Latitude, b.Latitude) {
+		changed = append(changed, "latitude")
+	}
+	if !sameCoordinate(a.Longitude, b.Longitude) {
+		changed = append(changed, "longitude")
+	}
+	return changed
+}
+
+// sameCoordinate compares two optional coordinates, nil meaning unknown
+func sameCoordinate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// TouchEmployee bumps an employee's updated_at without changing any field, so
+// sync consumers see the record as changed and re-pull it. Validation is
+// skipped because the data is stored exactly as it was read.
+func (s *EmployeeService) TouchEmployee(id int, actor events.Actor) (*models.Employee, error) {
+	employee, err := s.repo.GetEmployeeByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("employee with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	if err := s.saveEmployee(employee); err != nil {
+		return nil, err
+	}
+	if err := s.publishEvent(employeeEvent(events.EmployeeUpdated, employee, actor)); err != nil {
+		return nil, err
+	}
+
+	return employee, nil
+}
+
+// saveEmployee writes an existing employee (the repository sets updated_at)
+// and refreshes the caches that depend on it
+func (s *EmployeeService) saveEmployee(employee *models.Employee) error {
 	// Update in database
-	if err := s.repo.UpdateEmployee(existingEmployee); err != nil {
-		return nil, fmt.Errorf("failed to update employee: %w", err)
+	if err := s.repo.UpdateEmployee(employee); err != nil {
+		return fmt.Errorf("failed to update employee: %w", err)
 	}
 
 	// Update cache
-	if err := s.cache.SetEmployee(existingEmployee); err != nil {
-		log.Printf("Warning: Failed to update employee cache %d: %v", id, err)
+	if err := s.cache.SetEmployee(employee); err != nil {
+		s.invalidationFailed(err, "Failed to update employee cache %d", employee.ID)
 	}
 
 	// Invalidate list caches since data changed
 	if err := s.cache.InvalidateEmployeeListCache(); err != nil {
-		log.Printf("Warning: Failed to invalidate employee list cache: %v", err)
+		s.invalidationFailed(err, "Failed to invalidate employee list cache")
 	}
-
-	return existingEmployee, nil
+	s.touchLastModified()
+	return nil
 }
 
 // DeleteEmployee deletes an employee and returns the deleted employee data
-func (s *EmployeeService) DeleteEmployee(id int) (*models.EmployeeResponse, error) {
+func (s *EmployeeService) DeleteEmployee(id int, actor events.Actor) (*models.EmployeeResponse, error) {
 	// Get employee data before deletion
 	employee, err := s.repo.GetEmployeeByID(id)
 	if err != nil {
@@ -215,12 +472,16 @@ func (s *EmployeeService) DeleteEmployee(id int) (*models.EmployeeResponse, erro
 
 	// Remove from cache
 	if err := s.cache.DeleteEmployee(id); err != nil {
-		log.Printf("Warning: Failed to delete employee from cache %d: %v", id, err)
+		s.invalidationFailed(err, "Failed to delete employee from cache %d", id)
 	}
 
 	// Invalidate list caches since data changed
 	if err := s.cache.InvalidateEmployeeListCache(); err != nil {
-		log.Printf("Warning: Failed to invalidate employee list cache: %v", err)
+		s.invalidationFailed(err, "Failed to invalidate employee list cache")
+	}
+	s.touchLastModified()
+	if err := s.publishEvent(employeeEvent(events.EmployeeDeleted, employee, actor)); err != nil {
+		return nil, err
 	}
 
 	// Return the deleted employee data
@@ -228,44 +489,314 @@ func (s *EmployeeService) DeleteEmployee(id int) (*models.EmployeeResponse, erro
 	return &response, nil
 }
 
-// SearchEmployees searches employees by query
-func (s *EmployeeService) SearchEmployees(query string, limit, offset int) ([]models.Employee, int64, error) {
-	// Sanitize search query
+// DeleteEmployees deletes the employees with the given IDs in one
+// transaction and reports the IDs that matched no employee. Each deleted
+// employee is dropped from the cache and gets a deleted event; the list cache
+// is invalidated once for the whole batch.
+func (s *EmployeeService) DeleteEmployees(ids []int, actor events.Actor) (*models.BulkDeleteResult, error) {
+	employees, err := s.repo.GetEmployeesByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employees: %w", err)
+	}
+
+	found := make(map[int]bool, len(employees))
+	result := &models.BulkDeleteResult{DeletedIDs: []int{}, NotFoundIDs: []int{}}
+	for _, employee := range employees {
+		found[employee.ID] = true
+		result.DeletedIDs = append(result.DeletedIDs, employee.ID)
+	}
+	for _, id := range ids {
+		if !found[id] {
+			result.NotFoundIDs = append(result.NotFoundIDs, id)
+		}
+	}
+	if len(employees) == 0 {
+		return result, nil
+	}
+
+	result.Deleted, err = s.repo.DeleteEmployeesInBatch(result.DeletedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete employees: %w", err)
+	}
+
+	for _, id := range result.DeletedIDs {
+		if err := s.cache.DeleteEmployee(id); err != nil {
+			s.invalidationFailed(err, "Failed to delete employee from cache %d", id)
+		}
+	}
+	if err := s.cache.InvalidateEmployeeListCache(); err != nil {
+		s.invalidationFailed(err, "Failed to invalidate employee list cache")
+	}
+	s.touchLastModified()
+	for i := range employees {
+		if err := s.publishEvent(employeeEvent(events.EmployeeDeleted, &employees[i], actor)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// searchPattern turns a user's search query into the repository's LIKE
+// pattern. The query is matched literally unless wildcards is set, in which
+// case % and _ keep their LIKE meaning.
+func searchPattern(query string, wildcards bool) string {
 	query = strings.TrimSpace(query)
+	if wildcards {
+		return query
+	}
+	return database.EscapeLike(query)
+}
+
+// SearchEmployees searches employees by query, ordering matches by sort
+func (s *EmployeeService) SearchEmployees(ctx context.Context, query string, wildcards bool, limit, offset int, sort models.SortOptions, cacheTTL time.Duration) ([]models.Employee, int64, error) {
+	// Sanitize search query
+	query = searchPattern(query, wildcards)
 	if query == "" {
-		return s.GetAllEmployees(limit, offset)
+		return s.GetAllEmployees(ctx, limit, offset, sort, cacheTTL)
 	}
 
 	// Generate cache key for search
-	cacheKey := database.GenerateListCacheKey(limit, offset, query)
+	cacheKey := database.GenerateListCacheKey(limit, offset, query, nil, sort, cacheTTL)
 
 	// Try cache first
 	employees, total, err := s.cache.GetEmployeeList(cacheKey)
 	if err != nil {
-		log.Printf("Warning: Cache error for search: %v", err)
+		log.Printf("Warning: Cache error for search: %v request_id=%s", err, requestID(ctx))
 	} else if employees != nil {
-		log.Printf("Cache hit for search: %s (limit: %d, offset: %d)", query, limit, offset)
+		log.Printf("Cache hit for search: %s (limit: %d, offset: %d) request_id=%s", pii.Redact(query), limit, offset, requestID(ctx))
 		return employees, total, nil
 	}
 
 	// Cache miss, search in database
-	log.Printf("Cache miss for search, querying database: %s (limit: %d, offset: %d)", query, limit, offset)
-	employees, total, err = s.repo.SearchEmployees(query, limit, offset)
+	log.Printf("Cache miss for search, querying database: %s (limit: %d, offset: %d) request_id=%s", pii.Redact(query), limit, offset, requestID(ctx))
+	return loadListShared(s, cacheKey, func() (listResult, error) {
+		employees, total, err := s.repo.SearchEmployees(query, limit, offset, sort)
+		if err != nil {
+			return listResult{}, fmt.Errorf("failed to search employees: %w", err)
+		}
+
+		// Cache the search result
+		if err := s.cache.SetEmployeeList(cacheKey, employees, total, cacheTTL); err != nil {
+			if err := s.cacheWriteFailed(ctx, err, "Failed to cache search result"); err != nil {
+				return listResult{}, err
+			}
+		}
+
+		return listResult{employees, total}, nil
+	})
+}
+
+// ListEmployeesExcluding lists employees matching the optional search query
+// except excludeIDs (cache-first strategy). With limit 0 only the total is
+// computed. excludeIDs should be sorted so equal lists share a cache entry.
+func (s *EmployeeService) ListEmployeesExcluding(ctx context.Context, query string, wildcards bool, excludeIDs []int, limit, offset int, sort models.SortOptions, cacheTTL time.Duration) ([]models.Employee, int64, error) {
+	query = searchPattern(query, wildcards)
+	cacheKey := database.GenerateListCacheKey(limit, offset, query, excludeIDs, sort, cacheTTL)
+
+	// Try cache first
+	employees, total, err := s.cache.GetEmployeeList(cacheKey)
+	if err != nil {
+		log.Printf("Warning: Cache error for employee list: %v request_id=%s", err, requestID(ctx))
+	} else if employees != nil {
+		log.Printf("Cache hit for employee list excluding %d IDs (limit: %d, offset: %d) request_id=%s", len(excludeIDs), limit, offset, requestID(ctx))
+		return employees, total, nil
+	}
+
+	// Cache miss, query the database
+	log.Printf("Cache miss for employee list excluding %d IDs, querying database (limit: %d, offset: %d) request_id=%s", len(excludeIDs), limit, offset, requestID(ctx))
+	return loadListShared(s, cacheKey, func() (listResult, error) {
+		employees, total, err := s.repo.ListEmployeesExcluding(query, excludeIDs, limit, offset, sort)
+		if err != nil {
+			return listResult{}, fmt.Errorf("failed to list employees: %w", err)
+		}
+
+		if err := s.cache.SetEmployeeList(cacheKey, employees, total, cacheTTL); err != nil {
+			if err := s.cacheWriteFailed(ctx, err, "Failed to cache employee list"); err != nil {
+				return listResult{}, err
+			}
+		}
+
+		return listResult{employees, total}, nil
+	})
+}
+
+// FilterEmployees lists employees whose filter columns contain the given
+// values, combined with the optional search query and leaving out excludeIDs
+// (cache-first strategy).
+// Filter values always match literally; wildcards applies to query only.
+// With limit 0 only the total is computed.
+func (s *EmployeeService) FilterEmployees(ctx context.Context, filters map[string]string, query string, wildcards bool, excludeIDs []int, limit, offset int, sort models.SortOptions, cacheTTL time.Duration) ([]models.Employee, int64, error) {
+	query = searchPattern(query, wildcards)
+	cacheKey := database.GenerateFilterCacheKey(filters, limit, offset, query, excludeIDs, sort, cacheTTL)
+
+	// Try cache first
+	employees, total, err := s.cache.GetEmployeeList(cacheKey)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to search employees: %w", err)
+		log.Printf("Warning: Cache error for filtered list: %v request_id=%s", err, requestID(ctx))
+	} else if employees != nil {
+		log.Printf("Cache hit for employee list with %d filters (limit: %d, offset: %d) request_id=%s", len(filters), limit, offset, requestID(ctx))
+		return employees, total, nil
 	}
 
-	// Cache the search result
-	if err := s.cache.SetEmployeeList(cacheKey, employees, total); err != nil {
-		log.Printf("Warning: Failed to cache search result: %v", err)
+	// Cache miss, query the database
+	log.Printf("Cache miss for employee list with %d filters, querying database (limit: %d, offset: %d) request_id=%s", len(filters), limit, offset, requestID(ctx))
+	return loadListShared(s, cacheKey, func() (listResult, error) {
+		employees, total, err := s.repo.FilterEmployees(filters, query, excludeIDs, limit, offset, sort)
+		if err != nil {
+			return listResult{}, fmt.Errorf("failed to filter employees: %w", err)
+		}
+
+		if err := s.cache.SetEmployeeList(cacheKey, employees, total, cacheTTL); err != nil {
+			if err := s.cacheWriteFailed(ctx, err, "Failed to cache filtered list"); err != nil {
+				return listResult{}, err
+			}
+		}
+
+		return listResult{employees, total}, nil
+	})
+}
+
+// CountEmployees returns the number of employees matching the search query,
+// or all employees when it is empty, without loading any rows
+func (s *EmployeeService) CountEmployees(query string, wildcards bool) (int64, error) {
+	total, err := s.repo.CountEmployees(searchPattern(query, wildcards))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count employees: %w", err)
 	}
+	return total, nil
+}
 
-	return employees, total, nil
+// ExplainSearch returns the database plan for the page query a search (or a
+// plain listing when query is empty) would run, leaving out excludeIDs. It
+// bypasses the cache.
+func (s *EmployeeService) ExplainSearch(query string, wildcards bool, excludeIDs []int, limit, offset int, sort models.SortOptions) ([]map[string]interface{}, error) {
+	plan, err := s.repo.ExplainSearch(searchPattern(query, wildcards), excludeIDs, limit, offset, sort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain search: %w", err)
+	}
+	return plan, nil
+}
+
+// topCompaniesInStats is how many companies the stats endpoint ranks
+const topCompaniesInStats = 5
+
+// GetEmployeeStats returns aggregate employee figures (cache-first strategy)
+func (s *EmployeeService) GetEmployeeStats(ctx context.Context) (*models.EmployeeStats, error) {
+	// Try cache first
+	stats, err := s.cache.GetEmployeeStats()
+	if err != nil {
+		log.Printf("Warning: Cache error for employee stats: %v request_id=%s", err, requestID(ctx))
+	} else if stats != nil {
+		log.Printf("Cache hit for employee stats request_id=%s", requestID(ctx))
+		return stats, nil
+	}
+
+	// Cache miss, compute from database
+	log.Printf("Cache miss for employee stats, computing from database request_id=%s", requestID(ctx))
+	stats, err = s.repo.GetEmployeeStats(topCompaniesInStats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee stats: %w", err)
+	}
+
+	// Cache the result; list invalidation on writes also clears it
+	if err := s.cache.SetEmployeeStats(stats); err != nil {
+		if err := s.cacheWriteFailed(ctx, err, "Failed to cache employee stats"); err != nil {
+			return nil, err
+		}
+	}
+
+	return stats, nil
+}
+
+// LastModified returns when any employee last changed, as tracked in the
+// cache on every write, deletes included. Without a tracked time (after a
+// cache restart, with NoopCache or while the cache is unreachable) it returns
+// the zero time so no 304 is sent: the newest updated_at in the database
+// would not move on deletes. A miss starts tracking from now.
+func (s *EmployeeService) LastModified(ctx context.Context) (time.Time, error) {
+	modified, err := s.cache.GetLastModified()
+	if err != nil {
+		log.Printf("Warning: Cache error for last modified time: %v request_id=%s", err, requestID(ctx))
+		return time.Time{}, nil
+	}
+
+	if modified.IsZero() {
+		if err := s.cache.SetLastModified(time.Now()); err != nil {
+			if err := s.cacheWriteFailed(ctx, err, "Failed to start tracking last modified time"); err != nil {
+				return time.Time{}, err
+			}
+		}
+	}
+	return modified, nil
+}
+
+// touchLastModified records that employee data changed just now. Deletes are
+// covered too, which the database's max(updated_at) alone could not detect.
+func (s *EmployeeService) touchLastModified() {
+	if err := s.cache.SetLastModified(time.Now()); err != nil {
+		s.invalidationFailed(err, "Failed to update last modified time")
+	}
+}
+
+// invalidationFailed counts and logs a cache update that follows a committed
+// write. It never fails the request, not even with FAIL_ON_CACHE_ERROR: the
+// write already happened, and an error would make clients retry it.
+func (s *EmployeeService) invalidationFailed(err error, format string, args ...interface{}) {
+	s.cacheWriteFailures.Add(1)
+	log.Printf("Warning: %s: %v", fmt.Sprintf(format, args...), err)
+}
+
+// cacheWriteFailed counts and logs a failed cache fill on the read path. The
+// failure is swallowed unless FAIL_ON_CACHE_ERROR is set, in which case it is
+// returned so misconfigured caches surface as request errors.
+func (s *EmployeeService) cacheWriteFailed(ctx context.Context, err error, format string, args ...interface{}) error {
+	s.cacheWriteFailures.Add(1)
+	message := fmt.Sprintf(format, args...)
+	log.Printf("Warning: %s: %v request_id=%s", message, err, requestID(ctx))
+
+	if s.config.Redis.FailOnError {
+		return fmt.Errorf("cache write failed: %s: %w", message, err)
+	}
+	return nil
+}
+
+// InspectCache returns the cached entry for an employee (employee:<id>) or
+// list (employee_list:<key>) cache key, or nil when nothing is cached
+func (s *EmployeeService) InspectCache(key string) (*database.CacheEntry, error) {
+	if !database.InspectableCacheKey(key) {
+		return nil, fmt.Errorf("unsupported cache key")
+	}
+	entry, err := s.cache.Inspect(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect cache: %w", err)
+	}
+	return entry, nil
+}
+
+// ErrCacheUnavailable reports that the cache backend cannot be reached
+var ErrCacheUnavailable = errors.New("cache is unavailable")
+
+// CacheStats returns how many employees and lists are cached and the
+// configured expiry. An unreachable backend is reported as ErrCacheUnavailable.
+func (s *EmployeeService) CacheStats() (map[string]interface{}, error) {
+	if err := s.cache.Health(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCacheUnavailable, err)
+	}
+	stats, err := s.cache.GetCacheStats()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCacheUnavailable, err)
+	}
+	return stats, nil
+}
+
+// CacheWriteFailures returns how many cache writes have failed since startup
+func (s *EmployeeService) CacheWriteFailures() int64 {
+	return s.cacheWriteFailures.Load()
 }
 
 // GetEmployeeResponse converts employee to response format
-func (s *EmployeeService) GetEmployeeResponse(id int) (*models.EmployeeResponse, error) {
-	employee, err := s.GetEmployeeByID(id)
+func (s *EmployeeService) GetEmployeeResponse(ctx context.Context, id int) (*models.EmployeeResponse, error) {
+	employee, err := s.GetEmployeeByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -274,9 +805,24 @@ func (s *EmployeeService) GetEmployeeResponse(id int) (*models.EmployeeResponse,
 	return &response, nil
 }
 
+// CompareEmployees returns a field-by-field comparison of two employees
+func (s *EmployeeService) CompareEmployees(ctx context.Context, idA, idB int) (*models.EmployeeDiff, error) {
+	a, err := s.GetEmployeeByID(ctx, idA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.GetEmployeeByID(ctx, idB)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := models.DiffEmployees(a, b)
+	return &diff, nil
+}
+
 // GetEmployeeListResponse converts employee list to response format
-func (s *EmployeeService) GetEmployeeListResponse(limit, offset int) ([]models.EmployeeResponse, int64, error) {
-	employees, total, err := s.GetAllEmployees(limit, offset)
+func (s *EmployeeService) GetEmployeeListResponse(ctx context.Context, limit, offset int, sort models.SortOptions, cacheTTL time.Duration) ([]models.EmployeeResponse, int64, error) {
+	employees, total, err := s.GetAllEmployees(ctx, limit, offset, sort, cacheTTL)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -289,36 +835,18 @@ func (s *EmployeeService) GetEmployeeListResponse(limit, offset int) ([]models.E
 	return responses, total, nil
 }
 
-// ValidateEmployeeData validates employee data
-func (s *EmployeeService) ValidateEmployeeData(employee *models.Employee) []models.ValidationError {
-	var validationErrors []models.ValidationError
-
-	if err := s.validate.Struct(employee); err != nil {
-		for _, err := range err.(validator.ValidationErrors) {
-			validationErrors = append(validationErrors, models.ValidationError{
-				Field:   err.Field(),
-				Message: getValidationMessage(err),
-			})
-		}
-	}
-
-	return validationErrors
+// ValidateEmployeeData validates employee data with messages in the default locale
+func (s *EmployeeService) ValidateEmployeeData(employee *models.Employee) (errs, warnings []models.ValidationError) {
+	return s.ValidateEmployeeDataForLocale(employee, DefaultLocale)
 }
 
-// getValidationMessage returns user-friendly validation messages
-func getValidationMessage(err validator.FieldError) string {
-	switch err.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", err.Field())
-	case "email":
-		return "Invalid email format"
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", err.Field(), err.Param())
-	case "max":
-		return fmt.Sprintf("%s must not exceed %s characters", err.Field(), err.Param())
-	case "url":
-		return "Invalid URL format"
-	default:
-		return fmt.Sprintf("%s is invalid", err.Field())
+// ValidateEmployeeDataForLocale validates employee data with messages in the
+// given locale. errs block the record; warnings come from rules that
+// VALIDATION_SEVERITY downgrades and only flag it.
+func (s *EmployeeService) ValidateEmployeeDataForLocale(employee *models.Employee, locale string) (errs, warnings []models.ValidationError) {
+	problems := s.structProblems(employee, locale)
+	if problem := s.CheckCounty(employee.County, locale); problem != nil {
+		problems = append(problems, validationProblem{column: "county", rule: "county", ValidationError: *problem})
 	}
+	return s.severity.split(problems)
 }
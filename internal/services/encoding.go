@@ -0,0 +1,72 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// utf8BOM is the byte order mark some editors (notably Excel's CSV export) prepend
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Supported values for IMPORT_CHARSET
+const (
+	CharsetAuto        = "auto"
+	CharsetUTF8        = "utf-8"
+	CharsetWindows1252 = "windows-1252"
+	CharsetISO88591    = "iso-8859-1"
+)
+
+// decodeImportText converts raw text file content to UTF-8.
+// Byte order marks always win. Otherwise the configured charset is used; in
+// auto mode content that is not valid UTF-8 is assumed to be Windows-1252,
+// which covers the Latin-1 exports we receive from European partners.
+func decodeImportText(content []byte, charset string) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		return content[len(utf8BOM):], nil
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}):
+		return transcode(content, unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM))
+	case bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		return transcode(content, unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM))
+	}
+
+	switch strings.ToLower(charset) {
+	case "", CharsetAuto:
+		if utf8.Valid(content) {
+			return content, nil
+		}
+		return transcode(content, charmap.Windows1252)
+	case CharsetUTF8, "utf8":
+		if !utf8.Valid(content) {
+			return nil, fmt.Errorf("file is not valid UTF-8; set IMPORT_CHARSET to its actual encoding")
+		}
+		return content, nil
+	case CharsetWindows1252, "cp1252":
+		return transcode(content, charmap.Windows1252)
+	case CharsetISO88591, "latin1":
+		return transcode(content, charmap.ISO8859_1)
+	default:
+		return nil, fmt.Errorf("unsupported import charset %q", charset)
+	}
+}
+
+// transcode decodes content from the given encoding into UTF-8
+func transcode(content []byte, enc encoding.Encoding) ([]byte, error) {
+	decoded, err := enc.NewDecoder().Bytes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file content: %w", err)
+	}
+	return decoded, nil
+}
+
+// stripBOM removes a leading byte order mark from a single cell value, which
+// otherwise breaks header matching (e.g. "\ufefffirst_name")
+func stripBOM(value string) string {
+	return strings.TrimPrefix(value, "\ufeff")
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"testing"
+)
+
+func TestDecodeImportText(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		charset  string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "plain UTF-8 is untouched",
+			content:  []byte("first_name\nJosé"),
+			charset:  CharsetAuto,
+			expected: "first_name\nJosé",
+		},
+		{
+			name:     "UTF-8 BOM is stripped",
+			content:  append([]byte{0xEF, 0xBB, 0xBF}, []byte("first_name\nJosé")...),
+			charset:  CharsetAuto,
+			expected: "first_name\nJosé",
+		},
+		{
+			name:     "UTF-16LE BOM is transcoded",
+			content:  []byte{0xFF, 0xFE, 'J', 0, 'o', 0, 's', 0, 0xE9, 0},
+			charset:  CharsetAuto,
+			expected: "José",
+		},
+		{
+			name:     "Latin-1 bytes are detected in auto mode",
+			content:  []byte{'J', 'o', 's', 0xE9},
+			charset:  CharsetAuto,
+			expected: "José",
+		},
+		{
+			name:     "explicit ISO-8859-1",
+			content:  []byte{'M', 0xFC, 'l', 'l', 'e', 'r'},
+			charset:  CharsetISO88591,
+			expected: "Müller",
+		},
+		{
+			name:     "explicit Windows-1252 maps euro sign",
+			content:  []byte{0x80, '5'},
+			charset:  CharsetWindows1252,
+			expected: "€5",
+		},
+		{
+			name:    "strict UTF-8 rejects Latin-1 bytes",
+			content: []byte{'J', 'o', 's', 0xE9},
+			charset: CharsetUTF8,
+			wantErr: true,
+		},
+		{
+			name:    "unknown charset",
+			content: []byte("abc"),
+			charset: "ebcdic",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := decodeImportText(tt.content, tt.charset)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got result %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateAndMapHeaders_StripsBOM(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{})
+
+	headerMap, err := service.validateAndMapHeaders(
+		[]string{"\ufefffirst_name", "last_name", "email"},
+		[]string{"first_name", "last_name", "email"},
+	)
+	if err != nil {
+		t.Fatalf("Expected BOM-prefixed header to match, got: %v", err)
+	}
+	if headerMap["first_name"] != 0 {
+		t.Errorf("Expected first_name at column 0, got %d", headerMap["first_name"])
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"fmt"
+	"log"
+)
+
+// SetEventPublisher replaces the publisher built from the EVENTS_* settings,
+// e.g. with a Kafka or NATS client. Set it at startup, before serving requests.
+func (s *EmployeeService) SetEventPublisher(publisher events.Publisher) {
+	s.publisher = publisher
+}
+
+// publishEvent sends the event for a write that has already succeeded. By
+// default it is sent in the background and a failure is only logged, so the
+// write is never held up. With EVENTS_FAIL_ON_ERROR it is sent before
+// returning and a failure is returned; the write itself is not undone.
+func (s *EmployeeService) publishEvent(event events.Event) error {
+	if !s.config.Events.FailOnError {
+		go s.sendEvent(event)
+		return nil
+	}
+	if err := s.sendEvent(event); err != nil {
+		return fmt.Errorf("event publish failed: %w", err)
+	}
+	return nil
+}
+
+// sendEvent publishes one event within EVENTS_TIMEOUT, logging a failure
+func (s *EmployeeService) sendEvent(event events.Event) error {
+	ctx := context.Background()
+	if timeout := s.config.Events.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := s.publisher.Publish(ctx, event)
+	if err != nil {
+		log.Printf("Warning: failed to publish %s event %s request_id=%s: %v", event.Type, event.ID, event.Actor.RequestID, err)
+	}
+	return err
+}
+
+// employeeEvent builds an event carrying a copy of the employee, so later
+// changes to the caller's record cannot race with a background publish
+func employeeEvent(eventType events.Type, employee *models.Employee, actor events.Actor) events.Event {
+	event := events.New(eventType, actor)
+	record := *employee
+	event.Employee = &record
+	return event
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingPublisher collects published events and can be told to fail
+type recordingPublisher struct {
+	mu        sync.Mutex
+	events    []events.Event
+	err       error
+	published chan struct{}
+}
+
+func newRecordingPublisher() *recordingPublisher {
+	return &recordingPublisher{published: make(chan struct{}, 16)}
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event events.Event) error {
+	p.mu.Lock()
+	p.events = append(p.events, event)
+	p.mu.Unlock()
+	p.published <- struct{}{}
+	return p.err
+}
+
+// wait blocks until n more events were published, for background publishing
+func (p *recordingPublisher) wait(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-p.published:
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d of %d", i+1, n)
+		}
+	}
+}
+
+func (p *recordingPublisher) recorded() []events.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]events.Event(nil), p.events...)
+}
+
+func TestEmployeeEvents(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+	employees := service.employeeService
+	publisher := newRecordingPublisher()
+	employees.SetEventPublisher(publisher)
+	actor := events.Actor{ID: "alice", IP: "10.0.0.1", RequestID: "req-1"}
+
+	employee := &models.Employee{FirstName: "John", LastName: "Doe", Email: "john@example.com"}
+	if err := employees.CreateEmployee(employee, actor); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, _, err := employees.UpdateEmployee(employee.ID, &models.Employee{City: "Boston"}, DefaultLocale, actor); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := employees.DeleteEmployee(employee.ID, actor); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	publisher.wait(t, 3)
+
+	rows := [][]string{importHeaders, {"Ann", "Lee", "", "", "", "", "", "", "ann@example.com", ""}}
+	service.jobs["job-1"] = &JobResult{ID: "job-1", Status: JobStatusPending}
+	service.processJobRequest(&JobRequest{
+		JobID:  "job-1",
+		Actor:  actor,
+		Source: fileHeaderSource(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows))),
+		Mode:   ImportModeLive,
+	})
+	publisher.wait(t, 1)
+
+	recorded := publisher.recorded()
+	byType := make(map[events.Type]events.Event)
+	for _, event := range recorded {
+		byType[event.Type] = event
+		if event.Actor != actor || event.ID == "" {
+			t.Errorf("%s: expected the actor and an event ID, got %+v", event.Type, event)
+		}
+	}
+	if len(recorded) != 4 || len(byType) != 4 {
+		t.Fatalf("Expected one event per write, got %+v", recorded)
+	}
+
+	if created := byType[events.EmployeeCreated]; created.Employee == nil || created.Employee.Email != "john@example.com" {
+		t.Errorf("Expected the created record in the event, got %+v", created.Employee)
+	}
+	updated := byType[events.EmployeeUpdated]
+	if updated.Employee == nil || updated.Employee.City != "Boston" || len(updated.Changed) != 1 || updated.Changed[0] != "city" {
+		t.Errorf("Expected the updated record and changed columns, got %+v", updated)
+	}
+	if deleted := byType[events.EmployeeDeleted]; deleted.Employee == nil || deleted.Employee.ID != employee.ID {
+		t.Errorf("Expected the deleted record in the event, got %+v", deleted.Employee)
+	}
+	if imported := byType[events.EmployeesImported]; imported.Import == nil || imported.Import.JobID != "job-1" || imported.Import.Inserted != 1 {
+		t.Errorf("Expected an import summary, got %+v", imported.Import)
+	}
+	if repo.Count() != 1 {
+		t.Errorf("Expected only the imported employee left, got %d", repo.Count())
+	}
+}
+
+func TestEmployeeEvents_PublishFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		failOnError bool
+	}{
+		{"logged by default", false},
+		{"surfaced with EVENTS_FAIL_ON_ERROR", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestExcelService(&config.Config{Events: config.EventsConfig{FailOnError: tt.failOnError}})
+			publisher := newRecordingPublisher()
+			publisher.err = errors.New("broker unreachable")
+			service.employeeService.SetEventPublisher(publisher)
+
+			err := service.employeeService.CreateEmployee(&models.Employee{FirstName: "John", LastName: "Doe", Email: "john@example.com"}, events.Actor{})
+			publisher.wait(t, 1)
+			if tt.failOnError && (err == nil || !strings.Contains(err.Error(), "broker unreachable")) {
+				t.Errorf("Expected the publish failure to be returned, got %v", err)
+			}
+			if !tt.failOnError && err != nil {
+				t.Errorf("Expected the publish failure to be logged only, got %v", err)
+			}
+			if repo.Count() != 1 {
+				t.Errorf("Expected the employee stored either way, got %d", repo.Count())
+			}
+		})
+	}
+}
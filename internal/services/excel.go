@@ -3,13 +3,18 @@ package services
 import (
 	"bytes"
 	"employee-management/internal/config"
+	"employee-management/internal/events"
 	"employee-management/internal/models"
+	"employee-management/internal/pii"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"mime/multipart"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,11 +36,46 @@ type JobResult struct {
 	ID        string                      `json:"id"`
 	Status    JobStatus                   `json:"status"`
 	Result    *models.ExcelUploadResponse `json:"result,omitempty"`
+	Backup    *BackupResult               `json:"backup,omitempty"`   // Set for backup jobs instead of Result
+	Progress  int                         `json:"progress,omitempty"` // Rows processed so far by a running backup
 	Error     string                      `json:"error,omitempty"`
+	RequestID string                      `json:"request_id,omitempty"` // ID of the upload request that started the job
 	CreatedAt time.Time                   `json:"created_at"`
 	UpdatedAt time.Time                   `json:"updated_at"`
+
+	failedRows []failedRow // Invalid rows, served as an errors spreadsheet
+}
+
+// employeeColumns lists the spreadsheet columns used for import and export, in export order
+var employeeColumns = models.EmployeeColumnNames()
+
+// resolveRequiredColumns returns the headers every import file and row must
+// provide, from IMPORT_REQUIRED_HEADERS or else the field definitions
+func resolveRequiredColumns(cfg *config.Config) []string {
+	columns, err := models.ParseRequiredColumns(cfg.Import.RequiredHeaders)
+	if err != nil {
+		log.Printf("Warning: invalid IMPORT_REQUIRED_HEADERS %q (%v), using the default required headers", cfg.Import.RequiredHeaders, err)
+		return models.RequiredEmployeeColumns()
+	}
+	return columns
 }
 
+// Policies for rows whose required fields are all blank
+const (
+	BlankRowsError = "error"
+	BlankRowsSkip  = "skip"
+)
+
+// Policies for a template column that appears more than once in the header row
+const (
+	DuplicateHeadersError     = "error"
+	DuplicateHeadersFirstWins = "first-wins"
+	DuplicateHeadersLastWins  = "last-wins"
+)
+
+// defaultMaxValidationErrors applies when MAX_VALIDATION_ERRORS is not positive
+const defaultMaxValidationErrors = 5000
+
 // ExcelService handles Excel file processing
 type ExcelService struct {
 	employeeService *EmployeeService
@@ -43,15 +83,68 @@ type ExcelService struct {
 	mu              sync.RWMutex
 	jobs            map[string]*JobResult
 
+	// importSlots caps how many imports use a database connection at once so
+	// large imports leave pool headroom for API requests; nil means no cap
+	importSlots chan struct{}
+
+	// requiredColumns lists the headers every import file and row must provide
+	requiredColumns []string
+
+	// splitRules derive several fields from one combined import column
+	splitRules []splitRule
+
+	// transforms reshape each parsed row before validation
+	transforms []ImportTransform
+
+	// statusActions maps values of the status column to what they do
+	statusActions map[string]StatusAction
+
+	// uploads holds chunked uploads until they are complete
+	uploads *chunkedUploads
+
+	// uploadGate limits the imports each client IP has in flight
+	uploadGate *uploadGate
+
+	// memoryGuard refuses new uploads while memory use is too high
+	memoryGuard *memoryGuard
+
+	// backupRunning allows one on-demand backup at a time
+	backupRunning atomic.Bool
+
 	// Worker pool for concurrent job processing
 	jobQueue   chan *JobRequest
 	workerPool chan chan *JobRequest
 	maxWorkers int
-	quit       chan bool
+	quit       chan bool // Closed by Stop; ends the dispatcher and background tickers
+	stopOnce   sync.Once
+	// workerWait is how long dispatch waits for a free worker before failing a job
+	workerWait time.Duration
 } // JobRequest represents a job to be processed
 type JobRequest struct {
-	JobID string
-	File  *multipart.FileHeader
+	JobID  string
+	Actor  events.Actor // Who uploaded the file; Actor.IP is the key for MAX_UPLOADS_PER_IP
+	Source excelSource
+	Mode   ImportMode
+}
+
+// excelSource is a file the import pipeline can read: a multipart upload or a
+// reassembled chunked upload on disk
+type excelSource struct {
+	Filename string
+	Size     int64
+	Open     func() (io.ReadCloser, error)
+	Cleanup  func() // Optional; releases the file once the job has finished
+}
+
+// fileHeaderSource adapts a multipart upload to an excelSource
+func fileHeaderSource(file *multipart.FileHeader) excelSource {
+	return excelSource{
+		Filename: file.Filename,
+		Size:     file.Size,
+		Open: func() (io.ReadCloser, error) {
+			return file.Open()
+		},
+	}
 }
 
 // Worker represents a worker that processes jobs
@@ -82,15 +175,48 @@ func NewExcelService(employeeService *EmployeeService, cfg *config.Config) *Exce
 		workerPool:      make(chan chan *JobRequest, maxWorkers),
 		maxWorkers:      maxWorkers,
 		quit:            make(chan bool),
+		workerWait:      defaultWorkerWait,
+		importSlots:     newImportSlots(cfg),
+		requiredColumns: resolveRequiredColumns(cfg),
+		splitRules:      resolveSplitRules(cfg.Import.SplitRules),
+		transforms:      resolveImportTransforms(cfg),
+		statusActions:   resolveStatusActions(cfg.Import.StatusActions),
+		uploads:         newChunkedUploads(cfg.Import.ChunkedUploadDir, cfg.Import.ChunkedUploadTTL),
+		uploadGate:      newUploadGate(cfg.Import.MaxUploadsPerIP),
+		memoryGuard:     newMemoryGuard(cfg.Import.MemoryLimitMB),
 	}
 
-	log.Printf("Excel service: %d workers, queue size %d", maxWorkers, queueSize)
+	log.Printf("Excel service: %d workers, queue size %d, import DB connection budget %d",
+		maxWorkers, queueSize, cfg.ImportConnectionBudget())
 
 	// Start worker pool
 	service.startWorkerPool()
+	service.startJobCleaner(cfg.Import.JobCleanupInterval)
+	service.memoryGuard.start(cfg.Import.MemoryCheckInterval, service.quit)
 
 	return service
-} // startWorkerPool initializes and starts the worker pool
+}
+
+// newImportSlots creates the semaphore for the configured import connection budget
+func newImportSlots(cfg *config.Config) chan struct{} {
+	budget := cfg.ImportConnectionBudget()
+	if budget <= 0 {
+		return nil
+	}
+	return make(chan struct{}, budget)
+}
+
+// withImportConnection runs fn while holding one slot of the import connection
+// budget, waiting for a slot if all are in use
+func (s *ExcelService) withImportConnection(fn func()) {
+	if s.importSlots != nil {
+		s.importSlots <- struct{}{}
+		defer func() { <-s.importSlots }()
+	}
+	fn()
+}
+
+// startWorkerPool initializes and starts the worker pool
 func (s *ExcelService) startWorkerPool() {
 	// Create workers
 	for i := 0; i < s.maxWorkers; i++ {
@@ -108,6 +234,16 @@ func (s *ExcelService) startWorkerPool() {
 	go s.dispatch()
 }
 
+// Stop ends the dispatcher and the background tickers. Jobs already handed to
+// a worker run to completion. Call it once the HTTP server has stopped taking
+// uploads; calling it again does nothing.
+func (s *ExcelService) Stop() {
+	s.stopOnce.Do(func() { close(s.quit) })
+}
+
+// defaultWorkerWait is how long a queued job waits for a free worker
+const defaultWorkerWait = 5 * time.Second
+
 // dispatch manages job distribution to workers
 func (s *ExcelService) dispatch() {
 	for {
@@ -116,8 +252,9 @@ func (s *ExcelService) dispatch() {
 			select {
 			case jobQueue := <-s.workerPool:
 				jobQueue <- job
-			case <-time.After(5 * time.Second):
-				log.Printf("Timeout waiting for available worker for job %s", job.JobID)
+			case <-time.After(s.workerWait):
+				log.Printf("Timeout waiting for available worker for job %s request_id=%s", job.JobID, job.Actor.RequestID)
+				s.finishJobRequest(job)
 				s.updateJobStatus(job.JobID, JobStatusFailed, nil, "timeout waiting for available worker")
 			}
 		case <-s.quit:
@@ -136,7 +273,7 @@ func (w *Worker) start() {
 
 			select {
 			case job := <-w.jobQueue:
-				log.Printf("Worker %d processing job %s", w.id, job.JobID)
+				log.Printf("Worker %d processing job %s request_id=%s", w.id, job.JobID, job.Actor.RequestID)
 				w.service.processJobRequest(job)
 			case <-w.quit:
 				log.Printf("Worker %d stopping...", w.id)
@@ -146,29 +283,81 @@ func (w *Worker) start() {
 	}()
 }
 
+// finishJobRequest releases what a queued job holds: the client's upload
+// slot and the source file. Every way a job leaves the queue, processed or
+// abandoned, calls it.
+func (s *ExcelService) finishJobRequest(job *JobRequest) {
+	s.uploadGate.release(job.Actor.IP)
+	if job.Source.Cleanup != nil {
+		job.Source.Cleanup()
+	}
+}
+
 // processJobRequest processes a job request
 func (s *ExcelService) processJobRequest(job *JobRequest) {
+	// The client's upload slot and the source file are freed however the job ends
+	defer s.finishJobRequest(job)
+
 	// Update job status to running
 	s.updateJobStatus(job.JobID, JobStatusRunning, nil, "")
 
 	// Process the Excel file
-	result, err := s.ProcessExcelFile(job.File)
+	result, failedRows, err := s.processExcelSource(job.Source, job.Mode)
 
 	if err != nil {
+		log.Printf("Job %s failed request_id=%s: %s", job.JobID, job.Actor.RequestID, pii.RedactError(err))
 		s.updateJobStatus(job.JobID, JobStatusFailed, nil, err.Error())
 		return
 	}
 
-	s.updateJobStatus(job.JobID, JobStatusCompleted, result, "")
+	if importChangedEmployees(result) {
+		event := events.New(events.EmployeesImported, job.Actor)
+		event.Import = &events.ImportSummary{JobID: job.JobID, Inserted: result.InsertedRecords, Skipped: result.SkippedRecords, Removed: result.RemovedRecords}
+		if actions := result.Actions; actions != nil {
+			event.Import.Updated, event.Import.Deleted = actions.Updated, actions.Deleted
+		}
+		if err := s.employeeService.publishEvent(event); err != nil {
+			// The rows are in; the job still completes, flagged for the caller
+			result.Warnings = append(result.Warnings, "employees were imported but "+err.Error())
+		}
+	}
+
+	log.Printf("Job %s completed request_id=%s", job.JobID, job.Actor.RequestID)
+	s.completeJob(job.JobID, result, failedRows)
+}
+
+// importChangedEmployees reports whether an import wrote any employee
+func importChangedEmployees(result *models.ExcelUploadResponse) bool {
+	if actions := result.Actions; actions != nil && actions.Updated+actions.Deleted > 0 {
+		return true
+	}
+	return result.InsertedRecords > 0 || result.RemovedRecords > 0
 }
 
-// StartAsyncExcelProcessing starts async processing of an Excel file
-func (s *ExcelService) StartAsyncExcelProcessing(file *multipart.FileHeader) (string, error) {
+// StartAsyncExcelProcessing starts async processing of an Excel file.
+// actor.RequestID ties the job's log lines back to the upload request;
+// actor.IP is the key for MAX_UPLOADS_PER_IP.
+func (s *ExcelService) StartAsyncExcelProcessing(file *multipart.FileHeader, mode ImportMode, actor events.Actor) (string, error) {
 	// Validate file first
-	if err := s.validateExcelFile(file); err != nil {
+	if err := s.validateImportUpload(file.Filename, file.Size); err != nil {
 		return "", fmt.Errorf("file validation failed: %w", err)
 	}
 
+	return s.enqueueExcelJob(fileHeaderSource(file), mode, actor)
+}
+
+// enqueueExcelJob records a pending job for source and hands it to the worker
+// pool, which cleans the source up once the job ends. A source that cannot be
+// queued is left to the caller.
+func (s *ExcelService) enqueueExcelJob(source excelSource, mode ImportMode, actor events.Actor) (string, error) {
+	if err := s.CheckMemory(); err != nil {
+		return "", err
+	}
+	if !s.uploadGate.acquire(actor.IP) {
+		return "", ErrTooManyUploads
+	}
+	s.expireJobs()
+
 	// Generate job ID
 	jobID := uuid.New().String()
 
@@ -176,6 +365,7 @@ func (s *ExcelService) StartAsyncExcelProcessing(file *multipart.FileHeader) (st
 	job := &JobResult{
 		ID:        jobID,
 		Status:    JobStatusPending,
+		RequestID: actor.RequestID,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -186,8 +376,10 @@ func (s *ExcelService) StartAsyncExcelProcessing(file *multipart.FileHeader) (st
 
 	// Queue job for processing by worker pool
 	jobRequest := &JobRequest{
-		JobID: jobID,
-		File:  file,
+		JobID:  jobID,
+		Actor:  actor,
+		Source: source,
+		Mode:   mode,
 	}
 
 	select {
@@ -195,6 +387,7 @@ func (s *ExcelService) StartAsyncExcelProcessing(file *multipart.FileHeader) (st
 		// Job queued successfully
 	default:
 		// Queue is full
+		s.uploadGate.release(actor.IP)
 		s.updateJobStatus(jobID, JobStatusFailed, nil, "job queue is full, please try again later")
 		return "", fmt.Errorf("job queue is full, please try again later")
 	}
@@ -228,48 +421,152 @@ func (s *ExcelService) updateJobStatus(jobID string, status JobStatus, result *m
 	}
 }
 
+// completeJob records a finished import along with its invalid rows
+func (s *ExcelService) completeJob(jobID string, result *models.ExcelUploadResponse, failedRows []failedRow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, exists := s.jobs[jobID]; exists {
+		job.Status = JobStatusCompleted
+		job.Result = result
+		job.Error = ""
+		job.failedRows = failedRows
+		job.UpdatedAt = time.Now()
+	}
+}
+
+// expireJobs forgets finished jobs, and the invalid rows kept for them, once
+// they are older than the configured job TTL, then evicts the oldest finished
+// jobs beyond JOB_MAX_RETAINED. Pending and running jobs are never removed.
+func (s *ExcelService) expireJobs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ttl := s.config.Import.JobTTL; ttl > 0 {
+		cutoff := time.Now().Add(-ttl)
+		for id, job := range s.jobs {
+			if job.finished() && job.UpdatedAt.Before(cutoff) {
+				delete(s.jobs, id)
+			}
+		}
+	}
+	s.evictExcessJobs()
+}
+
 // ProcessExcelFile processes uploaded Excel file asynchronously
 func (s *ExcelService) ProcessExcelFile(file *multipart.FileHeader) (*models.ExcelUploadResponse, error) {
+	response, _, err := s.processExcelSource(fileHeaderSource(file), ImportModeLive)
+	return response, err
+}
+
+// processExcelSource validates, parses and imports one file, or every Excel
+// file in a ZIP archive as one import. It also returns the rows that failed
+// validation so jobs can offer them for download. Staging imports store the
+// valid rows under a new batch ID instead.
+func (s *ExcelService) processExcelSource(file excelSource, mode ImportMode) (*models.ExcelUploadResponse, []failedRow, error) {
 	// Validate file
-	if err := s.validateExcelFile(file); err != nil {
-		return nil, fmt.Errorf("file validation failed: %w", err)
+	if err := s.validateImportUpload(file.Filename, file.Size); err != nil {
+		return nil, nil, fmt.Errorf("file validation failed: %w", err)
 	}
 
 	// Open the uploaded file
 	src, err := file.Open()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+		return nil, nil, fmt.Errorf("failed to open uploaded file: %w", err)
 	}
 	defer src.Close()
 
 	// Read file content
 	content, err := io.ReadAll(src)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file content: %w", err)
+		return nil, nil, fmt.Errorf("failed to read file content: %w", err)
 	}
 
-	// Parse Excel file
-	employees, validationErrors, err := s.parseExcelContent(content, file.Filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Excel file: %w", err)
+	// Parse Excel file, or each one in an archive
+	var sheet *parsedSheet
+	var files []models.FileImportSummary
+	var archiveWarnings []string
+	if isZipUpload(file.Filename) {
+		sheet, files, archiveWarnings, err = s.parseZipContent(content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to import ZIP archive: %w", err)
+		}
+	} else {
+		sheet, err = s.parseExcelContent(content, file.Filename)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse Excel file: %w", err)
+		}
 	}
+	employees, invalidRows := sheet.Employees, sheet.InvalidRows
 
-	// Prepare response
+	// Prepare response. Invalid rows are counted separately from the detailed
+	// errors, which stop being collected once the cap is reached.
 	response := &models.ExcelUploadResponse{
-		TotalRecords:    len(employees) + len(validationErrors),
-		ValidRecords:    len(employees),
-		InvalidRecords:  len(validationErrors),
+		TotalRecords:    sheet.validRows() + invalidRows,
+		ValidRecords:    sheet.validRows(),
+		InvalidRecords:  invalidRows,
 		InsertedRecords: 0,
 		SkippedRecords:  0,
 		DuplicateEmails: []string{},
+		Truncated:       sheet.Truncated,
+		RowWarnings:     sheet.Warnings,
+		Files:           files,
+		Warnings:        archiveWarnings,
+	}
+	if sheet.Truncated {
+		response.Warnings = append(response.Warnings, fmt.Sprintf(
+			"validation errors truncated after %d; %d invalid rows in total", len(sheet.Errors), invalidRows))
+	}
+
+	// Reject structurally broken files before touching the database
+	if warning, err := s.checkValidRatio(sheet.validRows(), sheet.validRows()+invalidRows); err != nil {
+		return nil, nil, err
+	} else if warning != "" {
+		response.Warnings = append(response.Warnings, warning)
+	}
+
+	if sheet.HasStatus {
+		if mode != ImportModeLive {
+			return nil, nil, fmt.Errorf("files with a %s column can only be imported live, not with mode=%s", StatusColumn, mode)
+		}
+		if err := s.syncEmployees(sheet, response); err != nil {
+			return nil, nil, err
+		}
+		return response, sheet.FailedRows, nil
+	}
+
+	if mode == ImportModeReplace {
+		if err := s.replaceEmployees(employees, invalidRows, response); err != nil {
+			return nil, nil, err
+		}
+		return response, sheet.FailedRows, nil
+	}
+
+	if mode == ImportModeStaging && len(employees) > 0 {
+		batchID, err := s.stageEmployees(employees)
+		if err != nil {
+			return nil, nil, err
+		}
+		response.BatchID = batchID
+		response.Message = fmt.Sprintf("Staged %d records in batch %s for review, Invalid: %d",
+			len(employees), batchID, response.InvalidRecords)
+		return response, sheet.FailedRows, nil
 	}
 
 	// Process valid employees
 	if len(employees) > 0 {
 		// Save valid employees to database with detailed results
-		inserted, skipped, duplicateEmails, err := s.employeeService.repo.CreateEmployeesInBatchWithResult(employees)
+		// The batch insert holds one connection for its whole transaction
+		var (
+			inserted, skipped int
+			duplicateEmails   []string
+			err               error
+		)
+		s.withImportConnection(func() {
+			inserted, skipped, duplicateEmails, err = s.employeeService.repo.CreateEmployeesInBatchWithResult(employees)
+		})
 		if err != nil {
-			log.Printf("Error saving employees to database: %v", err)
+			log.Printf("Error saving employees to database: %s", pii.RedactError(err))
 			response.Message = fmt.Sprintf("Processed %d records, but failed to save to database: %v",
 				response.TotalRecords, err)
 		} else {
@@ -278,8 +575,11 @@ func (s *ExcelService) ProcessExcelFile(file *multipart.FileHeader) (*models.Exc
 			response.SkippedRecords = skipped
 			response.ValidRecords = inserted // Update to show only actually inserted records
 
-			// Include sample duplicate emails (limit to first 10 for readability)
-			maxDuplicatesToShow := 10
+			// Include sample duplicate emails (limited by MAX_DUPLICATES_IN_RESPONSE for readability)
+			maxDuplicatesToShow := s.config.Import.MaxDuplicatesShown
+			if maxDuplicatesToShow <= 0 {
+				maxDuplicatesToShow = 10
+			}
 			if len(duplicateEmails) > maxDuplicatesToShow {
 				response.DuplicateEmails = duplicateEmails[:maxDuplicatesToShow]
 			} else {
@@ -306,7 +606,7 @@ func (s *ExcelService) ProcessExcelFile(file *multipart.FileHeader) (*models.Exc
 					if len(duplicateEmails) < maxShow {
 						maxShow = len(duplicateEmails)
 					}
-					log.Printf("Duplicate emails encountered: %v", duplicateEmails[:maxShow])
+					log.Printf("Duplicate emails encountered: %v", pii.RedactAll(duplicateEmails[:maxShow]))
 					if len(duplicateEmails) > maxShow {
 						log.Printf("... and %d more duplicate emails", len(duplicateEmails)-maxShow)
 					}
@@ -319,73 +619,132 @@ func (s *ExcelService) ProcessExcelFile(file *multipart.FileHeader) (*models.Exc
 
 		// Invalidate cache since we added new data
 		if err := s.employeeService.cache.InvalidateEmployeeListCache(); err != nil {
-			log.Printf("Warning: Failed to invalidate employee list cache after batch insert: %v", err)
+			s.employeeService.invalidationFailed(err, "Failed to invalidate employee list cache after batch insert")
 		}
+		s.employeeService.touchLastModified()
 	} else {
 		response.Message = "No valid employee records found in the Excel file"
 	}
 
-	return response, nil
+	return response, sheet.FailedRows, nil
+}
+
+// checkValidRatio compares the fraction of valid rows against the configured minimum.
+// A very low ratio usually means shifted columns or a wrong template rather than bad data,
+// so depending on config it either fails the import or returns a warning message.
+func (s *ExcelService) checkValidRatio(valid, total int) (string, error) {
+	minRatio := s.config.Import.MinValidRatio
+	if minRatio <= 0 || total == 0 {
+		return "", nil
+	}
+
+	ratio := float64(valid) / float64(total)
+	if ratio >= minRatio {
+		return "", nil
+	}
+
+	message := fmt.Sprintf("only %d of %d rows (%.1f%%) are valid, below the minimum of %.1f%%; the file may be malformed",
+		valid, total, ratio*100, minRatio*100)
+	if s.config.Import.MinValidRatioStrict {
+		return "", fmt.Errorf("import rejected: %s", message)
+	}
+
+	log.Printf("Warning: %s", message)
+	return message, nil
 }
 
 // validateExcelFile validates the uploaded Excel file
 func (s *ExcelService) validateExcelFile(file *multipart.FileHeader) error {
+	return s.validateExcelUpload(file.Filename, file.Size)
+}
+
+// validateExcelUpload checks an upload's declared name and size
+func (s *ExcelService) validateExcelUpload(filename string, size int64) error {
 	// Check file size using config value
 	maxSize := s.config.Server.MaxFileSize
-	if file.Size > maxSize {
-		return fmt.Errorf("file size %d bytes exceeds maximum allowed size %d bytes", file.Size, maxSize)
+	if size > maxSize {
+		return fmt.Errorf("file size %d bytes exceeds maximum allowed size %d bytes", size, maxSize)
 	}
 
 	// Check file extension
-	filename := strings.ToLower(file.Filename)
-	if !strings.HasSuffix(filename, ".xlsx") && !strings.HasSuffix(filename, ".xls") {
-		return fmt.Errorf("invalid file format. Only .xlsx and .xls files are supported")
+	if !isExcelFilename(filename) && !isCSVFilename(filename) {
+		return fmt.Errorf("invalid file format. Only .xlsx, .xls and .csv files are supported")
 	}
 
 	return nil
 }
 
-// parseExcelContent parses Excel file content and returns employees and validation errors
-func (s *ExcelService) parseExcelContent(content []byte, filename string) ([]models.Employee, []models.ValidationError, error) {
-	// Open Excel file from bytes using excelize
-	xlFile, err := excelize.OpenReader(bytes.NewReader(content))
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to open Excel file: %w", err)
-	}
-	defer xlFile.Close()
+// parsedSheet is the result of parsing an import file
+type parsedSheet struct {
+	Employees   []models.Employee        // Rows that passed validation
+	Errors      []models.ValidationError // Detailed errors, at most MAX_VALIDATION_ERRORS
+	InvalidRows int                      // Every row that failed validation, including those past the cap
+	Truncated   bool                     // Some invalid rows were only counted
+	FailedRows  []failedRow              // Original data of the invalid rows whose errors were kept
+	Warnings    []models.ValidationError // Non-blocking problems on accepted rows, at most MAX_VALIDATION_ERRORS
+
+	// A file with a status column routes its rows: Employees holds the
+	// upserts, DeleteEmails the employees to delete
+	HasStatus     bool
+	DeleteEmails  []string
+	StatusSkipped int      // Rows whose status is configured to be skipped
+	Columns       []string // Employee columns the file provides, which upserts write
+}
 
-	// Get the first sheet name
-	sheetName := xlFile.GetSheetName(0)
-	if sheetName == "" {
-		return nil, nil, fmt.Errorf("Excel file has no sheets")
-	}
+// validRows counts the rows that passed validation, whatever their status
+func (p *parsedSheet) validRows() int {
+	return len(p.Employees) + len(p.DeleteEmails) + p.StatusSkipped
+}
 
-	// Get all rows from the first sheet
-	rows, err := xlFile.GetRows(sheetName)
+// failedRow is an invalid import row with its original cells in template order
+type failedRow struct {
+	Row    int
+	Values []string
+	Errors string
+}
+
+// parseExcelContent parses Excel file content into valid employees and the
+// rows that failed validation. At most MAX_VALIDATION_ERRORS errors are
+// collected; further invalid rows are only counted.
+func (s *ExcelService) parseExcelContent(content []byte, filename string) (*parsedSheet, error) {
+	rows, err := s.readSheetRows(content, filename)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read Excel sheet: %w", err)
+		return nil, err
 	}
 
 	if len(rows) <= 1 {
-		return nil, nil, fmt.Errorf("Excel file appears to be empty or has no data rows")
-	}
-
-	var employees []models.Employee
-	var validationErrors []models.ValidationError
-
-	// Define expected headers (as per your Excel structure)
-	expectedHeaders := []string{
-		"first_name", "last_name", "company_name", "address",
-		"city", "county", "postal", "phone", "email", "web",
+		return nil, fmt.Errorf("Excel file appears to be empty or has no data rows")
 	}
 
 	// Read header row (first row)
 	headerRow := rows[0]
 
 	// Validate headers
-	headerMap, err := s.validateAndMapHeaders(headerRow, expectedHeaders)
+	headerMap, err := s.validateAndMapHeaders(headerRow, employeeColumns)
 	if err != nil {
-		return nil, nil, fmt.Errorf("header validation failed: %w", err)
+		return nil, fmt.Errorf("header validation failed: %w", err)
+	}
+
+	_, hasStatus := headerMap[StatusColumn]
+	sheet := &parsedSheet{HasStatus: hasStatus, Columns: s.providedColumns(headerMap)}
+
+	// Keep detailed errors up to the cap so a pathological file cannot grow them without bound
+	maxErrors := s.maxValidationErrors()
+	collect := func(row []string, rowNumber int, rowErrors ...models.ValidationError) {
+		sheet.InvalidRows++
+		room := maxErrors - len(sheet.Errors)
+		if room > 0 {
+			sheet.FailedRows = append(sheet.FailedRows, failedRow{
+				Row:    rowNumber,
+				Values: templateValues(row, headerMap),
+				Errors: rowErrorText(rowErrors, rowNumber),
+			})
+		}
+		if len(rowErrors) > room {
+			rowErrors = rowErrors[:room]
+			sheet.Truncated = true
+		}
+		sheet.Errors = append(sheet.Errors, rowErrors...)
 	}
 
 	// Process data rows
@@ -397,37 +756,182 @@ func (s *ExcelService) parseExcelContent(content []byte, filename string) ([]mod
 			continue
 		}
 
+		// A status column decides what happens to the row before it is validated
+		if sheet.HasStatus {
+			action, statusError := s.rowStatusAction(row, headerMap, rowIndex+1)
+			if statusError != nil {
+				collect(row, rowIndex+1, *statusError)
+				continue
+			}
+			switch action {
+			case StatusSkip:
+				sheet.StatusSkipped++
+				continue
+			case StatusDelete:
+				email, emailError := deleteRowEmail(row, headerMap, rowIndex+1)
+				if emailError != nil {
+					collect(row, rowIndex+1, *emailError)
+				} else {
+					sheet.DeleteEmails = append(sheet.DeleteEmails, email)
+				}
+				continue
+			}
+		}
+
+		// Rows whose required cells are all blank are either dropped or reported
+		// once, instead of producing a "required" error for every field
+		if s.requiredCellsBlank(row, headerMap) {
+			if s.config.Import.BlankRequiredRows == BlankRowsSkip {
+				continue
+			}
+			collect(row, rowIndex+1, models.ValidationError{
+				Field:   fmt.Sprintf("Row %d", rowIndex+1),
+				Message: fmt.Sprintf("Row has no values for required fields (%s)", strings.Join(s.requiredColumns, ", ")),
+			})
+			continue
+		}
+
 		// Parse employee from row
-		employee, rowErrors := s.parseEmployeeFromRow(row, headerMap, rowIndex+1)
+		employee, rowErrors, rowWarnings := s.parseEmployeeFromRow(row, headerMap, rowIndex+1)
 		if len(rowErrors) > 0 {
-			validationErrors = append(validationErrors, rowErrors...)
+			collect(row, rowIndex+1, rowErrors...)
 		} else if employee != nil {
-			employees = append(employees, *employee)
+			sheet.Employees = append(sheet.Employees, *employee)
+			if room := maxErrors - len(sheet.Warnings); room > 0 {
+				sheet.Warnings = append(sheet.Warnings, rowWarnings[:min(room, len(rowWarnings))]...)
+			}
 		}
 	}
 
-	log.Printf("Parsed Excel file '%s': %d total rows, %d valid employees, %d validation errors",
-		filename, len(rows)-1, len(employees), len(validationErrors))
+	log.Printf("Parsed Excel file '%s': %d total rows, %d valid employees, %d invalid rows, %d validation errors (truncated: %t)",
+		filename, len(rows)-1, len(sheet.Employees), sheet.InvalidRows, len(sheet.Errors), sheet.Truncated)
 
-	return employees, validationErrors, nil
+	return sheet, nil
+}
+
+// readSheetRows returns the rows of an uploaded file: a CSV file's records,
+// or the first sheet of an Excel workbook
+func (s *ExcelService) readSheetRows(content []byte, filename string) ([][]string, error) {
+	if isCSVFilename(filename) {
+		return s.readCSVRows(content)
+	}
+	return readExcelRows(content)
+}
+
+// readExcelRows returns all rows of the first sheet of an Excel workbook
+func readExcelRows(content []byte) ([][]string, error) {
+	// Open Excel file from bytes using excelize
+	xlFile, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer xlFile.Close()
+
+	// Get the first sheet name
+	sheetName := xlFile.GetSheetName(0)
+	if sheetName == "" {
+		return nil, fmt.Errorf("Excel file has no sheets")
+	}
+
+	// Get all rows from the first sheet
+	rows, err := xlFile.GetRows(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Excel sheet: %w", err)
+	}
+	return rows, nil
+}
+
+// maxValidationErrors is the cap on detailed errors kept per import
+func (s *ExcelService) maxValidationErrors() int {
+	if s.config.Import.MaxValidationErrors <= 0 {
+		return defaultMaxValidationErrors
+	}
+	return s.config.Import.MaxValidationErrors
+}
+
+// coordinateColumns are the optional location columns an import may carry
+var coordinateColumns = []string{"latitude", "longitude"}
+
+// providedColumns returns the employee columns a file provides, as a header
+// of their own or through a split rule
+func (s *ExcelService) providedColumns(headerMap map[string]int) []string {
+	var columns []string
+	for _, group := range [][]string{employeeColumns, coordinateColumns} {
+		for _, column := range group {
+			_, present := headerMap[column]
+			if _, split := s.splitSourceFor(column, headerMap); present || split {
+				columns = append(columns, column)
+			}
+		}
+	}
+	return columns
+}
+
+// templateValues returns a row's cells in template column order, so rows
+// from files with reordered or extra columns line up with the import template
+func templateValues(row []string, headerMap map[string]int) []string {
+	values := make([]string, len(employeeColumns))
+	for i, column := range employeeColumns {
+		if index, ok := headerMap[column]; ok && index < len(row) {
+			values[i] = row[index]
+		}
+	}
+	return values
+}
+
+// rowErrorText joins a row's validation errors into one line, e.g.
+// "FirstName: FirstName is required; Email: Invalid email format"
+func rowErrorText(rowErrors []models.ValidationError, rowNumber int) string {
+	prefix := fmt.Sprintf("Row %d - ", rowNumber)
+	messages := make([]string, len(rowErrors))
+	for i, rowError := range rowErrors {
+		field := strings.TrimPrefix(rowError.Field, prefix)
+		if field == rowError.Field {
+			// Row-level errors have no field to name
+			messages[i] = rowError.Message
+			continue
+		}
+		messages[i] = field + ": " + rowError.Message
+	}
+	return strings.Join(messages, "; ")
 }
 
 // validateAndMapHeaders validates Excel headers and creates a mapping
 func (s *ExcelService) validateAndMapHeaders(headerRow []string, expectedHeaders []string) (map[string]int, error) {
 	headerMap := make(map[string]int)
 
+	expected := make(map[string]bool, len(expectedHeaders))
+	for _, header := range expectedHeaders {
+		expected[header] = true
+	}
+	policy := s.config.Import.DuplicateHeaders
+
 	// Convert headers to lowercase and map to column indices
+	var duplicates []string
 	for i, header := range headerRow {
-		cleanHeader := strings.TrimSpace(strings.ToLower(header))
+		cleanHeader := strings.TrimSpace(strings.ToLower(stripBOM(header)))
+		if first, seen := headerMap[cleanHeader]; seen && expected[cleanHeader] {
+			switch policy {
+			case DuplicateHeadersFirstWins:
+				continue
+			case DuplicateHeadersLastWins:
+			default:
+				duplicates = append(duplicates, fmt.Sprintf("%s (columns %d and %d)", cleanHeader, first+1, i+1))
+			}
+		}
 		headerMap[cleanHeader] = i
 	}
 
+	if len(duplicates) > 0 {
+		return nil, fmt.Errorf("duplicate headers found: %s", strings.Join(duplicates, ", "))
+	}
+
 	// Check for required headers
 	missingHeaders := []string{}
 	for _, expectedHeader := range expectedHeaders {
 		if _, found := headerMap[expectedHeader]; !found {
-			// Check if it's a required field
-			if expectedHeader == "first_name" || expectedHeader == "last_name" || expectedHeader == "email" {
+			// Check if it's a required field not provided by a split rule either
+			if _, split := s.splitSourceFor(expectedHeader, headerMap); s.isRequiredColumn(expectedHeader) && !split {
 				missingHeaders = append(missingHeaders, expectedHeader)
 			}
 		}
@@ -440,16 +944,25 @@ func (s *ExcelService) validateAndMapHeaders(headerRow []string, expectedHeaders
 	return headerMap, nil
 }
 
-// parseEmployeeFromRow parses an employee from an Excel row
-func (s *ExcelService) parseEmployeeFromRow(row []string, headerMap map[string]int, rowNumber int) (*models.Employee, []models.ValidationError) {
-	var validationErrors []models.ValidationError
+// parseEmployeeFromRow parses an employee from an Excel row. Warnings from
+// rules configured as non-blocking are returned alongside a valid employee.
+func (s *ExcelService) parseEmployeeFromRow(row []string, headerMap map[string]int, rowNumber int) (*models.Employee, []models.ValidationError, []models.ValidationError) {
+	// Combined columns are split first; a filled-in column of its own wins
+	derived, validationErrors := s.splitRow(row, headerMap, rowNumber)
 
-	// Helper function to get cell value safely
+	// Helper function to get cell value safely. Values are trimmed unless the
+	// field preserves whitespace; a blank cell is empty either way.
 	getCellValue := func(columnName string) string {
 		if colIndex, exists := headerMap[columnName]; exists && colIndex < len(row) {
-			return strings.TrimSpace(row[colIndex])
+			value := stripBOM(row[colIndex])
+			if trimmed := strings.TrimSpace(value); trimmed != "" {
+				if models.PreservesWhitespace(columnName) {
+					return value
+				}
+				return trimmed
+			}
 		}
-		return ""
+		return derived[columnName]
 	}
 
 	// Create employee
@@ -466,24 +979,82 @@ func (s *ExcelService) parseEmployeeFromRow(row []string, headerMap map[string]i
 		Web:         getCellValue("web"),
 	}
 
-	// Validate employee using the service validator
-	fieldErrors := s.employeeService.ValidateEmployeeData(employee)
-	for _, fieldError := range fieldErrors {
-		validationErrors = append(validationErrors, models.ValidationError{
-			Field:   fmt.Sprintf("Row %d - %s", rowNumber, fieldError.Field),
-			Message: fieldError.Message,
-		})
-	}
+	// Coordinates are optional columns; a value that is not a number is a row error
+	var coordinateErrors []models.ValidationError
+	employee.Latitude, coordinateErrors = parseCoordinate(getCellValue("latitude"), "latitude", rowNumber)
+	validationErrors = append(validationErrors, coordinateErrors...)
+	employee.Longitude, coordinateErrors = parseCoordinate(getCellValue("longitude"), "longitude", rowNumber)
+	validationErrors = append(validationErrors, coordinateErrors...)
+
+	// Apply the same input corrections as the API and the import transforms, then validate
+	s.employeeService.NormalizeImportedEmployee(employee)
+	s.applyTransforms(employee)
+	fieldErrors, fieldWarnings := s.employeeService.ValidateEmployeeData(employee)
+	validationErrors = append(validationErrors, rowProblems(fieldErrors, rowNumber)...)
 
 	// Note: We don't check for duplicate emails here during parsing.
 	// The database layer will handle duplicates during batch insert,
 	// which is more efficient and provides proper skip behavior.
 
 	if len(validationErrors) > 0 {
-		return nil, validationErrors
+		return nil, validationErrors, nil
+	}
+
+	return employee, nil, rowProblems(fieldWarnings, rowNumber)
+}
+
+// parseCoordinate reads an optional latitude or longitude cell; blank means
+// unknown. The range is checked later by the struct validation.
+func parseCoordinate(value, column string, rowNumber int) (*float64, []models.ValidationError) {
+	if value == "" {
+		return nil, nil
+	}
+	coordinate, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || math.IsNaN(coordinate) || math.IsInf(coordinate, 0) {
+		return nil, []models.ValidationError{{
+			Field:   fmt.Sprintf("Row %d - %s", rowNumber, column),
+			Message: fmt.Sprintf("%s must be a number, got %q", column, value),
+		}}
+	}
+	return &coordinate, nil
+}
+
+// rowProblems prefixes each problem's field with its row number
+func rowProblems(problems []models.ValidationError, rowNumber int) []models.ValidationError {
+	var prefixed []models.ValidationError
+	for _, problem := range problems {
+		prefixed = append(prefixed, models.ValidationError{
+			Field:   fmt.Sprintf("Row %d - %s", rowNumber, problem.Field),
+			Message: problem.Message,
+		})
 	}
+	return prefixed
+}
+
+// isRequiredColumn reports whether a column must be present and filled in
+func (s *ExcelService) isRequiredColumn(column string) bool {
+	for _, required := range s.requiredColumns {
+		if column == required {
+			return true
+		}
+	}
+	return false
+}
 
-	return employee, nil
+// requiredCellsBlank checks if every required cell in a row is empty or whitespace
+func (s *ExcelService) requiredCellsBlank(row []string, headerMap map[string]int) bool {
+	for _, column := range s.requiredColumns {
+		colIndex, exists := headerMap[column]
+		if !exists {
+			colIndex, exists = s.splitSourceFor(column, headerMap)
+		}
+		if exists && colIndex < len(row) {
+			if strings.TrimSpace(stripBOM(row[colIndex])) != "" {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 // isRowEmpty checks if a row is empty
@@ -515,22 +1086,9 @@ func (s *ExcelService) ValidateExcelStructure(file *multipart.FileHeader) (*mode
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	xlFile, err := excelize.OpenReader(bytes.NewReader(content))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Excel file: %w", err)
-	}
-	defer xlFile.Close()
-
-	// Get the first sheet name
-	sheetName := xlFile.GetSheetName(0)
-	if sheetName == "" {
-		return nil, fmt.Errorf("Excel file has no sheets")
-	}
-
-	// Get all rows from the first sheet
-	rows, err := xlFile.GetRows(sheetName)
+	rows, err := s.readSheetRows(content, file.Filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Excel sheet: %w", err)
+		return nil, err
 	}
 
 	if len(rows) <= 1 {
@@ -542,12 +1100,8 @@ func (s *ExcelService) ValidateExcelStructure(file *multipart.FileHeader) (*mode
 
 	// Check headers only
 	headerRow := rows[0]
-	expectedHeaders := []string{
-		"first_name", "last_name", "company_name", "address",
-		"city", "county", "postal", "phone", "email", "web",
-	}
 
-	_, err = s.validateAndMapHeaders(headerRow, expectedHeaders)
+	_, err = s.validateAndMapHeaders(headerRow, employeeColumns)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,532 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"employee-management/internal/pii"
+	"employee-management/internal/testutil"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var importHeaders = []string{
+	"first_name", "last_name", "company_name", "address",
+	"city", "county", "postal", "phone", "email", "web",
+}
+
+// buildWorkbook creates an in-memory xlsx file with the given rows
+func buildWorkbook(t *testing.T, rows [][]string) []byte {
+	t.Helper()
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			t.Fatalf("failed to build cell name: %v", err)
+		}
+		values := make([]interface{}, len(row))
+		for j, value := range row {
+			values[j] = value
+		}
+		if err := f.SetSheetRow(sheet, cell, &values); err != nil {
+			t.Fatalf("failed to write row: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatalf("failed to write workbook: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// newFileHeader wraps content in a multipart.FileHeader like an upload would produce
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := req.ParseMultipartForm(32 << 20); err != nil {
+		t.Fatalf("failed to parse multipart form: %v", err)
+	}
+	return req.MultipartForm.File["file"][0]
+}
+
+// newTestExcelService builds an ExcelService without starting the worker pool
+func newTestExcelService(cfg *config.Config) (*ExcelService, *testutil.FakeRepository) {
+	if cfg.Server.MaxFileSize == 0 {
+		cfg.Server.MaxFileSize = 10 * 1024 * 1024
+	}
+	repo := testutil.NewFakeRepository()
+	employeeService := NewEmployeeService(repo, testutil.NewFakeCache(), cfg)
+	return &ExcelService{
+		employeeService: employeeService,
+		config:          cfg,
+		jobs:            make(map[string]*JobResult),
+		quit:            make(chan bool),
+		requiredColumns: resolveRequiredColumns(cfg),
+		splitRules:      resolveSplitRules(cfg.Import.SplitRules),
+		transforms:      resolveImportTransforms(cfg),
+		statusActions:   resolveStatusActions(cfg.Import.StatusActions),
+		uploadGate:      newUploadGate(cfg.Import.MaxUploadsPerIP),
+		memoryGuard:     newMemoryGuard(cfg.Import.MemoryLimitMB),
+	}, repo
+}
+
+// mostlyInvalidRows returns a file body where only one of four rows is valid
+func mostlyInvalidRows() [][]string {
+	return [][]string{
+		importHeaders,
+		{"John", "Doe", "Acme", "", "", "", "", "", "john@example.com", ""},
+		{"J", "", "", "", "", "", "", "", "not-an-email", ""},
+		{"", "", "Acme", "", "", "", "", "", "", ""},
+		{"X", "Y", "", "", "", "", "", "", "bad", ""},
+	}
+}
+
+func TestProcessExcelFile_MinValidRatio(t *testing.T) {
+	t.Run("strict mode rejects file before inserting", func(t *testing.T) {
+		service, repo := newTestExcelService(&config.Config{
+			Import: config.ImportConfig{MinValidRatio: 0.5, MinValidRatioStrict: true},
+		})
+		file := newFileHeader(t, "employees.xlsx", buildWorkbook(t, mostlyInvalidRows()))
+
+		_, err := service.ProcessExcelFile(file)
+		if err == nil {
+			t.Fatal("Expected import to be rejected, got no error")
+		}
+		if !strings.Contains(err.Error(), "1 of 4 rows") {
+			t.Errorf("Expected descriptive ratio error, got: %v", err)
+		}
+		if repo.Count() != 0 {
+			t.Errorf("Expected no inserts, got %d", repo.Count())
+		}
+	})
+
+	t.Run("warning mode imports and reports warning", func(t *testing.T) {
+		service, repo := newTestExcelService(&config.Config{
+			Import: config.ImportConfig{MinValidRatio: 0.5, MinValidRatioStrict: false},
+		})
+		file := newFileHeader(t, "employees.xlsx", buildWorkbook(t, mostlyInvalidRows()))
+
+		response, err := service.ProcessExcelFile(file)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(response.Warnings) != 1 {
+			t.Errorf("Expected 1 warning, got %v", response.Warnings)
+		}
+		if repo.Count() != 1 {
+			t.Errorf("Expected 1 insert, got %d", repo.Count())
+		}
+	})
+
+	t.Run("ratio above threshold passes", func(t *testing.T) {
+		service, _ := newTestExcelService(&config.Config{
+			Import: config.ImportConfig{MinValidRatio: 0.2, MinValidRatioStrict: true},
+		})
+		file := newFileHeader(t, "employees.xlsx", buildWorkbook(t, mostlyInvalidRows()))
+
+		response, err := service.ProcessExcelFile(file)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(response.Warnings) != 0 {
+			t.Errorf("Expected no warnings, got %v", response.Warnings)
+		}
+	})
+}
+
+func TestParseExcelContent_WhitespaceOnlyRequiredFields(t *testing.T) {
+	rows := [][]string{
+		importHeaders,
+		{"John", "Doe", "Acme", "", "", "", "", "", "john@example.com", ""},
+		{"   ", " ", "Acme", "1 Main St", "", "", "", "", "\t", ""},
+	}
+
+	t.Run("error mode reports a single clear error", func(t *testing.T) {
+		service, _ := newTestExcelService(&config.Config{
+			Import: config.ImportConfig{BlankRequiredRows: BlankRowsError},
+		})
+
+		sheet, err := service.parseExcelContent(buildWorkbook(t, rows), "test.xlsx")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		employees, validationErrors, invalidRows := sheet.Employees, sheet.Errors, sheet.InvalidRows
+		if len(employees) != 1 || invalidRows != 1 {
+			t.Errorf("Expected 1 valid and 1 invalid row, got %d and %d", len(employees), invalidRows)
+		}
+		if len(validationErrors) != 1 || !strings.Contains(validationErrors[0].Message, "no values for required fields") {
+			t.Errorf("Expected one blank-row error, got %+v", validationErrors)
+		}
+	})
+
+	t.Run("skip mode drops the row", func(t *testing.T) {
+		service, _ := newTestExcelService(&config.Config{
+			Import: config.ImportConfig{BlankRequiredRows: BlankRowsSkip},
+		})
+
+		sheet, err := service.parseExcelContent(buildWorkbook(t, rows), "test.xlsx")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		employees, validationErrors, invalidRows := sheet.Employees, sheet.Errors, sheet.InvalidRows
+		if len(employees) != 1 || invalidRows != 0 || len(validationErrors) != 0 {
+			t.Errorf("Expected blank row to be skipped, got %d valid, %d invalid, errors %+v",
+				len(employees), invalidRows, validationErrors)
+		}
+	})
+}
+
+// slowBatchRepository records how many batch inserts run at the same time
+type slowBatchRepository struct {
+	*testutil.FakeRepository
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (r *slowBatchRepository) CreateEmployeesInBatchWithResult(employees []models.Employee) (int, int, []string, error) {
+	current := r.inFlight.Add(1)
+	defer r.inFlight.Add(-1)
+	for {
+		observed := r.maxInFlight.Load()
+		if current <= observed || r.maxInFlight.CompareAndSwap(observed, current) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return len(employees), 0, nil, nil
+}
+
+func TestValidateAndMapHeaders_CustomRequiredSet(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{
+		Import: config.ImportConfig{RequiredHeaders: "first_name,last_name,email,company_name"},
+	})
+
+	_, err := service.validateAndMapHeaders([]string{"first_name", "last_name", "email"}, employeeColumns)
+	if err == nil || !strings.Contains(err.Error(), "company_name") {
+		t.Errorf("Expected company_name to be required, got %v", err)
+	}
+	if _, err := service.validateAndMapHeaders([]string{"email", "company_name", "last_name", "first_name"}, employeeColumns); err != nil {
+		t.Errorf("Expected headers to satisfy the custom set, got %v", err)
+	}
+
+	// An unknown name falls back to the field definitions
+	service, _ = newTestExcelService(&config.Config{
+		Import: config.ImportConfig{RequiredHeaders: "first_name,salary"},
+	})
+	if strings.Join(service.requiredColumns, ",") != "first_name,last_name,email" {
+		t.Errorf("Expected default required headers, got %v", service.requiredColumns)
+	}
+}
+
+func TestValidateAndMapHeaders_DuplicateHeaders(t *testing.T) {
+	headers := []string{"first_name", "last_name", "Email", "notes", "notes", " email "}
+
+	tests := []struct {
+		policy     string
+		wantErr    bool
+		emailIndex int
+	}{
+		{DuplicateHeadersError, true, 0},
+		{"", true, 0},
+		{DuplicateHeadersFirstWins, false, 2},
+		{DuplicateHeadersLastWins, false, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			service, _ := newTestExcelService(&config.Config{
+				Import: config.ImportConfig{DuplicateHeaders: tt.policy},
+			})
+
+			headerMap, err := service.validateAndMapHeaders(headers, employeeColumns)
+			if tt.wantErr {
+				// Only template columns count; the repeated notes column is ignored
+				if err == nil || !strings.Contains(err.Error(), "email (columns 3 and 6)") || strings.Contains(err.Error(), "notes") {
+					t.Errorf("Expected duplicate email error naming both columns, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if headerMap["email"] != tt.emailIndex {
+				t.Errorf("Expected email mapped to column %d, got %d", tt.emailIndex, headerMap["email"])
+			}
+		})
+	}
+
+	// The policy decides which column's values are imported
+	service, _ := newTestExcelService(&config.Config{
+		Import: config.ImportConfig{DuplicateHeaders: DuplicateHeadersFirstWins},
+	})
+	rows := [][]string{
+		{"first_name", "last_name", "email", "email"},
+		{"John", "Doe", "john@example.com", "wrong@example.com"},
+	}
+	sheet, err := service.parseExcelContent(buildWorkbook(t, rows), "test.xlsx")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sheet.Employees) != 1 || sheet.Employees[0].Email != "john@example.com" {
+		t.Errorf("Expected the first email column to be imported, got %+v", sheet.Employees)
+	}
+
+	service, _ = newTestExcelService(&config.Config{})
+	if _, err := service.parseExcelContent(buildWorkbook(t, rows), "test.xlsx"); err == nil || !strings.Contains(err.Error(), "duplicate headers") {
+		t.Errorf("Expected the file to be rejected by default, got %v", err)
+	}
+}
+
+func TestProcessExcelFile_ImportConnectionBudget(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{MaxOpenConns: 10},
+		Import:   config.ImportConfig{DBConnFraction: 0.2},
+	}
+	if budget := cfg.ImportConnectionBudget(); budget != 2 {
+		t.Fatalf("Expected budget of 2 connections, got %d", budget)
+	}
+
+	repo := &slowBatchRepository{FakeRepository: testutil.NewFakeRepository()}
+	service := &ExcelService{
+		employeeService: NewEmployeeService(repo, testutil.NewFakeCache(), cfg),
+		config:          cfg,
+		jobs:            make(map[string]*JobResult),
+		importSlots:     newImportSlots(cfg),
+	}
+	cfg.Server.MaxFileSize = 10 * 1024 * 1024
+
+	content := buildWorkbook(t, [][]string{
+		importHeaders,
+		{"John", "Doe", "Acme", "", "", "", "", "", "john@example.com", ""},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", content)); err != nil {
+				t.Errorf("Import failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if observed := repo.maxInFlight.Load(); observed > 2 {
+		t.Errorf("Expected at most 2 concurrent batch inserts, observed %d", observed)
+	}
+}
+
+func TestProcessExcelFile_MaxDuplicatesShown(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{
+		Import: config.ImportConfig{MaxDuplicatesShown: 3},
+	})
+
+	rows := [][]string{importHeaders}
+	for i := 1; i <= 5; i++ {
+		email := fmt.Sprintf("dup%d@example.com", i)
+		repo.Seed(models.Employee{FirstName: "Existing", LastName: "Employee", Email: email})
+		rows = append(rows, []string{"John", "Doe", "", "", "", "", "", "", email, ""})
+	}
+
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.SkippedRecords != 5 {
+		t.Fatalf("Expected 5 skipped duplicates, got %d", response.SkippedRecords)
+	}
+	if len(response.DuplicateEmails) != 3 {
+		t.Errorf("Expected 3 duplicate emails in response, got %v", response.DuplicateEmails)
+	}
+	if !strings.Contains(response.Message, "and 2 more") {
+		t.Errorf("Expected message to mention the 2 omitted duplicates, got %q", response.Message)
+	}
+	if strings.Contains(response.Message, "dup4@example.com") {
+		t.Errorf("Expected message to list only the first 3 duplicates, got %q", response.Message)
+	}
+}
+
+func TestProcessExcelFile_RedactsPIIInLogs(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	pii.SetLogRedaction(true)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		pii.SetLogRedaction(false)
+	})
+
+	service, repo := newTestExcelService(&config.Config{})
+	repo.Seed(models.Employee{FirstName: "Existing", LastName: "Employee", Email: "jane.doe@example.com"})
+	rows := [][]string{importHeaders, {"Jane", "Doe", "", "", "", "", "", "504-621-8927", "jane.doe@example.com", ""}}
+
+	if _, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows))); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := service.employeeService.SearchEmployees(context.Background(), "jane.doe@example.com", false, 10, 0, models.SortOptions{}, 0); err != nil {
+		t.Fatalf("Unexpected search error: %v", err)
+	}
+
+	output := logged.String()
+	if strings.Contains(output, "jane.doe@example.com") {
+		t.Errorf("Expected emails to be masked in the log, got:\n%s", output)
+	}
+	if !strings.Contains(output, "j***@example.com") {
+		t.Errorf("Expected the masked email in the log, got:\n%s", output)
+	}
+}
+
+func TestProcessExcelFile_MaxValidationErrors(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{
+		Import: config.ImportConfig{MaxValidationErrors: 5},
+	})
+
+	// Every invalid row has two errors: missing first name and a malformed email
+	rows := [][]string{importHeaders, {"John", "Doe", "", "", "", "", "", "", "john@example.com", ""}}
+	for i := 0; i < 20; i++ {
+		rows = append(rows, []string{"", "Doe", "Acme", "", "", "", "", "", "not-an-email", ""})
+	}
+	content := buildWorkbook(t, rows)
+
+	sheet, err := service.parseExcelContent(content, "test.xlsx")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sheet.Errors) != 5 {
+		t.Errorf("Expected errors to be capped at 5, got %d", len(sheet.Errors))
+	}
+	if !sheet.Truncated {
+		t.Error("Expected truncated flag to be set")
+	}
+	if sheet.InvalidRows != 20 || len(sheet.Employees) != 1 {
+		t.Errorf("Expected all 20 invalid rows to be counted beside 1 valid, got %d and %d", sheet.InvalidRows, len(sheet.Employees))
+	}
+
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", content))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !response.Truncated || response.InvalidRecords != 20 || response.TotalRecords != 21 {
+		t.Errorf("Expected truncated response counting 20 of 21 rows invalid, got %+v", response)
+	}
+}
+
+func TestProcessExcelFile_UniquePhone(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Database: config.DatabaseConfig{UniquePhone: true}})
+	repo.UniquePhone = true
+
+	rows := [][]string{
+		importHeaders,
+		{"John", "Doe", "", "", "", "", "", "555-0100", "john@example.com", ""},
+		{"Jane", "Roe", "", "", "", "", "", "555-0100", "jane@example.com", ""},
+		{"Bob", "Smith", "", "", "", "", "", "", "bob@example.com", ""},
+		{"Ann", "Lee", "", "", "", "", "", "", "ann@example.com", ""},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.InsertedRecords != 3 || response.SkippedRecords != 1 {
+		t.Errorf("Expected the repeated phone to be skipped and empty phones kept, got %+v", response)
+	}
+	if len(response.DuplicateEmails) != 1 || response.DuplicateEmails[0] != "jane@example.com" {
+		t.Errorf("Expected the skipped row to be reported, got %v", response.DuplicateEmails)
+	}
+}
+
+func TestParseExcelContent_PreserveWhitespace(t *testing.T) {
+	for i := range models.EmployeeFields {
+		if models.EmployeeFields[i].Column == "address" {
+			models.EmployeeFields[i].PreserveWhitespace = true
+			t.Cleanup(func() { models.EmployeeFields[i].PreserveWhitespace = false })
+		}
+	}
+	service, _ := newTestExcelService(&config.Config{})
+
+	rows := [][]string{
+		importHeaders,
+		{"  John ", " Doe", "", "  Unit 4  Main St ", "", "", "", "", "john@example.com", ""},
+		{"Jane", "Roe", "", "   ", "", "", "", "", "jane@example.com", ""},
+	}
+	sheet, err := service.parseExcelContent(buildWorkbook(t, rows), "test.xlsx")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sheet.Employees) != 2 {
+		t.Fatalf("Expected 2 employees, got %d (errors %v)", len(sheet.Employees), sheet.Errors)
+	}
+
+	john := sheet.Employees[0]
+	if john.Address != "  Unit 4  Main St " {
+		t.Errorf("Expected the address to keep its spaces, got %q", john.Address)
+	}
+	if john.FirstName != "John" || john.LastName != "Doe" {
+		t.Errorf("Expected names to be trimmed, got %q %q", john.FirstName, john.LastName)
+	}
+	if sheet.Employees[1].Address != "" {
+		t.Errorf("Expected a blank address to stay empty, got %q", sheet.Employees[1].Address)
+	}
+}
+
+func TestParseExcelContent_Coordinates(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{})
+
+	rows := [][]string{
+		{"first_name", "last_name", "email", "latitude", "longitude"},
+		{"John", "Doe", "john@example.com", "51.5072", "-0.1276"},
+		{"Jane", "Roe", "jane@example.com", "", ""},
+		{"Ann", "Lee", "ann@example.com", "48,8566", "2,3522"},
+		{"Bob", "Ray", "bob@example.com", "north", "0"},
+		{"Eve", "Poe", "eve@example.com", "0", "181"},
+	}
+	sheet, err := service.parseExcelContent(buildWorkbook(t, rows), "test.xlsx")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(sheet.Employees) != 3 || sheet.InvalidRows != 2 {
+		t.Fatalf("Expected 3 valid and 2 invalid rows, got %d and %d: %+v", len(sheet.Employees), sheet.InvalidRows, sheet.Errors)
+	}
+	john, jane, ann := sheet.Employees[0], sheet.Employees[1], sheet.Employees[2]
+	if john.Latitude == nil || *john.Latitude != 51.5072 || john.Longitude == nil || *john.Longitude != -0.1276 {
+		t.Errorf("Expected John's coordinates to be parsed, got %v, %v", john.Latitude, john.Longitude)
+	}
+	if jane.Latitude != nil || jane.Longitude != nil {
+		t.Errorf("Expected blank coordinates to stay unknown, got %v, %v", jane.Latitude, jane.Longitude)
+	}
+	if ann.Latitude == nil || *ann.Latitude != 48.8566 {
+		t.Errorf("Expected a decimal comma to be accepted, got %v", ann.Latitude)
+	}
+
+	problems := fmt.Sprint(sheet.Errors)
+	if !strings.Contains(problems, "Row 5 - latitude") || !strings.Contains(problems, "Row 6 - Longitude") {
+		t.Errorf("Expected the unparseable latitude and out-of-range longitude reported, got %v", sheet.Errors)
+	}
+}
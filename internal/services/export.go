@@ -0,0 +1,238 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// DefaultExportSheet names the sheet of an .xlsx export without a sheet param
+const DefaultExportSheet = "Employees"
+
+// ExportFilter narrows down which employees are included in an export
+type ExportFilter struct {
+	Search    string   // Same free-text search as the list endpoint
+	Wildcards bool     // Treat % and _ in Search as LIKE wildcards
+	Columns   []string // Columns from ParseColumnOrder or ParseColumnSubset; nil uses the default order
+	Sheet     string   // Sheet name of an .xlsx export; "" uses DefaultExportSheet
+}
+
+// ParseColumnOrder turns a comma-separated column list into a full column order.
+// Listed columns come first in the given order and any columns not mentioned
+// follow in their default order, so a partial list never drops data.
+func ParseColumnOrder(spec string) ([]string, error) {
+	listed, err := parseColumnList(spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(listed) == 0 {
+		return employeeColumns, nil
+	}
+
+	seen := make(map[string]bool, len(listed))
+	for _, column := range listed {
+		seen[column] = true
+	}
+	order := listed
+	for _, column := range employeeColumns {
+		if !seen[column] {
+			order = append(order, column)
+		}
+	}
+	return order, nil
+}
+
+// CheckExportConfig validates COLUMN_ORDER against the employee fields, so a
+// typo stops the service at startup instead of failing every export with 400
+func CheckExportConfig(cfg *config.ExportConfig) error {
+	if _, err := ParseColumnOrder(cfg.ColumnOrder); err != nil {
+		return fmt.Errorf("invalid COLUMN_ORDER %q: %w", cfg.ColumnOrder, err)
+	}
+	return nil
+}
+
+// ParseColumnSubset turns a comma-separated column list into the only
+// columns to export, in the given order
+func ParseColumnSubset(spec string) ([]string, error) {
+	listed, err := parseColumnList(spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(listed) == 0 {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+	return listed, nil
+}
+
+// parseColumnList reads a comma-separated list of known, distinct columns
+func parseColumnList(spec string) ([]string, error) {
+	known := make(map[string]bool, len(employeeColumns))
+	for _, column := range employeeColumns {
+		known[column] = true
+	}
+
+	listed := make([]string, 0, len(employeeColumns))
+	seen := make(map[string]bool, len(employeeColumns))
+	for _, name := range strings.Split(spec, ",") {
+		column := strings.ToLower(strings.TrimSpace(name))
+		if column == "" {
+			continue
+		}
+		if !known[column] {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("column %q listed more than once", column)
+		}
+		seen[column] = true
+		listed = append(listed, column)
+	}
+	return listed, nil
+}
+
+// ValidateSheetName applies Excel's rules for sheet names: 1 to 31
+// characters, none of :\/?*[] and no single quote at either end
+func ValidateSheetName(name string) error {
+	switch {
+	case name == "":
+		return excelize.ErrSheetNameBlank
+	case utf8.RuneCountInString(name) > excelize.MaxSheetNameLength:
+		return excelize.ErrSheetNameLength
+	case strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'"):
+		return excelize.ErrSheetNameSingleQuote
+	case strings.ContainsAny(name, ":\\/?*[]"):
+		return excelize.ErrSheetNameInvalid
+	}
+	return nil
+}
+
+// ExportEmployeesCSV streams employees matching the filter to w as CSV.
+// Rows are written as they are read from the database cursor, and the header
+// uses the import column names so the file can be edited and re-uploaded in
+// any column order.
+func (s *ExcelService) ExportEmployeesCSV(w io.Writer, filter ExportFilter) error {
+	columns := filter.Columns
+	if columns == nil {
+		columns = employeeColumns
+	}
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	err := s.employeeService.repo.StreamEmployees(searchPattern(filter.Search, filter.Wildcards), func(employee *models.Employee) error {
+		return writer.Write(employeeRecord(employee, columns))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export employees: %w", err)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportEmployees writes employees matching the filter to w as an .xlsx
+// workbook with the import columns, so the file can be edited and re-uploaded.
+// The rows go on one sheet named by the filter.
+// Rows go through excelize's StreamWriter as they are read from the database
+// cursor, which spills to a temporary file instead of holding the sheet in
+// memory. Nothing is written to w until every row has been read.
+func (s *ExcelService) ExportEmployees(w io.Writer, filter ExportFilter) error {
+	columns := filter.Columns
+	if columns == nil {
+		columns = employeeColumns
+	}
+
+	sheet := filter.Sheet
+	if sheet == "" {
+		sheet = DefaultExportSheet
+	}
+
+	xlFile := excelize.NewFile()
+	defer xlFile.Close()
+
+	if err := xlFile.SetSheetName(xlFile.GetSheetName(0), sheet); err != nil {
+		return fmt.Errorf("invalid sheet name: %w", err)
+	}
+	stream, err := xlFile.NewStreamWriter(sheet)
+	if err != nil {
+		return fmt.Errorf("failed to create sheet writer: %w", err)
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		return fmt.Errorf("failed to write header row: %w", err)
+	}
+
+	row := 1
+	err = s.employeeService.repo.StreamEmployees(searchPattern(filter.Search, filter.Wildcards), func(employee *models.Employee) error {
+		row++
+		cell, err := excelize.CoordinatesToCellName(1, row)
+		if err != nil {
+			return err
+		}
+		// Values are written as text so phone numbers and postal codes keep
+		// their leading zeros
+		record := employeeRecord(employee, columns)
+		values := make([]interface{}, len(record))
+		for i, value := range record {
+			values[i] = value
+		}
+		return stream.SetRow(cell, values)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export employees: %w", err)
+	}
+
+	if err := stream.Flush(); err != nil {
+		return fmt.Errorf("failed to finish sheet: %w", err)
+	}
+	if err := xlFile.Write(w); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+// ExportEmployeesNDJSON streams employees matching the filter to w as
+// newline-delimited JSON, one employee per line, straight from the database
+// cursor. flush, when set, is called with the rows written so far every
+// flushEvery rows, so clients start receiving data before the export finishes.
+func (s *ExcelService) ExportEmployeesNDJSON(w io.Writer, filter ExportFilter, flushEvery int, flush func(rows int)) error {
+	encoder := json.NewEncoder(w)
+	rows := 0
+
+	err := s.employeeService.repo.StreamEmployees(searchPattern(filter.Search, filter.Wildcards), func(employee *models.Employee) error {
+		if err := encoder.Encode(employee); err != nil {
+			return err
+		}
+		rows++
+		if flush != nil && flushEvery > 0 && rows%flushEvery == 0 {
+			flush(rows)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream employees: %w", err)
+	}
+	return nil
+}
+
+// employeeRecord returns the employee's values for the given columns
+func employeeRecord(employee *models.Employee, columns []string) []string {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = employee.ColumnValue(column)
+	}
+	return record
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"testing"
+)
+
+func TestCheckExportConfig(t *testing.T) {
+	for _, tt := range []struct {
+		columnOrder string
+		wantErr     bool
+	}{
+		{"", false},
+		{"email, Last_Name", false},
+		{"email,salary", true},
+		{"email,email", true},
+	} {
+		err := CheckExportConfig(&config.ExportConfig{ColumnOrder: tt.columnOrder})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %t, got %v", tt.columnOrder, tt.wantErr, err)
+		}
+	}
+}
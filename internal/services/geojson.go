@@ -0,0 +1,24 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"fmt"
+)
+
+// GetEmployeesGeoJSON returns every employee with both coordinates as a
+// GeoJSON FeatureCollection for the mapping UI. Employees without a location
+// are skipped. Rows are read through a cursor, so only the features are held
+// in memory.
+func (s *EmployeeService) GetEmployeesGeoJSON() (models.FeatureCollection, error) {
+	var features []models.Feature
+	err := s.repo.StreamEmployees("", func(employee *models.Employee) error {
+		if feature, ok := employee.ToFeature(); ok {
+			features = append(features, feature)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.FeatureCollection{}, fmt.Errorf("failed to get employee locations: %w", err)
+	}
+	return models.NewFeatureCollection(features), nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"sort"
+	"time"
+)
+
+// finished reports whether a job has reached a final status
+func (j *JobResult) finished() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed
+}
+
+// startJobCleaner expires old jobs every interval until Stop, so the job map
+// shrinks even when no new uploads arrive. A non-positive interval leaves
+// cleanup to enqueueing new jobs.
+func (s *ExcelService) startJobCleaner(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.expireJobs()
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+}
+
+// evictExcessJobs drops the least recently updated finished jobs until at
+// most MaxRetainedJobs remain. The caller must hold s.mu.
+func (s *ExcelService) evictExcessJobs() {
+	limit := s.config.Import.MaxRetainedJobs
+	if limit <= 0 {
+		return
+	}
+
+	var finished []*JobResult
+	for _, job := range s.jobs {
+		if job.finished() {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= limit {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool { return finished[i].UpdatedAt.Before(finished[j].UpdatedAt) })
+	for _, job := range finished[:len(finished)-limit] {
+		delete(s.jobs, job.ID)
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// jobErrorsHeader names the column appended to the job errors file
+const jobErrorsHeader = "errors"
+
+// WriteJobErrors writes the invalid rows of a finished import to w as a
+// workbook laid out like the import template with an extra errors column,
+// so users can fix the rows and upload the file again
+func (s *ExcelService) WriteJobErrors(jobID string, w io.Writer) error {
+	s.mu.RLock()
+	job, exists := s.jobs[jobID]
+	var status JobStatus
+	var failedRows []failedRow
+	if exists {
+		status = job.Status
+		failedRows = job.failedRows
+	}
+	s.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("job not found")
+	}
+	if status != JobStatusCompleted {
+		return fmt.Errorf("job has not completed")
+	}
+
+	xlFile := excelize.NewFile()
+	defer xlFile.Close()
+	sheetName := xlFile.GetSheetName(0)
+
+	header := make([]interface{}, 0, len(employeeColumns)+1)
+	for _, column := range employeeColumns {
+		header = append(header, column)
+	}
+	header = append(header, jobErrorsHeader)
+	if err := xlFile.SetSheetRow(sheetName, "A1", &header); err != nil {
+		return fmt.Errorf("failed to write header row: %w", err)
+	}
+
+	for i, failed := range failedRows {
+		values := make([]interface{}, 0, len(failed.Values)+1)
+		for _, value := range failed.Values {
+			values = append(values, value)
+		}
+		values = append(values, failed.Errors)
+
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return fmt.Errorf("failed to address row %d: %w", failed.Row, err)
+		}
+		if err := xlFile.SetSheetRow(sheetName, cell, &values); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", failed.Row, err)
+		}
+	}
+
+	if err := xlFile.Write(w); err != nil {
+		return fmt.Errorf("failed to write errors file: %w", err)
+	}
+	return nil
+}
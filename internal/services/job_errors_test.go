@@ -0,0 +1,155 @@
+package services
+
+import (
+	"bytes"
+	"employee-management/internal/config"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteJobErrors_ContainsInvalidRows(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{})
+
+	// Columns are reordered so the errors file must map them back to the template
+	rows := [][]string{
+		{"email", "first_name", "last_name", "company_name"},
+		{"john@example.com", "John", "Doe", "Acme"},
+		{"not-an-email", "Jane", "Roe", "Acme"},
+		{"bob@example.com", "", "Smith", "Acme"},
+	}
+	file := newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows))
+
+	service.jobs["job-1"] = &JobResult{ID: "job-1", Status: JobStatusPending}
+	if err := service.WriteJobErrors("job-1", &bytes.Buffer{}); err == nil {
+		t.Error("Expected error for a job that has not completed")
+	}
+
+	service.processJobRequest(&JobRequest{JobID: "job-1", Source: fileHeaderSource(file)})
+
+	var out bytes.Buffer
+	if err := service.WriteJobErrors("job-1", &out); err != nil {
+		t.Fatalf("WriteJobErrors failed: %v", err)
+	}
+
+	xlFile, err := excelize.OpenReader(&out)
+	if err != nil {
+		t.Fatalf("Errors file is not a valid workbook: %v", err)
+	}
+	defer xlFile.Close()
+	got, err := xlFile.GetRows(xlFile.GetSheetName(0))
+	if err != nil {
+		t.Fatalf("Failed to read errors file: %v", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("Expected header and 2 invalid rows, got %v", got)
+	}
+	header := got[0]
+	if len(header) != len(employeeColumns)+1 || header[0] != employeeColumns[0] || header[len(header)-1] != jobErrorsHeader {
+		t.Errorf("Expected template header plus errors column, got %v", header)
+	}
+
+	emailColumn := -1
+	for i, column := range employeeColumns {
+		if column == "email" {
+			emailColumn = i
+		}
+	}
+	if got[1][0] != "Jane" || got[1][emailColumn] != "not-an-email" {
+		t.Errorf("Expected Jane's original data in template order, got %v", got[1])
+	}
+	if errorsCell := got[1][len(got[1])-1]; !strings.Contains(errorsCell, "Email") {
+		t.Errorf("Expected email error for Jane, got %q", errorsCell)
+	}
+	if got[2][1] != "Smith" || !strings.Contains(got[2][len(got[2])-1], "FirstName") {
+		t.Errorf("Expected Smith's row with a first name error, got %v", got[2])
+	}
+
+	if err := service.WriteJobErrors("missing", &bytes.Buffer{}); err == nil || err.Error() != "job not found" {
+		t.Errorf("Expected job not found, got %v", err)
+	}
+}
+
+func TestExpireJobs(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{JobTTL: time.Hour}})
+
+	old := time.Now().Add(-2 * time.Hour)
+	service.jobs["done"] = &JobResult{ID: "done", Status: JobStatusCompleted, UpdatedAt: old}
+	service.jobs["running"] = &JobResult{ID: "running", Status: JobStatusRunning, UpdatedAt: old}
+	service.jobs["recent"] = &JobResult{ID: "recent", Status: JobStatusCompleted, UpdatedAt: time.Now()}
+
+	service.expireJobs()
+
+	if _, err := service.GetJobStatus("done"); err == nil {
+		t.Error("Expected finished job past its TTL to be removed")
+	}
+	for _, id := range []string{"running", "recent"} {
+		if _, err := service.GetJobStatus(id); err != nil {
+			t.Errorf("Expected job %s to be kept, got %v", id, err)
+		}
+	}
+}
+
+func TestExpireJobs_MaxRetained(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{MaxRetainedJobs: 2}})
+
+	now := time.Now()
+	service.jobs["oldest"] = &JobResult{ID: "oldest", Status: JobStatusFailed, UpdatedAt: now.Add(-3 * time.Minute)}
+	service.jobs["older"] = &JobResult{ID: "older", Status: JobStatusCompleted, UpdatedAt: now.Add(-2 * time.Minute)}
+	service.jobs["newer"] = &JobResult{ID: "newer", Status: JobStatusCompleted, UpdatedAt: now.Add(-time.Minute)}
+	service.jobs["newest"] = &JobResult{ID: "newest", Status: JobStatusCompleted, UpdatedAt: now}
+	service.jobs["pending"] = &JobResult{ID: "pending", Status: JobStatusPending, UpdatedAt: now.Add(-time.Hour)}
+
+	service.expireJobs()
+
+	for _, id := range []string{"oldest", "older"} {
+		if _, err := service.GetJobStatus(id); err == nil {
+			t.Errorf("Expected job %s to be evicted over the cap", id)
+		}
+	}
+	for _, id := range []string{"newer", "newest", "pending"} {
+		if _, err := service.GetJobStatus(id); err != nil {
+			t.Errorf("Expected job %s to be kept, got %v", id, err)
+		}
+	}
+}
+
+func TestJobCleaner(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{JobTTL: time.Hour}})
+	service.jobs["done"] = &JobResult{ID: "done", Status: JobStatusCompleted, UpdatedAt: time.Now().Add(-2 * time.Hour)}
+
+	service.startJobCleaner(10 * time.Millisecond)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := service.GetJobStatus("done"); err != nil {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("Expected the background cleaner to remove the job past its retention")
+}
+
+func TestJobCleaner_Stop(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{JobTTL: time.Hour}})
+	service.startJobCleaner(5 * time.Millisecond)
+	service.Stop()
+	service.Stop()
+	time.Sleep(20 * time.Millisecond) // let a tick already in progress finish
+
+	// Once stopped, the cleaner leaves even expired jobs alone
+	service.mu.Lock()
+	service.jobs["done"] = &JobResult{ID: "done", Status: JobStatusCompleted, UpdatedAt: time.Now().Add(-2 * time.Hour)}
+	service.mu.Unlock()
+	time.Sleep(50 * time.Millisecond)
+
+	service.mu.Lock()
+	_, kept := service.jobs["done"]
+	service.mu.Unlock()
+	if !kept {
+		t.Error("Expected the stopped cleaner not to run")
+	}
+}
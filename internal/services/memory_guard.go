@@ -0,0 +1,132 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"errors"
+	"log"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ErrMemoryPressure is returned for new uploads while the process holds more
+// memory than IMPORT_MEMORY_LIMIT_MB
+var ErrMemoryPressure = errors.New("server is low on memory, please retry later")
+
+// memoryRecoveryRatio is the share of the limit memory must fall below before
+// uploads are accepted again, so the guard does not flap around the limit
+const memoryRecoveryRatio = 0.9
+
+// memoryGuard sheds new uploads while memory use is above a limit. Memory is
+// sampled periodically rather than per request because runtime.ReadMemStats
+// briefly stops the world.
+type memoryGuard struct {
+	limit uint64        // 0 disables the guard
+	read  func() uint64 // Current memory use in bytes
+
+	mu     sync.RWMutex
+	status models.MemoryStatus
+}
+
+func newMemoryGuard(limitMB int) *memoryGuard {
+	guard := &memoryGuard{read: processMemory}
+	if limitMB > 0 {
+		guard.limit = uint64(limitMB) * 1024 * 1024
+	}
+	guard.status = models.MemoryStatus{
+		Enabled:      guard.limit > 0,
+		LimitBytes:   guard.limit,
+		RecoverBytes: uint64(float64(guard.limit) * memoryRecoveryRatio),
+	}
+	return guard
+}
+
+// processMemory returns the memory the Go runtime holds from the operating
+// system minus what it has handed back, which tracks the process's RSS
+func processMemory() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Sys - stats.HeapReleased
+}
+
+// start samples memory now and then every interval until stop is closed. A
+// disabled guard never samples.
+func (g *memoryGuard) start(interval time.Duration, stop <-chan bool) {
+	if g.limit == 0 || interval <= 0 {
+		return
+	}
+	g.sample()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				g.sample()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// sample records the current memory use. The guard trips above the limit and
+// only recovers once use falls below memoryRecoveryRatio of it.
+func (g *memoryGuard) sample() {
+	if g.limit == 0 {
+		return
+	}
+	used := g.read()
+	now := time.Now()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	overloaded := g.status.Overloaded
+	switch {
+	case used > g.limit:
+		overloaded = true
+	case used < g.status.RecoverBytes:
+		overloaded = false
+	}
+	if overloaded && !g.status.Overloaded {
+		log.Printf("Warning: memory use %d MB is above IMPORT_MEMORY_LIMIT_MB, refusing new uploads", used/(1024*1024))
+	} else if !overloaded && g.status.Overloaded {
+		log.Printf("Memory use recovered to %d MB, accepting uploads again", used/(1024*1024))
+	}
+
+	g.status.UsedBytes = used
+	g.status.Overloaded = overloaded
+	g.status.CheckedAt = &now
+}
+
+// overloaded reports whether new uploads should be refused
+func (g *memoryGuard) overloaded() bool {
+	if g.limit == 0 {
+		return false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.status.Overloaded
+}
+
+// snapshot returns the latest sample
+func (g *memoryGuard) snapshot() models.MemoryStatus {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.status
+}
+
+// CheckMemory returns ErrMemoryPressure while new uploads are refused for
+// IMPORT_MEMORY_LIMIT_MB. Handlers call it before reading an upload's body.
+func (s *ExcelService) CheckMemory() error {
+	if s.memoryGuard.overloaded() {
+		return ErrMemoryPressure
+	}
+	return nil
+}
+
+// MemoryStatus returns the memory guard's latest sample for the health endpoint
+func (s *ExcelService) MemoryStatus() models.MemoryStatus {
+	return s.memoryGuard.snapshot()
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryGuard_ShedsUploadsAboveLimit(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Import: config.ImportConfig{MemoryLimitMB: 100}})
+	used := uint64(50 << 20)
+	service.memoryGuard.read = func() uint64 { return used }
+
+	service.memoryGuard.sample()
+	if err := service.CheckMemory(); err != nil {
+		t.Fatalf("Expected uploads below the limit to be accepted, got: %v", err)
+	}
+
+	// Simulate an import ballooning past the limit
+	used = 120 << 20
+	service.memoryGuard.sample()
+	file := newFileHeader(t, "employees.xlsx", buildWorkbook(t, [][]string{
+		importHeaders,
+		{"John", "Doe", "Acme", "", "", "", "", "", "john@example.com", ""},
+	}))
+	if _, err := service.StartAsyncExcelProcessing(file, ImportModeLive, events.Actor{IP: "203.0.113.7"}); !errors.Is(err, ErrMemoryPressure) {
+		t.Fatalf("Expected ErrMemoryPressure above the limit, got: %v", err)
+	}
+	if repo.Count() != 0 {
+		t.Errorf("Expected no inserts, got %d", repo.Count())
+	}
+
+	status := service.MemoryStatus()
+	if !status.Enabled || !status.Overloaded || status.UsedBytes != used || status.LimitBytes != 100<<20 {
+		t.Errorf("Unexpected memory status: %+v", status)
+	}
+
+	// Just under the limit is not enough to recover
+	used = 95 << 20
+	service.memoryGuard.sample()
+	if err := service.CheckMemory(); !errors.Is(err, ErrMemoryPressure) {
+		t.Errorf("Expected uploads to stay refused until memory recovers, got: %v", err)
+	}
+
+	used = 80 << 20
+	service.memoryGuard.sample()
+	if err := service.CheckMemory(); err != nil {
+		t.Errorf("Expected uploads to be accepted after recovery, got: %v", err)
+	}
+}
+
+func TestMemoryGuard_Disabled(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{})
+	service.memoryGuard.read = func() uint64 { return 1 << 40 }
+
+	service.memoryGuard.sample()
+	if err := service.CheckMemory(); err != nil {
+		t.Errorf("Expected a disabled guard to accept uploads, got: %v", err)
+	}
+	if status := service.MemoryStatus(); status.Enabled {
+		t.Errorf("Expected disabled status, got %+v", status)
+	}
+}
+
+func TestMemoryGuard_Stop(t *testing.T) {
+	guard := newMemoryGuard(100)
+	var samples atomic.Int32
+	guard.read = func() uint64 {
+		samples.Add(1)
+		return 0
+	}
+
+	stop := make(chan bool)
+	guard.start(5*time.Millisecond, stop)
+	close(stop)
+	time.Sleep(20 * time.Millisecond) // let a tick already in progress finish
+
+	// Once stopped, the guard no longer samples
+	stopped := samples.Load()
+	time.Sleep(50 * time.Millisecond)
+	if samples.Load() != stopped {
+		t.Errorf("Expected no samples after stop, got %d more", samples.Load()-stopped)
+	}
+}
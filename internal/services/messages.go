@@ -0,0 +1,123 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultLocale is used when the client does not ask for a supported language
+const DefaultLocale = "en"
+
+// defaultMessageKey holds the fallback message for tags without a dedicated entry
+const defaultMessageKey = "default"
+
+// validationMessages maps locale -> validation tag -> message template.
+// Templates may reference {field} and {param}.
+var validationMessages = map[string]map[string]string{
+	"en": {
+		"required":        "{field} is required",
+		"email":           "Invalid email format",
+		"email:simple":    "Email must look like name@domain.tld",
+		"email:rfc":       "Email must be a valid RFC 5322 address without a display name",
+		"email:strict":    "Email may only use letters, digits and . _ % + - before the @, followed by a domain with a letter-only TLD",
+		"min":             "{field} must be at least {param} characters",
+		"max":             "{field} must not exceed {param} characters",
+		"url":             "Invalid URL format",
+		"county":          "{field} must be one of: {param}",
+		"latitude":        "{field} must be a latitude between -90 and 90",
+		"longitude":       "{field} must be a longitude between -180 and 180",
+		defaultMessageKey: "{field} is invalid",
+	},
+	"es": {
+		"required":        "{field} es obligatorio",
+		"email":           "Formato de correo electrónico no válido",
+		"email:simple":    "El correo debe tener la forma nombre@dominio.tld",
+		"email:rfc":       "El correo debe ser una dirección RFC 5322 válida sin nombre visible",
+		"email:strict":    "El correo solo admite letras, dígitos y . _ % + - antes de la @, seguidos de un dominio con TLD solo de letras",
+		"min":             "{field} debe tener al menos {param} caracteres",
+		"max":             "{field} no debe superar {param} caracteres",
+		"url":             "Formato de URL no válido",
+		"county":          "{field} debe ser uno de: {param}",
+		"latitude":        "{field} debe ser una latitud entre -90 y 90",
+		"longitude":       "{field} debe ser una longitud entre -180 y 180",
+		defaultMessageKey: "{field} no es válido",
+	},
+}
+
+// ResolveLocale picks the best supported locale from an Accept-Language header,
+// honouring q-values and falling back to DefaultLocale
+func ResolveLocale(acceptLanguage string) string {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if value, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = value
+				}
+			}
+		}
+
+		// Only the primary subtag matters for our catalog ("es-MX" -> "es")
+		primary := strings.SplitN(tag, "-", 2)[0]
+		candidates = append(candidates, candidate{locale: primary, q: q})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	for _, c := range candidates {
+		if _, ok := validationMessages[c.locale]; ok && c.q > 0 {
+			return c.locale
+		}
+	}
+	return DefaultLocale
+}
+
+// getValidationMessage returns user-friendly validation messages in the given
+// locale. Email errors use the message for the configured EMAIL_VALIDATION mode.
+func getValidationMessage(err validator.FieldError, locale, emailMode string) string {
+	catalog, ok := validationMessages[locale]
+	if !ok {
+		catalog = validationMessages[DefaultLocale]
+	}
+
+	template, ok := catalog[err.Tag()]
+	if err.Tag() == "email" && emailMode != "" {
+		if modeTemplate, found := catalog["email:"+emailMode]; found {
+			template, ok = modeTemplate, true
+		}
+	}
+	if !ok {
+		template = catalog[defaultMessageKey]
+	}
+
+	return strings.NewReplacer("{field}", err.Field(), "{param}", err.Param()).Replace(template)
+}
+
+// localizedMessage renders the catalog entry for key, for checks that run
+// outside the struct validator
+func localizedMessage(locale, key, field, param string) string {
+	catalog, ok := validationMessages[locale]
+	if !ok {
+		catalog = validationMessages[DefaultLocale]
+	}
+	template, ok := catalog[key]
+	if !ok {
+		template = catalog[defaultMessageKey]
+	}
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(template)
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"employee-management/internal/testutil"
+)
+
+func TestResolveLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{"empty header falls back", "", "en"},
+		{"exact match", "es", "es"},
+		{"region subtag", "es-MX", "es"},
+		{"unsupported language falls back", "fr-FR", "en"},
+		{"first supported wins", "fr-FR, es;q=0.8, en;q=0.5", "es"},
+		{"q-values reorder preference", "en;q=0.3, es;q=0.9", "es"},
+		{"q=0 excludes language", "es;q=0", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveLocale(tt.header); got != tt.expected {
+				t.Errorf("ResolveLocale(%q) = %q, want %q", tt.header, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateEmployeeDataForLocale(t *testing.T) {
+	service := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{})
+	employee := &models.Employee{FirstName: "J", LastName: "Doe", Email: "john@example.com"}
+
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"en", "FirstName must be at least 2 characters"},
+		{"es", "FirstName debe tener al menos 2 caracteres"},
+		{"de", "FirstName must be at least 2 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			errors, _ := service.ValidateEmployeeDataForLocale(employee, tt.locale)
+			if len(errors) != 1 {
+				t.Fatalf("Expected 1 validation error, got %d", len(errors))
+			}
+			if errors[0].Message != tt.expected {
+				t.Errorf("Expected message %q, got %q", tt.expected, errors[0].Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Modes for TITLE_CASE_NAMES
+const (
+	TitleCaseOff    = "off"
+	TitleCaseImport = "import" // Only rows from import files
+	TitleCaseAll    = "all"    // Imports plus create and update through the API
+)
+
+// NormalizeEmployee applies the configured input corrections in place. It runs
+// before validation on every write path (create, update and import) so the
+// same input is accepted or rejected regardless of how it arrives.
+func (s *EmployeeService) NormalizeEmployee(employee *models.Employee) {
+	s.normalizeEmployee(employee, s.config.Validation.TitleCaseNames == TitleCaseAll)
+}
+
+// NormalizeImportedEmployee is NormalizeEmployee for rows read from an import
+// file, which are also title-cased when TITLE_CASE_NAMES is import
+func (s *EmployeeService) NormalizeImportedEmployee(employee *models.Employee) {
+	mode := s.config.Validation.TitleCaseNames
+	s.normalizeEmployee(employee, mode == TitleCaseImport || mode == TitleCaseAll)
+}
+
+func (s *EmployeeService) normalizeEmployee(employee *models.Employee, titleCase bool) {
+	if s.config.Validation.AutoFixWebScheme {
+		employee.Web = addWebScheme(employee.Web)
+	}
+	if s.config.Validation.NormalizeWeb {
+		employee.Web = normalizeWebURL(employee.Web, s.config.Validation.WebTrailingSlash)
+	}
+	if titleCase {
+		employee.FirstName = titleCaseName(employee.FirstName)
+		employee.LastName = titleCaseName(employee.LastName)
+	}
+	if width := s.config.Validation.PostalPadWidth; width > 0 {
+		employee.Postal = padPostal(employee.Postal, width)
+	}
+}
+
+// padPostal restores leading zeros that spreadsheets and number-typed clients
+// drop from US ZIP codes: an all-digit code shorter than width is left-padded
+// with zeros ("2101" -> "02101"), as is the first part of a ZIP+4
+// ("2101-1234" -> "02101-1234"). Codes with letters, such as UK postcodes,
+// and codes already at least width digits long are unchanged.
+func padPostal(postal string, width int) string {
+	base, extension, hasExtension := strings.Cut(postal, "-")
+	if !allDigits(base) || (hasExtension && (len(extension) != 4 || !allDigits(extension))) {
+		return postal
+	}
+	if len(base) < width {
+		base = strings.Repeat("0", width-len(base)) + base
+	}
+	if hasExtension {
+		return base + "-" + extension
+	}
+	return base
+}
+
+// allDigits reports whether s is non-empty and only ASCII digits
+func allDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// addWebScheme prepends https:// to a non-empty URL that has no scheme, e.g.
+// "example.com" -> "https://example.com"; values with a scheme are unchanged
+func addWebScheme(web string) string {
+	if web == "" || strings.Contains(web, "://") {
+		return web
+	}
+	return "https://" + web
+}
+
+// defaultPorts are dropped from normalized URLs of the matching scheme
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// normalizeWebURL lowercases the scheme and host and drops a default port, so
+// "HTTP://Example.COM:80/About" -> "http://example.com/About". The path keeps
+// its case. With stripSlash a trailing slash goes too ("https://x.com/" ->
+// "https://x.com"). Values that do not parse as absolute URLs are returned
+// unchanged for validation to reject.
+func normalizeWebURL(web string, stripSlash bool) string {
+	parsed, err := url.Parse(web)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return web
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6 literal
+	}
+	if port := parsed.Port(); port != "" && port != defaultPorts[parsed.Scheme] {
+		host += ":" + port
+	}
+	parsed.Host = host
+
+	if stripSlash && strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+		parsed.RawPath = ""
+	}
+	return parsed.String()
+}
+
+// titleCaseName capitalizes each part of a name and lowercases the rest:
+// "JOHN" -> "John", "mary-jane" -> "Mary-Jane", "o'brien" -> "O'Brien".
+// A leading "Mc" also capitalizes the following letter ("mcdonald" ->
+// "McDonald"). Other prefixes and particles are not special-cased, so "MacLeod"
+// becomes "Macleod" and "van der Berg" becomes "Van Der Berg".
+func titleCaseName(name string) string {
+	runes := []rune(strings.ToLower(name))
+	startOfPart := true
+	for i, r := range runes {
+		if startOfPart && unicode.IsLetter(r) {
+			runes[i] = unicode.ToUpper(r)
+			startOfPart = false
+			if r == 'm' && i+2 < len(runes) && runes[i+1] == 'c' && unicode.IsLetter(runes[i+2]) {
+				runes[i+2] = unicode.ToUpper(runes[i+2])
+			}
+			continue
+		}
+		startOfPart = unicode.IsSpace(r) || r == '-' || r == '\''
+	}
+	return string(runes)
+}
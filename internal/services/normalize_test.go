@@ -0,0 +1,245 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"employee-management/internal/testutil"
+	"testing"
+)
+
+func TestNormalizeEmployee_AutoFixWebScheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		web     string
+		want    string
+	}{
+		{"scheme-less value gets https", true, "example.com", "https://example.com"},
+		{"scheme-less value with path", true, "www.example.com/about", "https://www.example.com/about"},
+		{"https value is unchanged", true, "https://example.com", "https://example.com"},
+		{"http value is unchanged", true, "http://example.com", "http://example.com"},
+		{"other scheme is unchanged", true, "ftp://example.com", "ftp://example.com"},
+		{"empty value stays empty", true, "", ""},
+		{"disabled leaves value alone", false, "example.com", "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{
+				Validation: config.ValidationConfig{AutoFixWebScheme: tt.enabled},
+			})
+			employee := &models.Employee{Web: tt.web}
+			service.NormalizeEmployee(employee)
+			if employee.Web != tt.want {
+				t.Errorf("Expected web %q, got %q", tt.want, employee.Web)
+			}
+		})
+	}
+}
+
+func TestProcessExcelFile_AutoFixWebScheme(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{
+		Validation: config.ValidationConfig{AutoFixWebScheme: true},
+	})
+
+	rows := [][]string{
+		importHeaders,
+		{"John", "Doe", "", "", "", "", "", "", "john@example.com", "example.com"},
+		{"Jane", "Roe", "", "", "", "", "", "", "jane@example.com", "http://example.org"},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.InsertedRecords != 2 {
+		t.Fatalf("Expected both rows to import, got %+v", response)
+	}
+
+	for email, want := range map[string]string{
+		"john@example.com": "https://example.com",
+		"jane@example.com": "http://example.org",
+	} {
+		employee, err := repo.GetEmployeeByEmail(email)
+		if err != nil {
+			t.Fatalf("Expected %s to be stored: %v", email, err)
+		}
+		if employee.Web != want {
+			t.Errorf("Expected web %q for %s, got %q", want, email, employee.Web)
+		}
+	}
+}
+
+func TestTitleCaseName(t *testing.T) {
+	tests := map[string]string{
+		"john":          "John",
+		"JOHN":          "John",
+		"jOHN":          "John",
+		"mary-jane":     "Mary-Jane",
+		"o'brien":       "O'Brien",
+		"O'BRIEN":       "O'Brien",
+		"mcdonald":      "McDonald",
+		"McDonald":      "McDonald",
+		"van der berg":  "Van Der Berg",
+		"MACLEOD":       "Macleod",
+		"ÉLODIE":        "Élodie",
+		"  anna  marie": "  Anna  Marie",
+		"":              "",
+	}
+	for input, want := range tests {
+		if got := titleCaseName(input); got != want {
+			t.Errorf("titleCaseName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeEmployee_TitleCaseNames(t *testing.T) {
+	tests := []struct {
+		mode       string
+		wantAPI    string
+		wantImport string
+	}{
+		{TitleCaseOff, "JOHN", "JOHN"},
+		{"", "JOHN", "JOHN"},
+		{TitleCaseImport, "JOHN", "John"},
+		{TitleCaseAll, "John", "John"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			service := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{
+				Validation: config.ValidationConfig{TitleCaseNames: tt.mode},
+			})
+
+			viaAPI := &models.Employee{FirstName: "JOHN", LastName: "JOHN", CompanyName: "ACME"}
+			service.NormalizeEmployee(viaAPI)
+			if viaAPI.FirstName != tt.wantAPI || viaAPI.LastName != tt.wantAPI {
+				t.Errorf("API write: expected %q, got %q %q", tt.wantAPI, viaAPI.FirstName, viaAPI.LastName)
+			}
+
+			imported := &models.Employee{FirstName: "JOHN", LastName: "JOHN", CompanyName: "ACME"}
+			service.NormalizeImportedEmployee(imported)
+			if imported.FirstName != tt.wantImport || imported.LastName != tt.wantImport {
+				t.Errorf("Import: expected %q, got %q %q", tt.wantImport, imported.FirstName, imported.LastName)
+			}
+
+			if viaAPI.CompanyName != "ACME" || imported.CompanyName != "ACME" {
+				t.Error("Expected non-name fields to be left alone")
+			}
+		})
+	}
+}
+
+func TestNormalizeWebURL(t *testing.T) {
+	variants := []string{
+		"https://example.com",
+		"https://example.com/",
+		"HTTPS://EXAMPLE.COM",
+		"https://Example.com:443/",
+	}
+	for _, web := range variants {
+		if got := normalizeWebURL(web, true); got != "https://example.com" {
+			t.Errorf("normalizeWebURL(%q) = %q, want https://example.com", web, got)
+		}
+	}
+
+	tests := []struct {
+		web        string
+		stripSlash bool
+		want       string
+	}{
+		{"HTTP://Example.COM:80/About/Team", false, "http://example.com/About/Team"},
+		{"https://example.com/docs/", false, "https://example.com/docs/"},
+		{"https://example.com/docs/", true, "https://example.com/docs"},
+		{"http://example.com:8080/", true, "http://example.com:8080"},
+		{"https://example.com:80", false, "https://example.com:80"},
+		{"http://[::1]:80/", true, "http://[::1]"},
+		{"https://example.com/?q=1", true, "https://example.com?q=1"},
+		{"example.com", true, "example.com"},
+		{"", true, ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeWebURL(tt.web, tt.stripSlash); got != tt.want {
+			t.Errorf("normalizeWebURL(%q, %v) = %q, want %q", tt.web, tt.stripSlash, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeEmployee_NormalizeWeb(t *testing.T) {
+	service := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{
+		Validation: config.ValidationConfig{AutoFixWebScheme: true, NormalizeWeb: true, WebTrailingSlash: true},
+	})
+	employee := &models.Employee{Web: "Example.COM/"}
+	service.NormalizeEmployee(employee)
+	if employee.Web != "https://example.com" {
+		t.Errorf("Expected the scheme fix and normalization to combine, got %q", employee.Web)
+	}
+
+	disabled := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{})
+	employee = &models.Employee{Web: "HTTPS://EXAMPLE.COM/"}
+	disabled.NormalizeImportedEmployee(employee)
+	if employee.Web != "HTTPS://EXAMPLE.COM/" {
+		t.Errorf("Expected no change without NORMALIZE_WEB, got %q", employee.Web)
+	}
+}
+
+func TestNormalizeEmployee_PostalPadWidth(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		postal string
+		want   string
+	}{
+		{"short ZIP is padded", 5, "2101", "02101"},
+		{"very short ZIP is padded", 5, "501", "00501"},
+		{"full-width ZIP is unchanged", 5, "02101", "02101"},
+		{"longer code is unchanged", 5, "021010", "021010"},
+		{"ZIP+4 pads the first part", 5, "2101-1234", "02101-1234"},
+		{"UK postcode is unchanged", 5, "SW1A 1AA", "SW1A 1AA"},
+		{"alphanumeric code is unchanged", 5, "K1A0B1", "K1A0B1"},
+		{"malformed extension is unchanged", 5, "2101-12", "2101-12"},
+		{"empty value stays empty", 5, "", ""},
+		{"disabled leaves value alone", 0, "2101", "2101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewEmployeeService(testutil.NewFakeRepository(), testutil.NewFakeCache(), &config.Config{
+				Validation: config.ValidationConfig{PostalPadWidth: tt.width},
+			})
+			employee := &models.Employee{Postal: tt.postal}
+			service.NormalizeEmployee(employee)
+			if employee.Postal != tt.want {
+				t.Errorf("Expected postal %q, got %q", tt.want, employee.Postal)
+			}
+		})
+	}
+}
+
+func TestProcessExcelFile_PostalPadWidth(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{
+		Validation: config.ValidationConfig{PostalPadWidth: 5},
+	})
+
+	rows := [][]string{
+		importHeaders,
+		{"John", "Doe", "", "", "Boston", "", "2101", "", "john@example.com", ""},
+		{"Jane", "Roe", "", "", "London", "", "EC1A 1BB", "", "jane@example.com", ""},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.InsertedRecords != 2 {
+		t.Fatalf("Expected both rows to import, got %+v", response)
+	}
+
+	for email, want := range map[string]string{"john@example.com": "02101", "jane@example.com": "EC1A 1BB"} {
+		employee, err := repo.GetEmployeeByEmail(email)
+		if err != nil {
+			t.Fatalf("%s not stored: %v", email, err)
+		}
+		if employee.Postal != want {
+			t.Errorf("%s: expected postal %q, got %q", email, want, employee.Postal)
+		}
+	}
+}
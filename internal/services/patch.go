@@ -0,0 +1,149 @@
+package services
+
+import (
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// PatchEmployee changes only the given fields of an employee. Unlike
+// UpdateEmployee, an empty string or null clears an optional column, and only
+// the supplied fields are validated, so a record with a legacy invalid value
+// can still be patched elsewhere. Keys are column names; latitude and
+// longitude take a number or null. Unknown or read-only keys are rejected.
+func (s *EmployeeService) PatchEmployee(id int, fields map[string]any, actor events.Actor) (employee *models.Employee, changed []string, err error) {
+	if len(fields) == 0 {
+		return nil, nil, &EmployeeValidationError{Details: []models.ValidationError{
+			{Field: "body", Message: "at least one field is required"},
+		}}
+	}
+
+	// Read the supplied values into a scratch employee, so configured input
+	// corrections only touch the fields being patched
+	var patch models.Employee
+	var problems []models.ValidationError
+	columns := make([]string, 0, len(fields))
+	for column := range fields {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		if problem := setPatchField(&patch, column, fields[column]); problem != nil {
+			problems = append(problems, *problem)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, nil, &EmployeeValidationError{Details: problems}
+	}
+	s.NormalizeEmployee(&patch)
+
+	existingEmployee, err := s.repo.GetEmployeeByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("employee with ID %d not found", id)
+		}
+		return nil, nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+	original := *existingEmployee
+
+	for _, column := range columns {
+		switch column {
+		case "latitude":
+			existingEmployee.Latitude = patch.Latitude
+		case "longitude":
+			existingEmployee.Longitude = patch.Longitude
+		default:
+			existingEmployee.SetColumnValue(column, patch.ColumnValue(column))
+		}
+	}
+
+	if err := s.checkPatchedFields(existingEmployee, fields); err != nil {
+		return nil, nil, err
+	}
+
+	if _, ok := fields["email"]; ok && existingEmployee.Email != original.Email {
+		emailEmployee, err := s.repo.GetEmployeeByEmail(existingEmployee.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("failed to check existing email: %w", err)
+		}
+		if emailEmployee != nil && emailEmployee.ID != id {
+			return nil, nil, &EmployeeConflictError{Field: "email", Value: existingEmployee.Email}
+		}
+	}
+	if _, ok := fields["phone"]; ok && existingEmployee.Phone != original.Phone {
+		if err := s.checkPhoneAvailable(existingEmployee.Phone, id); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	changed = changedColumns(&original, existingEmployee)
+	if s.config.Server.SkipUnchangedUpdates && len(changed) == 0 {
+		return existingEmployee, changed, nil
+	}
+
+	if err := s.storeUpdate(existingEmployee, changed, actor); err != nil {
+		return nil, nil, err
+	}
+	return existingEmployee, changed, nil
+}
+
+// setPatchField stores one supplied value on the scratch employee, or
+// describes why it cannot be applied
+func setPatchField(patch *models.Employee, column string, value any) *models.ValidationError {
+	switch column {
+	case "latitude", "longitude":
+		var coordinate *float64
+		switch v := value.(type) {
+		case nil:
+		case float64:
+			coordinate = &v
+		default:
+			return &models.ValidationError{Field: column, Message: "must be a number or null"}
+		}
+		if column == "latitude" {
+			patch.Latitude = coordinate
+		} else {
+			patch.Longitude = coordinate
+		}
+		return nil
+	}
+
+	if _, ok := models.LookupEmployeeField(column); !ok {
+		return &models.ValidationError{Field: column, Message: "unknown or read-only field"}
+	}
+	switch v := value.(type) {
+	case nil:
+		patch.SetColumnValue(column, "")
+	case string:
+		patch.SetColumnValue(column, v)
+	default:
+		return &models.ValidationError{Field: column, Message: "must be a string or null"}
+	}
+	return nil
+}
+
+// checkPatchedFields validates the patched employee but only reports
+// blocking problems on the supplied fields
+func (s *EmployeeService) checkPatchedFields(employee *models.Employee, fields map[string]any) error {
+	problems := s.structProblems(employee, DefaultLocale)
+	if _, ok := fields["county"]; ok {
+		if problem := s.CheckCounty(employee.County, DefaultLocale); problem != nil {
+			problems = append(problems, validationProblem{column: "county", rule: "county", ValidationError: *problem})
+		}
+	}
+
+	var supplied []validationProblem
+	for _, problem := range problems {
+		if _, ok := fields[problem.column]; ok {
+			supplied = append(supplied, problem)
+		}
+	}
+	if errs, _ := s.severity.split(supplied); len(errs) > 0 {
+		return &EmployeeValidationError{Details: errs}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Dependency names reported by the readiness probe and accepted in
+// READINESS_REQUIRED
+const (
+	DependencyDatabase = "database"
+	DependencyCache    = "cache"
+	DependencyEvents   = "events"
+)
+
+// DependencyCheck returns nil when the dependency is usable
+type DependencyCheck func(ctx context.Context) error
+
+// Readiness evaluates whether the service can take traffic by checking its
+// dependencies. Evaluations are reused for READINESS_CACHE_TTL, so frequent
+// probes from several replicas do not load the database, and concurrent
+// probes share one evaluation.
+type Readiness struct {
+	required map[string]bool
+	cacheTTL time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+
+	evaluations singleflight.Group
+
+	mu         sync.Mutex // guards the fields below, never held while probing
+	names      []string
+	checks     map[string]DependencyCheck
+	generation int // bumped by AddCheck so older evaluations are not cached
+	last       *models.ReadinessReport
+}
+
+// NewReadiness returns a readiness probe without dependencies; register them
+// with AddCheck at startup
+func NewReadiness(cfg *config.ReadinessConfig) *Readiness {
+	required := make(map[string]bool)
+	for _, name := range strings.Split(cfg.Required, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+		case DependencyDatabase, DependencyCache, DependencyEvents:
+			required[name] = true
+		default:
+			log.Printf("Warning: unknown READINESS_REQUIRED entry %q ignored", name)
+		}
+	}
+	return &Readiness{
+		required: required,
+		cacheTTL: cfg.CacheTTL,
+		timeout:  cfg.Timeout,
+		now:      time.Now,
+		checks:   make(map[string]DependencyCheck),
+	}
+}
+
+// AddCheck registers a dependency. Dependencies that are not configured, such
+// as an event broker while events are logged, are simply not registered and
+// never block readiness.
+func (r *Readiness) AddCheck(name string, check DependencyCheck) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.checks[name]; !exists {
+		r.names = append(r.names, name)
+	}
+	r.checks[name] = check
+	r.generation++
+	r.last = nil
+}
+
+// Check returns the latest evaluation, checking every dependency in parallel
+// when the cached one has expired. The service is ready when every required
+// dependency is up; its status is degraded while an optional one is down.
+//
+// Dependencies are probed on a background context bounded by
+// READINESS_TIMEOUT, so a caller that disconnects cannot fail the shared
+// evaluation. That caller gets an uncached report of every dependency down.
+func (r *Readiness) Check(ctx context.Context) models.ReadinessReport {
+	r.mu.Lock()
+	if r.last != nil && r.now().Sub(r.last.CheckedAt) < r.cacheTTL {
+		report := *r.last
+		r.mu.Unlock()
+		return report
+	}
+	r.mu.Unlock()
+
+	result := r.evaluations.DoChan("readiness", func() (interface{}, error) {
+		return r.evaluate(), nil
+	})
+	select {
+	case evaluation := <-result:
+		return evaluation.Val.(models.ReadinessReport)
+	case <-ctx.Done():
+		return r.abandoned(ctx.Err())
+	}
+}
+
+// evaluate probes every registered dependency and caches the report unless
+// a dependency was registered meanwhile
+func (r *Readiness) evaluate() models.ReadinessReport {
+	r.mu.Lock()
+	generation := r.generation
+	names := append([]string(nil), r.names...)
+	checks := make([]DependencyCheck, len(names))
+	for i, name := range names {
+		checks[i] = r.checks[name]
+	}
+	r.mu.Unlock()
+
+	statuses := make([]models.DependencyStatus, len(names))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check DependencyCheck) {
+			defer wg.Done()
+			statuses[i] = r.probe(check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := r.report(names, statuses)
+
+	r.mu.Lock()
+	if r.generation == generation {
+		r.last = &report
+	}
+	r.mu.Unlock()
+	return report
+}
+
+// abandoned reports every dependency down with the caller's context error,
+// for a caller that stopped waiting before the evaluation finished
+func (r *Readiness) abandoned(err error) models.ReadinessReport {
+	r.mu.Lock()
+	names := append([]string(nil), r.names...)
+	r.mu.Unlock()
+
+	statuses := make([]models.DependencyStatus, len(names))
+	for i := range statuses {
+		statuses[i] = models.DependencyStatus{Status: models.DependencyDown, Error: err.Error()}
+	}
+	return r.report(names, statuses)
+}
+
+// report combines dependency statuses into a readiness report
+func (r *Readiness) report(names []string, statuses []models.DependencyStatus) models.ReadinessReport {
+	report := models.ReadinessReport{
+		Ready:        true,
+		Status:       models.DependencyUp,
+		CheckedAt:    r.now(),
+		Dependencies: make(map[string]models.DependencyStatus, len(names)),
+	}
+	for i, name := range names {
+		status := statuses[i]
+		status.Required = r.required[name]
+		if status.Status != models.DependencyUp {
+			if status.Required {
+				report.Ready = false
+			}
+			report.Status = models.DependencyDegraded
+		}
+		report.Dependencies[name] = status
+	}
+	if !report.Ready {
+		report.Status = models.DependencyDown
+	}
+	return report
+}
+
+// probe runs one check within READINESS_TIMEOUT. A check that ignores its
+// context is abandoned when the budget runs out and reported as down.
+func (r *Readiness) probe(check DependencyCheck) models.DependencyStatus {
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	result := make(chan error, 1)
+	go func() { result <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	status := models.DependencyStatus{Status: models.DependencyUp, LatencyMS: time.Since(started).Milliseconds()}
+	if err != nil {
+		status.Status = models.DependencyDown
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// EventBrokerCheck returns a check that the configured event broker is
+// reachable, or nil when events do not go to a remote broker
+func (s *EmployeeService) EventBrokerCheck() DependencyCheck {
+	pinger, ok := s.publisher.(events.Pinger)
+	if !ok {
+		return nil
+	}
+	return pinger.Ping
+}
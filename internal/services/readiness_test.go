@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadiness_MixedDependencies(t *testing.T) {
+	up := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+	hang := func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+
+	tests := []struct {
+		name      string
+		required  string
+		database  DependencyCheck
+		cache     DependencyCheck
+		events    DependencyCheck
+		wantReady bool
+		status    string
+	}{
+		{"all up", "database,events", up, up, up, true, models.DependencyUp},
+		{"optional cache down", "database,events", up, down, up, true, models.DependencyDegraded},
+		{"required database down", "database,events", down, up, up, false, models.DependencyDown},
+		{"required cache down", "database,cache", up, down, nil, false, models.DependencyDown},
+		{"required broker times out", "database,events", up, up, hang, false, models.DependencyDown},
+		{"required broker not configured", "database,events", up, up, nil, true, models.DependencyUp},
+		{"nothing required", "", down, down, down, true, models.DependencyDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readiness := NewReadiness(&config.ReadinessConfig{Required: tt.required, Timeout: 50 * time.Millisecond})
+			readiness.AddCheck(DependencyDatabase, tt.database)
+			readiness.AddCheck(DependencyCache, tt.cache)
+			if tt.events != nil {
+				readiness.AddCheck(DependencyEvents, tt.events)
+			}
+
+			report := readiness.Check(context.Background())
+			if report.Ready != tt.wantReady || report.Status != tt.status {
+				t.Errorf("Expected ready=%t with status %s, got %+v", tt.wantReady, tt.status, report)
+			}
+			if _, ok := report.Dependencies[DependencyEvents]; ok != (tt.events != nil) {
+				t.Errorf("Expected only registered dependencies in the report, got %+v", report.Dependencies)
+			}
+			for name, status := range report.Dependencies {
+				if (status.Status == models.DependencyDown) != (status.Error != "") {
+					t.Errorf("%s: expected an error exactly when down, got %+v", name, status)
+				}
+			}
+		})
+	}
+}
+
+func TestReadiness_CachesEvaluation(t *testing.T) {
+	calls := 0
+	readiness := NewReadiness(&config.ReadinessConfig{Required: "database", CacheTTL: 2 * time.Second})
+	readiness.AddCheck(DependencyDatabase, func(context.Context) error {
+		calls++
+		if calls > 1 {
+			return errors.New("gone")
+		}
+		return nil
+	})
+	now := time.Now()
+	readiness.now = func() time.Time { return now }
+
+	if !readiness.Check(context.Background()).Ready || !readiness.Check(context.Background()).Ready || calls != 1 {
+		t.Fatalf("Expected the evaluation to be reused within the TTL, got %d checks", calls)
+	}
+
+	now = now.Add(3 * time.Second)
+	report := readiness.Check(context.Background())
+	if report.Ready || calls != 2 || report.Dependencies[DependencyDatabase].Error != "gone" {
+		t.Errorf("Expected a fresh evaluation after the TTL, got %+v after %d checks", report, calls)
+	}
+}
+
+func TestReadiness_CallerCancellation(t *testing.T) {
+	release := make(chan struct{})
+	calls := 0
+	readiness := NewReadiness(&config.ReadinessConfig{Required: "database", CacheTTL: time.Minute, Timeout: time.Second})
+	readiness.AddCheck(DependencyDatabase, func(ctx context.Context) error {
+		calls++
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	// The caller goes away while the probe is still running
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report := readiness.Check(ctx); report.Ready {
+		t.Fatalf("Expected a cancelled caller to get a not-ready report, got %+v", report)
+	}
+
+	// The probe kept running on its own context; its result is shared and cached
+	close(release)
+	for _, attempt := range []string{"first", "second"} {
+		if report := readiness.Check(context.Background()); !report.Ready {
+			t.Errorf("%s check: expected the cancellation not to be cached, got %+v", attempt, report)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected one probe shared by every caller, got %d", calls)
+	}
+}
+
+func TestEventBrokerCheck(t *testing.T) {
+	service := NewEmployeeService(nil, nil, &config.Config{})
+	if service.EventBrokerCheck() != nil {
+		t.Error("Expected no broker check without a remote broker")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	service.SetEventPublisher(events.NewHTTPPublisher(server.URL))
+	check := service.EventBrokerCheck()
+	if check == nil || check(context.Background()) != nil {
+		t.Error("Expected any HTTP answer to count as reachable")
+	}
+
+	server.Close()
+	if err := check(context.Background()); err == nil {
+		t.Error("Expected an unreachable broker to fail the check")
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrReplaceNotAllowed is returned for replace imports while IMPORT_ALLOW_REPLACE is off
+var ErrReplaceNotAllowed = errors.New("replace imports are disabled; set IMPORT_ALLOW_REPLACE=true to enable them")
+
+// ParseReplaceMode turns replace=true into ImportModeReplace. Replacing
+// deletes every employee, so it must be enabled with IMPORT_ALLOW_REPLACE
+// and confirmed per request with confirm=replace, and it cannot be combined
+// with another mode.
+func (s *ExcelService) ParseReplaceMode(mode ImportMode, replace, confirm string) (ImportMode, error) {
+	if replace == "" || replace == "false" {
+		return mode, nil
+	}
+	if replace != "true" {
+		return "", fmt.Errorf("replace must be true or false")
+	}
+	if !s.config.Import.AllowReplace {
+		return "", ErrReplaceNotAllowed
+	}
+	if mode != ImportModeLive {
+		return "", fmt.Errorf("replace cannot be combined with mode=%s", mode)
+	}
+	if confirm != "replace" {
+		return "", fmt.Errorf("replace deletes every employee; confirm with confirm=replace")
+	}
+	return ImportModeReplace, nil
+}
+
+// replaceEmployees swaps every employee for the rows of a snapshot file. The
+// file was parsed and validated in full before this point, and a file with
+// any invalid row, or none at all, is rejected, so a broken feed never
+// replaces good data. The swap itself is one transaction: readers see the
+// old employees until it commits and a failure leaves them in place.
+func (s *ExcelService) replaceEmployees(employees []models.Employee, invalidRows int, response *models.ExcelUploadResponse) error {
+	if invalidRows > 0 {
+		return fmt.Errorf("replace rejected: %d invalid rows; employees were left unchanged", invalidRows)
+	}
+	if len(employees) == 0 {
+		return fmt.Errorf("replace rejected: the file has no employees; employees were left unchanged")
+	}
+
+	var (
+		removed           int64
+		inserted, skipped int
+		duplicateEmails   []string
+		err               error
+	)
+	s.withImportConnection(func() {
+		removed, inserted, skipped, duplicateEmails, err = s.employeeService.repo.ReplaceEmployees(employees)
+	})
+	if err != nil {
+		return fmt.Errorf("replace failed, employees were left unchanged: %w", err)
+	}
+	log.Printf("Replaced %d employees with %d from the import (%d duplicates skipped)", removed, inserted, skipped)
+
+	// Every cached employee and list is stale now
+	service := s.employeeService
+	if err := service.cache.InvalidateEmployeeCache(); err != nil {
+		service.invalidationFailed(err, "Failed to invalidate employee cache after replace")
+	}
+	if err := service.cache.InvalidateEmployeeListCache(); err != nil {
+		service.invalidationFailed(err, "Failed to invalidate employee list cache after replace")
+	}
+	service.touchLastModified()
+
+	response.RemovedRecords = removed
+	response.InsertedRecords = inserted
+	response.SkippedRecords = skipped
+	response.ValidRecords = inserted
+	maxDuplicatesToShow := s.config.Import.MaxDuplicatesShown
+	if maxDuplicatesToShow <= 0 {
+		maxDuplicatesToShow = 10
+	}
+	if len(duplicateEmails) > maxDuplicatesToShow {
+		response.DuplicateEmails = duplicateEmails[:maxDuplicatesToShow]
+	} else if len(duplicateEmails) > 0 {
+		response.DuplicateEmails = duplicateEmails
+	}
+	response.Message = fmt.Sprintf("Replaced %d employees with %d from the file, Skipped: %d duplicates",
+		removed, inserted, skipped)
+	return nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestReplaceImport_SwapsEmployees(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+	repo.Seed(
+		models.Employee{FirstName: "Old", LastName: "One", Email: "old1@example.com"},
+		models.Employee{FirstName: "Old", LastName: "Two", Email: "old2@example.com"},
+		models.Employee{FirstName: "Kept", LastName: "Email", Email: "jane@example.com"},
+	)
+
+	rows := [][]string{
+		{"first_name", "last_name", "email"},
+		{"John", "Doe", "john@example.com"},
+		{"Jane", "Roe", "jane@example.com"},
+	}
+	source := fileHeaderSource(newFileHeader(t, "snapshot.xlsx", buildWorkbook(t, rows)))
+
+	response, _, err := service.processExcelSource(source, ImportModeReplace)
+	if err != nil {
+		t.Fatalf("replace import failed: %v", err)
+	}
+	if response.RemovedRecords != 3 || response.InsertedRecords != 2 {
+		t.Errorf("Expected 3 removed and 2 inserted, got %+v", response)
+	}
+	if repo.Count() != 2 {
+		t.Fatalf("Expected only the file's 2 employees, got %d", repo.Count())
+	}
+	if _, err := repo.GetEmployeeByEmail("old1@example.com"); err == nil {
+		t.Error("Expected old employees to be gone after replace")
+	}
+	if employee, err := repo.GetEmployeeByEmail("jane@example.com"); err != nil || employee.LastName != "Roe" {
+		t.Errorf("Expected jane@example.com to hold the file's row, got %+v (err %v)", employee, err)
+	}
+}
+
+func TestReplaceImport_LeavesEmployeesOnFailure(t *testing.T) {
+	valid := [][]string{{"first_name", "last_name", "email"}, {"John", "Doe", "john@example.com"}}
+	withInvalid := append(valid, []string{"Bad", "Row", "not-an-email"})
+
+	tests := []struct {
+		name       string
+		rows       [][]string
+		replaceErr error
+	}{
+		{name: "invalid row", rows: withInvalid},
+		{name: "empty file", rows: valid[:1]},
+		{name: "database error", rows: valid, replaceErr: errors.New("deadlock")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestExcelService(&config.Config{})
+			repo.Seed(models.Employee{FirstName: "Old", LastName: "One", Email: "old1@example.com"})
+			repo.ReplaceErr = tt.replaceErr
+
+			source := fileHeaderSource(newFileHeader(t, "snapshot.xlsx", buildWorkbook(t, tt.rows)))
+			if _, _, err := service.processExcelSource(source, ImportModeReplace); err == nil {
+				t.Fatal("Expected the replace to fail")
+			}
+			if _, err := repo.GetEmployeeByEmail("old1@example.com"); err != nil || repo.Count() != 1 {
+				t.Errorf("Expected the existing employee to remain alone, got %d rows (err %v)", repo.Count(), err)
+			}
+		})
+	}
+}
+
+func TestParseReplaceMode(t *testing.T) {
+	enabled := &ExcelService{config: &config.Config{Import: config.ImportConfig{AllowReplace: true}}}
+	disabled := &ExcelService{config: &config.Config{}}
+
+	if mode, err := enabled.ParseReplaceMode(ImportModeLive, "true", "replace"); err != nil || mode != ImportModeReplace {
+		t.Errorf("Expected a confirmed replace, got %q (err %v)", mode, err)
+	}
+	if mode, err := disabled.ParseReplaceMode(ImportModeStaging, "", ""); err != nil || mode != ImportModeStaging {
+		t.Errorf("Expected the mode to pass through without replace, got %q (err %v)", mode, err)
+	}
+	if _, err := disabled.ParseReplaceMode(ImportModeLive, "true", "replace"); !errors.Is(err, ErrReplaceNotAllowed) {
+		t.Errorf("Expected ErrReplaceNotAllowed while disabled, got %v", err)
+	}
+	if _, err := enabled.ParseReplaceMode(ImportModeLive, "true", ""); err == nil {
+		t.Error("Expected an unconfirmed replace to be rejected")
+	}
+	if _, err := enabled.ParseReplaceMode(ImportModeStaging, "true", "replace"); err == nil {
+		t.Error("Expected replace with mode=staging to be rejected")
+	}
+}
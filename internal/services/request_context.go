@@ -0,0 +1,19 @@
+package services
+
+import "context"
+
+// requestIDKey is the context key of the request ID in service calls
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the ID of the HTTP request a
+// service call serves, so the service's log lines can be correlated with it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// requestID returns the request ID stored by WithRequestID, or "" when the
+// call does not serve a request
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
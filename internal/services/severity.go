@@ -0,0 +1,154 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Severity decides whether a failed validation rule blocks the record
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // The record is rejected (default for every rule)
+	SeverityWarning Severity = "warning" // The record is accepted and the problem reported
+)
+
+// severityRules maps "column" or "column:rule" to a severity, e.g.
+// "phone:max" or "web". A column:rule entry wins over its bare column.
+type severityRules map[string]Severity
+
+// parseSeverityRules reads VALIDATION_SEVERITY, a comma-separated list of
+// key=severity pairs such as "web:url=warning,county=warning". Entries naming
+// an unknown column or severity are logged and ignored.
+func parseSeverityRules(spec string) severityRules {
+	rules := make(severityRules)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, level, err := parseSeverityEntry(entry)
+		if err != nil {
+			log.Printf("Warning: ignoring VALIDATION_SEVERITY entry %q: %v", entry, err)
+			continue
+		}
+		rules[key] = level
+	}
+	return rules
+}
+
+// parseSeverityEntry splits one key=severity pair and checks both halves
+func parseSeverityEntry(entry string) (string, Severity, error) {
+	key, level, found := strings.Cut(entry, "=")
+	if !found {
+		return "", "", fmt.Errorf("expected key=severity")
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	column, rule, _ := strings.Cut(key, ":")
+	if _, ok := models.LookupEmployeeField(column); !ok {
+		return "", "", fmt.Errorf("unknown column %q", column)
+	}
+	if strings.Contains(key, ":") && rule == "" {
+		return "", "", fmt.Errorf("empty rule for column %q", column)
+	}
+
+	switch Severity(strings.ToLower(strings.TrimSpace(level))) {
+	case SeverityError:
+		return key, SeverityError, nil
+	case SeverityWarning:
+		return key, SeverityWarning, nil
+	default:
+		return "", "", fmt.Errorf("severity must be error or warning")
+	}
+}
+
+// of returns the severity of a rule failing on a column
+func (r severityRules) of(column, rule string) Severity {
+	if level, ok := r[column+":"+rule]; ok {
+		return level
+	}
+	if level, ok := r[column]; ok {
+		return level
+	}
+	return SeverityError
+}
+
+// validationProblem is a failed rule before it is sorted by severity
+type validationProblem struct {
+	column string
+	rule   string
+	models.ValidationError
+}
+
+// split sorts problems into blocking errors and non-blocking warnings
+func (r severityRules) split(problems []validationProblem) (errs, warnings []models.ValidationError) {
+	for _, problem := range problems {
+		if r.of(problem.column, problem.rule) == SeverityWarning {
+			warnings = append(warnings, problem.ValidationError)
+		} else {
+			errs = append(errs, problem.ValidationError)
+		}
+	}
+	return errs, warnings
+}
+
+// employeeType is used to map validator struct fields back to their columns
+var employeeType = reflect.TypeOf(models.Employee{})
+
+// columnOfField returns the JSON/database column of an Employee struct field
+func columnOfField(field string) string {
+	if structField, ok := employeeType.FieldByName(field); ok {
+		return strings.Split(structField.Tag.Get("json"), ",")[0]
+	}
+	return strings.ToLower(field)
+}
+
+// structProblems runs the struct validator and records which rule failed on
+// which column
+func (s *EmployeeService) structProblems(employee *models.Employee, locale string) []validationProblem {
+	err := s.validate.Struct(employee)
+	if err == nil {
+		return nil
+	}
+	var problems []validationProblem
+	for _, err := range err.(validator.ValidationErrors) {
+		problems = append(problems, validationProblem{
+			column: columnOfField(err.StructField()),
+			rule:   err.Tag(),
+			ValidationError: models.ValidationError{
+				Field:   err.Field(),
+				Message: getValidationMessage(err, locale, s.config.Validation.EmailMode),
+			},
+		})
+	}
+	return problems
+}
+
+// EmployeeValidationError lists every blocking problem with the fields of an
+// update or patch
+type EmployeeValidationError struct {
+	Details []models.ValidationError
+}
+
+func (e *EmployeeValidationError) Error() string {
+	problems := make([]string, len(e.Details))
+	for i, detail := range e.Details {
+		problems[i] = detail.Field + ": " + detail.Message
+	}
+	return "validation failed: " + strings.Join(problems, "; ")
+}
+
+// blockingError returns the first blocking struct validation problem as an
+// error, ignoring rules configured as warnings
+func (s *EmployeeService) blockingError(employee *models.Employee) error {
+	errs, _ := s.severity.split(s.structProblems(employee, DefaultLocale))
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("validation failed: %s: %s", errs[0].Field, errs[0].Message)
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"testing"
+)
+
+func TestParseSeverityRules(t *testing.T) {
+	rules := parseSeverityRules("web:url=warning, PHONE = Warning, email:max=error, bogus=warning, city=loud, county:=warning, postal")
+
+	tests := []struct {
+		column, rule string
+		want         Severity
+	}{
+		{"web", "url", SeverityWarning},
+		{"web", "max", SeverityError},
+		{"phone", "max", SeverityWarning},
+		{"email", "max", SeverityError},
+		{"email", "required", SeverityError},
+		{"city", "max", SeverityError},
+		{"county", "county", SeverityError},
+		{"postal", "max", SeverityError},
+	}
+	for _, tt := range tests {
+		if got := rules.of(tt.column, tt.rule); got != tt.want {
+			t.Errorf("%s:%s: expected %s, got %s", tt.column, tt.rule, tt.want, got)
+		}
+	}
+	if len(rules) != 3 {
+		t.Errorf("Expected invalid entries to be dropped, got %v", rules)
+	}
+}
+
+func TestValidateEmployeeData_Severity(t *testing.T) {
+	cfg := &config.Config{Validation: config.ValidationConfig{
+		Severity:        "web:url=warning,county=warning",
+		AllowedCounties: "Kent",
+	}}
+	service := NewEmployeeService(nil, nil, cfg)
+
+	tests := []struct {
+		name         string
+		employee     models.Employee
+		wantErrors   int
+		wantWarnings int
+	}{
+		{"clean", models.Employee{FirstName: "John", LastName: "Doe", Email: "john@example.com"}, 0, 0},
+		{"downgraded rules only warn", models.Employee{FirstName: "John", LastName: "Doe", Email: "john@example.com", Web: "not a url", County: "Devon"}, 0, 2},
+		{"bad email still blocks", models.Employee{FirstName: "John", LastName: "Doe", Email: "invalid", Web: "not a url"}, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, warnings := service.ValidateEmployeeData(&tt.employee)
+			if len(errs) != tt.wantErrors || len(warnings) != tt.wantWarnings {
+				t.Errorf("Expected %d errors and %d warnings, got %v and %v", tt.wantErrors, tt.wantWarnings, errs, warnings)
+			}
+		})
+	}
+
+	// Warnings do not stop the service from saving the record
+	if err := service.blockingError(&models.Employee{FirstName: "John", LastName: "Doe", Email: "john@example.com", Web: "not a url"}); err != nil {
+		t.Errorf("Expected a warning-only record to pass, got %v", err)
+	}
+}
+
+func TestProcessExcelFile_SeverityWarnings(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Validation: config.ValidationConfig{Severity: "web=warning"}})
+
+	rows := [][]string{
+		{"first_name", "last_name", "email", "web"},
+		{"John", "Doe", "john@example.com", "not a url"},
+		{"Jane", "Roe", "invalid", ""},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if response.InsertedRecords != 1 || response.InvalidRecords != 1 || repo.Count() != 1 {
+		t.Errorf("Expected the warned row imported and the bad email rejected, got %+v", response)
+	}
+	if len(response.RowWarnings) != 1 || response.RowWarnings[0].Field != "Row 2 - Web" {
+		t.Errorf("Expected one warning for row 2's web, got %v", response.RowWarnings)
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"employee-management/internal/models"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// newLoadGroup returns the group that coalesces cache misses, or nil when
+// CACHE_SINGLEFLIGHT is off
+func newLoadGroup(enabled bool) *singleflight.Group {
+	if !enabled {
+		return nil
+	}
+	return &singleflight.Group{}
+}
+
+// listResult is one page of a list read, as shared between coalesced callers
+type listResult struct {
+	employees []models.Employee
+	total     int64
+}
+
+// loadShared runs load, the database read and cache fill behind a cache
+// miss. While CACHE_SINGLEFLIGHT is on, concurrent misses on the same cache
+// key wait for the first caller's load instead of each querying the database,
+// so a hot key expiring does not send a burst of identical queries.
+func loadShared[T any](s *EmployeeService, key string, load func() (T, error)) (T, error) {
+	if s.loads == nil {
+		return load()
+	}
+	value, err, _ := s.loads.Do(key, func() (interface{}, error) {
+		return load()
+	})
+	result, _ := value.(T)
+	return result, err
+}
+
+// loadEmployeeShared is loadShared for single employees. Callers that joined
+// another's load get their own copy, as they would from the cache.
+func loadEmployeeShared(s *EmployeeService, key string, load func() (*models.Employee, error)) (*models.Employee, error) {
+	employee, err := loadShared(s, key, load)
+	if employee == nil || s.loads == nil {
+		return employee, err
+	}
+	copied := *employee
+	return &copied, err
+}
+
+// loadListShared is loadShared for list pages, copying the shared page
+func loadListShared(s *EmployeeService, key string, load func() (listResult, error)) ([]models.Employee, int64, error) {
+	result, err := loadShared(s, key, load)
+	if err != nil {
+		return nil, 0, err
+	}
+	if s.loads == nil || result.employees == nil {
+		return result.employees, result.total, nil
+	}
+	return append([]models.Employee{}, result.employees...), result.total, nil
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"employee-management/internal/testutil"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingReadRepository holds every read until release is closed and counts
+// how many reached the database
+type blockingReadRepository struct {
+	*testutil.FakeRepository
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (r *blockingReadRepository) GetEmployeeByID(id int) (*models.Employee, error) {
+	r.calls.Add(1)
+	<-r.release
+	return r.FakeRepository.GetEmployeeByID(id)
+}
+
+func (r *blockingReadRepository) GetAllEmployees(limit, offset int, sort models.SortOptions) ([]models.Employee, int64, error) {
+	r.calls.Add(1)
+	<-r.release
+	return r.FakeRepository.GetAllEmployees(limit, offset, sort)
+}
+
+// waitForCalls waits until the repository has seen n reads
+func waitForCalls(t *testing.T, repo *blockingReadRepository, n int32) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for repo.calls.Load() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d database reads, got %d", n, repo.calls.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCacheMisses_ShareOneDatabaseRead(t *testing.T) {
+	const callers = 20
+
+	newService := func(singleFlight bool, cache *testutil.FakeCache) (*EmployeeService, *blockingReadRepository) {
+		repo := &blockingReadRepository{FakeRepository: testutil.NewFakeRepository(), release: make(chan struct{})}
+		repo.Seed(
+			models.Employee{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+			models.Employee{ID: 2, FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"},
+		)
+		return NewEmployeeService(repo, cache, &config.Config{Redis: config.RedisConfig{SingleFlight: singleFlight}}), repo
+	}
+
+	t.Run("employee by ID", func(t *testing.T) {
+		service, repo := newService(true, testutil.NewFakeCache())
+
+		var wg sync.WaitGroup
+		results := make([]*models.Employee, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = service.GetEmployeeByID(context.Background(), 1)
+			}(i)
+		}
+
+		// Let the other callers miss the cache and queue behind the first read
+		waitForCalls(t, repo, 1)
+		time.Sleep(20 * time.Millisecond)
+		close(repo.release)
+		wg.Wait()
+
+		if calls := repo.calls.Load(); calls != 1 {
+			t.Errorf("Expected 1 database read for %d simultaneous misses, got %d", callers, calls)
+		}
+		for i, employee := range results {
+			if employee == nil || employee.Email != "john@example.com" {
+				t.Fatalf("Caller %d got %+v", i, employee)
+			}
+		}
+		if results[0] == results[1] {
+			t.Error("Expected each caller to get its own copy of the shared employee")
+		}
+	})
+
+	t.Run("employee list", func(t *testing.T) {
+		service, repo := newService(true, testutil.NewFakeCache())
+
+		var wg sync.WaitGroup
+		totals := make([]int64, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, totals[i], _ = service.GetAllEmployees(context.Background(), 10, 0, models.SortOptions{}, 0)
+			}(i)
+		}
+
+		waitForCalls(t, repo, 1)
+		time.Sleep(20 * time.Millisecond)
+		close(repo.release)
+		wg.Wait()
+
+		if calls := repo.calls.Load(); calls != 1 {
+			t.Errorf("Expected 1 database read for %d simultaneous misses, got %d", callers, calls)
+		}
+		for i, total := range totals {
+			if total != 2 {
+				t.Errorf("Caller %d got total %d, want 2", i, total)
+			}
+		}
+	})
+
+	t.Run("disabled reads once per miss", func(t *testing.T) {
+		// A cache that cannot be written keeps every caller missing
+		cache := testutil.NewFakeCache()
+		cache.SetErr = errors.New("connection refused")
+		service, repo := newService(false, cache)
+
+		var wg sync.WaitGroup
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				service.GetEmployeeByID(context.Background(), 1)
+			}()
+		}
+
+		waitForCalls(t, repo, callers)
+		close(repo.release)
+		wg.Wait()
+	})
+}
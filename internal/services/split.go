@@ -0,0 +1,166 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// SplitRule derives several employee fields from one import column, for
+// partner files that combine e.g. "City, County Postal" in a single cell.
+// A rule either matches Pattern, whose named groups are employee columns, or
+// cuts the cell at Delimiter into Fields in order (the last field keeps any
+// remainder). Configured in IMPORT_SPLIT_RULES as a JSON array.
+type SplitRule struct {
+	Column    string   `json:"column"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Delimiter string   `json:"delimiter,omitempty"`
+	Fields    []string `json:"fields,omitempty"`
+}
+
+// splitRule is a SplitRule ready to apply
+type splitRule struct {
+	column    string
+	pattern   *regexp.Regexp
+	delimiter string
+	fields    []string // Target columns, in pattern group or delimiter order
+}
+
+// parseSplitRules validates and compiles IMPORT_SPLIT_RULES; empty means none
+func parseSplitRules(spec string) ([]splitRule, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	var configured []SplitRule
+	if err := json.Unmarshal([]byte(spec), &configured); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	rules := make([]splitRule, 0, len(configured))
+	for _, rule := range configured {
+		column := strings.ToLower(strings.TrimSpace(rule.Column))
+		if column == "" {
+			return nil, fmt.Errorf("rule without a column")
+		}
+		compiled := splitRule{column: column, delimiter: rule.Delimiter}
+
+		switch {
+		case rule.Pattern != "" && rule.Delimiter != "":
+			return nil, fmt.Errorf("rule for %q sets both pattern and delimiter", column)
+		case rule.Pattern != "":
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("rule for %q: %w", column, err)
+			}
+			compiled.pattern = pattern
+			for _, name := range pattern.SubexpNames()[1:] {
+				if name != "" {
+					compiled.fields = append(compiled.fields, name)
+				}
+			}
+		case rule.Delimiter != "":
+			compiled.fields = rule.Fields
+		default:
+			return nil, fmt.Errorf("rule for %q needs a pattern or a delimiter", column)
+		}
+
+		if len(compiled.fields) == 0 {
+			return nil, fmt.Errorf("rule for %q produces no fields", column)
+		}
+		for _, field := range compiled.fields {
+			if _, ok := models.LookupEmployeeField(field); !ok {
+				return nil, fmt.Errorf("rule for %q targets unknown field %q", column, field)
+			}
+		}
+		rules = append(rules, compiled)
+	}
+	return rules, nil
+}
+
+// resolveSplitRules returns the configured split rules, or none when they are invalid
+func resolveSplitRules(spec string) []splitRule {
+	rules, err := parseSplitRules(spec)
+	if err != nil {
+		log.Printf("Warning: invalid IMPORT_SPLIT_RULES (%v), not splitting any columns", err)
+		return nil
+	}
+	return rules
+}
+
+// split derives field values from a cell. Blank cells derive nothing.
+func (r splitRule) split(value string) (map[string]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	derived := make(map[string]string, len(r.fields))
+	if r.pattern != nil {
+		match := r.pattern.FindStringSubmatch(value)
+		if match == nil {
+			return nil, fmt.Errorf("%q does not match the expected format", value)
+		}
+		for i, name := range r.pattern.SubexpNames() {
+			if i > 0 && name != "" {
+				derived[name] = strings.TrimSpace(match[i])
+			}
+		}
+		return derived, nil
+	}
+
+	parts := strings.SplitN(value, r.delimiter, len(r.fields))
+	if len(parts) != len(r.fields) {
+		return nil, fmt.Errorf("%q has %d parts separated by %q, expected %d", value, len(parts), r.delimiter, len(r.fields))
+	}
+	for i, field := range r.fields {
+		derived[field] = strings.TrimSpace(parts[i])
+	}
+	return derived, nil
+}
+
+// splitSourceFor returns the header index of a split source column that
+// provides column, so a file can satisfy a required header through a rule
+func (s *ExcelService) splitSourceFor(column string, headerMap map[string]int) (int, bool) {
+	for _, rule := range s.splitRules {
+		index, present := headerMap[rule.column]
+		if !present {
+			continue
+		}
+		for _, field := range rule.fields {
+			if field == column {
+				return index, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// splitRow applies every rule whose source column is in the file. Failures
+// are returned as row errors naming the source column.
+func (s *ExcelService) splitRow(row []string, headerMap map[string]int, rowNumber int) (map[string]string, []models.ValidationError) {
+	derived := make(map[string]string)
+	var rowErrors []models.ValidationError
+
+	for _, rule := range s.splitRules {
+		index, present := headerMap[rule.column]
+		if !present || index >= len(row) {
+			continue
+		}
+		values, err := rule.split(stripBOM(row[index]))
+		if err != nil {
+			rowErrors = append(rowErrors, models.ValidationError{
+				Field:   fmt.Sprintf("Row %d - %s", rowNumber, rule.column),
+				Message: "Cannot split column: " + err.Error(),
+			})
+			continue
+		}
+		for field, value := range values {
+			derived[field] = value
+		}
+	}
+	return derived, rowErrors
+}
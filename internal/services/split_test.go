@@ -0,0 +1,89 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"strings"
+	"testing"
+)
+
+const locationRule = `[{"column": "location", "pattern": "^(?P<city>[^,]+),\\s*(?P<county>.+?)\\s+(?P<postal>\\S+)$"}]`
+
+func TestProcessExcelFile_SplitRules(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Import: config.ImportConfig{SplitRules: locationRule}})
+
+	rows := [][]string{
+		{"first_name", "last_name", "email", "location"},
+		{"John", "Doe", "john@example.com", "Springfield, Greene County 65801"},
+		{"Jane", "Roe", "jane@example.com", "no comma here"},
+	}
+	sheet, err := service.parseExcelContent(buildWorkbook(t, rows), "employees.xlsx")
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	if len(sheet.Employees) != 1 {
+		t.Fatalf("Expected 1 valid employee, got %d (errors %v)", len(sheet.Employees), sheet.Errors)
+	}
+	john := sheet.Employees[0]
+	if john.City != "Springfield" || john.County != "Greene County" || john.Postal != "65801" {
+		t.Errorf("Expected the location split into city, county and postal, got %q / %q / %q", john.City, john.County, john.Postal)
+	}
+
+	if sheet.InvalidRows != 1 || len(sheet.Errors) != 1 || sheet.Errors[0].Field != "Row 3 - location" ||
+		!strings.Contains(sheet.Errors[0].Message, "does not match") {
+		t.Errorf("Expected a row-level split error for row 3, got %v", sheet.Errors)
+	}
+	if repo.Count() != 0 {
+		t.Errorf("Parsing must not insert, got %d rows", repo.Count())
+	}
+}
+
+func TestProcessExcelFile_SplitRuleProvidesRequiredColumns(t *testing.T) {
+	rules := `[{"column": "name", "delimiter": " ", "fields": ["first_name", "last_name"]}]`
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{SplitRules: rules}})
+
+	rows := [][]string{
+		{"name", "email"},
+		{"Mary Ann Smith", "mary@example.com"},
+		{"Cher", "cher@example.com"},
+	}
+	sheet, err := service.parseExcelContent(buildWorkbook(t, rows), "employees.xlsx")
+	if err != nil {
+		t.Fatalf("Expected the name rule to satisfy the required name headers, got %v", err)
+	}
+	if len(sheet.Employees) != 1 || sheet.Employees[0].FirstName != "Mary" || sheet.Employees[0].LastName != "Ann Smith" {
+		t.Errorf("Expected the remainder to go to the last field, got %+v", sheet.Employees)
+	}
+	if sheet.InvalidRows != 1 {
+		t.Errorf("Expected the single-word name to fail splitting, got %v", sheet.Errors)
+	}
+}
+
+func TestParseSplitRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		valid bool
+	}{
+		{"empty", "", true},
+		{"pattern", locationRule, true},
+		{"delimiter", `[{"column": "name", "delimiter": " ", "fields": ["first_name", "last_name"]}]`, true},
+		{"not JSON", "location=city,county", false},
+		{"unknown field", `[{"column": "name", "delimiter": " ", "fields": ["nickname"]}]`, false},
+		{"unknown group", `[{"column": "loc", "pattern": "(?P<town>.+)"}]`, false},
+		{"both modes", `[{"column": "loc", "pattern": "(?P<city>.+)", "delimiter": ","}]`, false},
+		{"neither mode", `[{"column": "loc", "fields": ["city"]}]`, false},
+		{"bad regex", `[{"column": "loc", "pattern": "(?P<city>"}]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSplitRules(tt.spec)
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid rules, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
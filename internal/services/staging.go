@@ -0,0 +1,111 @@
+package services
+
+import (
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportMode selects where an import writes its valid rows
+type ImportMode string
+
+// Import modes. Live imports insert straight into employees; staging imports
+// park the rows in employees_staging under a batch ID for review; replace
+// imports swap the whole table for the file (see replaceEmployees).
+const (
+	ImportModeLive    ImportMode = "live"
+	ImportModeStaging ImportMode = "staging"
+	ImportModeReplace ImportMode = "replace"
+)
+
+// ParseImportMode validates the mode query parameter; empty means live
+func ParseImportMode(raw string) (ImportMode, error) {
+	switch ImportMode(raw) {
+	case "", ImportModeLive:
+		return ImportModeLive, nil
+	case ImportModeStaging:
+		return ImportModeStaging, nil
+	}
+	return "", fmt.Errorf("must be %s or %s", ImportModeLive, ImportModeStaging)
+}
+
+// ErrStagingBatchNotFound is returned for unknown, promoted or discarded batches
+var ErrStagingBatchNotFound = errors.New("staging batch not found")
+
+// stageEmployees stores validated import rows under a new batch ID
+func (s *ExcelService) stageEmployees(employees []models.Employee) (string, error) {
+	batchID := uuid.New().String()
+	staged := make([]models.StagedEmployee, len(employees))
+	for i, employee := range employees {
+		staged[i] = models.NewStagedEmployee(batchID, employee)
+	}
+
+	var err error
+	s.withImportConnection(func() {
+		err = s.employeeService.repo.CreateStagedEmployees(staged)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to stage employees: %w", err)
+	}
+	return batchID, nil
+}
+
+// GetStagedEmployees returns one page of a staging batch for review
+func (s *EmployeeService) GetStagedEmployees(batchID string, limit, offset int) ([]models.StagedEmployee, int64, error) {
+	staged, total, err := s.repo.GetStagedEmployees(batchID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get staged employees: %w", err)
+	}
+	if total == 0 {
+		return nil, 0, ErrStagingBatchNotFound
+	}
+	return staged, total, nil
+}
+
+// PromoteStagingBatch moves a staging batch into employees. Duplicates of
+// existing employees are skipped and reported; the batch is gone afterwards.
+func (s *EmployeeService) PromoteStagingBatch(batchID string, actor events.Actor) (*models.PromoteResult, error) {
+	inserted, skipped, duplicateEmails, err := s.repo.PromoteStagedEmployees(batchID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStagingBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to promote staging batch: %w", err)
+	}
+
+	if inserted > 0 {
+		if err := s.cache.InvalidateEmployeeListCache(); err != nil {
+			s.invalidationFailed(err, "Failed to invalidate employee list cache after promoting batch %s", batchID)
+		}
+		s.touchLastModified()
+
+		event := events.New(events.EmployeesImported, actor)
+		event.Import = &events.ImportSummary{BatchID: batchID, Inserted: inserted, Skipped: skipped}
+		if err := s.publishEvent(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.PromoteResult{
+		BatchID:         batchID,
+		InsertedRecords: inserted,
+		SkippedRecords:  skipped,
+		DuplicateEmails: duplicateEmails,
+	}, nil
+}
+
+// DiscardStagingBatch deletes a staging batch without touching employees
+func (s *EmployeeService) DiscardStagingBatch(batchID string) error {
+	removed, err := s.repo.DeleteStagedEmployees(batchID)
+	if err != nil {
+		return fmt.Errorf("failed to discard staging batch: %w", err)
+	}
+	if removed == 0 {
+		return ErrStagingBatchNotFound
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestStagingImport_LoadReviewPromote(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+	repo.Seed(models.Employee{FirstName: "Existing", LastName: "Employee", Email: "jane@example.com"})
+
+	rows := [][]string{
+		{"first_name", "last_name", "email"},
+		{"John", "Doe", "john@example.com"},
+		{"Jane", "Roe", "jane@example.com"},
+		{"Bob", "Smith", "bob@example.com"},
+		{"Bad", "Row", "not-an-email"},
+	}
+	source := fileHeaderSource(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+
+	response, _, err := service.processExcelSource(source, ImportModeStaging)
+	if err != nil {
+		t.Fatalf("staging import failed: %v", err)
+	}
+	if response.BatchID == "" || response.InsertedRecords != 0 || response.InvalidRecords != 1 {
+		t.Fatalf("Expected a batch with nothing inserted and 1 invalid row, got %+v", response)
+	}
+	if repo.Count() != 1 {
+		t.Errorf("Expected staging to leave employees untouched, got %d rows", repo.Count())
+	}
+
+	employees := service.employeeService
+	staged, total, err := employees.GetStagedEmployees(response.BatchID, 2, 0)
+	if err != nil || total != 3 || len(staged) != 2 || staged[0].Email != "john@example.com" {
+		t.Fatalf("Expected first page of 3 staged rows in load order, got %+v total %d (err %v)", staged, total, err)
+	}
+
+	result, err := employees.PromoteStagingBatch(response.BatchID, events.Actor{})
+	if err != nil {
+		t.Fatalf("promote failed: %v", err)
+	}
+	if result.InsertedRecords != 2 || result.SkippedRecords != 1 || result.DuplicateEmails[0] != "jane@example.com" {
+		t.Errorf("Expected 2 inserted and jane@example.com skipped, got %+v", result)
+	}
+	if repo.Count() != 3 {
+		t.Errorf("Expected 3 employees after promote, got %d", repo.Count())
+	}
+
+	if _, err := employees.PromoteStagingBatch(response.BatchID, events.Actor{}); !errors.Is(err, ErrStagingBatchNotFound) {
+		t.Errorf("Expected a promoted batch to be gone, got %v", err)
+	}
+}
+
+func TestStagingImport_Discard(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+
+	rows := [][]string{{"first_name", "last_name", "email"}, {"John", "Doe", "john@example.com"}}
+	source := fileHeaderSource(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	response, _, err := service.processExcelSource(source, ImportModeStaging)
+	if err != nil {
+		t.Fatalf("staging import failed: %v", err)
+	}
+
+	employees := service.employeeService
+	if err := employees.DiscardStagingBatch(response.BatchID); err != nil {
+		t.Fatalf("discard failed: %v", err)
+	}
+	if _, _, err := employees.GetStagedEmployees(response.BatchID, 10, 0); !errors.Is(err, ErrStagingBatchNotFound) {
+		t.Errorf("Expected a discarded batch to be gone, got %v", err)
+	}
+	if err := employees.DiscardStagingBatch(response.BatchID); !errors.Is(err, ErrStagingBatchNotFound) {
+		t.Errorf("Expected discarding twice to report not found, got %v", err)
+	}
+	if repo.Count() != 0 {
+		t.Errorf("Expected no employees after discarding, got %d", repo.Count())
+	}
+}
+
+func TestParseImportMode(t *testing.T) {
+	for raw, expected := range map[string]ImportMode{"": ImportModeLive, "live": ImportModeLive, "staging": ImportModeStaging} {
+		if mode, err := ParseImportMode(raw); err != nil || mode != expected {
+			t.Errorf("ParseImportMode(%q) = %q, %v; want %q", raw, mode, err, expected)
+		}
+	}
+	if _, err := ParseImportMode("dry-run"); err == nil {
+		t.Error("Expected an unknown mode to be rejected")
+	}
+}
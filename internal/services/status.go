@@ -0,0 +1,137 @@
+package services
+
+import (
+	"employee-management/internal/models"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// StatusColumn is the optional import column that turns an import into a
+// sync: each row's value selects a StatusAction through IMPORT_STATUS_ACTIONS
+const StatusColumn = "status"
+
+// StatusAction is what a status column import does with a row
+type StatusAction string
+
+const (
+	StatusUpsert StatusAction = "upsert" // Insert the row, or update the employee with its email
+	StatusDelete StatusAction = "delete" // Delete the employee with the row's email
+	StatusSkip   StatusAction = "skip"   // Ignore the row
+)
+
+// resolveStatusActions parses IMPORT_STATUS_ACTIONS, a comma-separated list
+// of value=action pairs. Values are matched case-insensitively; invalid
+// entries are logged and ignored.
+func resolveStatusActions(spec string) map[string]StatusAction {
+	actions := make(map[string]StatusAction)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		value, action, ok := strings.Cut(entry, "=")
+		value = strings.ToLower(strings.TrimSpace(value))
+		action = strings.ToLower(strings.TrimSpace(action))
+		switch StatusAction(action) {
+		case StatusUpsert, StatusDelete, StatusSkip:
+		default:
+			ok = false
+		}
+		if !ok || value == "" {
+			log.Printf("Warning: ignoring IMPORT_STATUS_ACTIONS entry %q, expected value=upsert|delete|skip", entry)
+			continue
+		}
+		actions[value] = StatusAction(action)
+	}
+	return actions
+}
+
+// rowStatusAction returns the action for a row of a file with a status
+// column. A blank status upserts, like a file without the column; a value
+// missing from IMPORT_STATUS_ACTIONS is a row error.
+func (s *ExcelService) rowStatusAction(row []string, headerMap map[string]int, rowNumber int) (StatusAction, *models.ValidationError) {
+	var status string
+	if index := headerMap[StatusColumn]; index < len(row) {
+		status = strings.ToLower(strings.TrimSpace(stripBOM(row[index])))
+	}
+	if status == "" {
+		return StatusUpsert, nil
+	}
+	if action, ok := s.statusActions[status]; ok {
+		return action, nil
+	}
+
+	known := make([]string, 0, len(s.statusActions))
+	for value := range s.statusActions {
+		known = append(known, value)
+	}
+	sort.Strings(known)
+	return "", &models.ValidationError{
+		Field:   fmt.Sprintf("Row %d - %s", rowNumber, StatusColumn),
+		Message: fmt.Sprintf("unknown status %q (expected one of: %s)", status, strings.Join(known, ", ")),
+	}
+}
+
+// deleteRowEmail returns the email identifying the employee a delete row
+// removes. Other cells of a delete row are not validated.
+func deleteRowEmail(row []string, headerMap map[string]int, rowNumber int) (string, *models.ValidationError) {
+	if index, ok := headerMap["email"]; ok && index < len(row) {
+		if email := strings.TrimSpace(stripBOM(row[index])); email != "" {
+			return email, nil
+		}
+	}
+	return "", &models.ValidationError{
+		Field:   fmt.Sprintf("Row %d - email", rowNumber),
+		Message: "delete rows need the email of the employee to delete",
+	}
+}
+
+// syncEmployees applies a file with a status column: valid upsert rows are
+// inserted or update the employee with the same email in the columns the
+// file provides, and delete rows
+// remove theirs, all in one transaction. Invalid rows are left out as in any
+// live import.
+func (s *ExcelService) syncEmployees(sheet *parsedSheet, response *models.ExcelUploadResponse) error {
+	service := s.employeeService
+
+	var (
+		counts    *models.ImportActionCounts
+		conflicts []string
+		err       error
+	)
+	s.withImportConnection(func() {
+		counts, conflicts, err = service.repo.SyncEmployees(sheet.Employees, sheet.Columns, sheet.DeleteEmails)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync employees, nothing was changed: %w", err)
+	}
+	counts.Skipped = sheet.StatusSkipped
+
+	// Updates and deletes make cached employees stale, not only the lists
+	if err := service.cache.InvalidateEmployeeCache(); err != nil {
+		service.invalidationFailed(err, "Failed to invalidate employee cache after sync")
+	}
+	if err := service.cache.InvalidateEmployeeListCache(); err != nil {
+		service.invalidationFailed(err, "Failed to invalidate employee list cache after sync")
+	}
+	service.touchLastModified()
+
+	response.Actions = counts
+	response.InsertedRecords = counts.Inserted
+	response.SkippedRecords = counts.Conflicts
+	maxDuplicatesToShow := s.config.Import.MaxDuplicatesShown
+	if maxDuplicatesToShow <= 0 {
+		maxDuplicatesToShow = 10
+	}
+	if len(conflicts) > maxDuplicatesToShow {
+		response.DuplicateEmails = conflicts[:maxDuplicatesToShow]
+	} else if len(conflicts) > 0 {
+		response.DuplicateEmails = conflicts
+	}
+	response.Message = fmt.Sprintf("Synced %d records. Inserted: %d, Updated: %d, Unchanged: %d, Deleted: %d, Not found: %d, Skipped: %d, Conflicts: %d, Invalid: %d",
+		response.TotalRecords, counts.Inserted, counts.Updated, counts.Unchanged, counts.Deleted,
+		counts.NotFound, counts.Skipped, counts.Conflicts, response.InvalidRecords)
+	return nil
+}
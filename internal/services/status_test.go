@@ -0,0 +1,109 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatusImport_RoutesRowsByStatus(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{
+		Import: config.ImportConfig{StatusActions: "active=upsert,inactive=skip,delete=delete"},
+	})
+	repo.Seed(
+		models.Employee{FirstName: "Jane", LastName: "Roe", CompanyName: "Old Co", Email: "jane@example.com"},
+		models.Employee{FirstName: "Sam", LastName: "Same", Email: "sam@example.com"},
+		models.Employee{FirstName: "Gone", LastName: "Soon", Email: "gone@example.com"},
+		models.Employee{FirstName: "Idle", LastName: "Kept", Email: "idle@example.com"},
+	)
+
+	rows := [][]string{
+		{"first_name", "last_name", "company_name", "email", "status"},
+		{"John", "Doe", "", "john@example.com", "active"},
+		{"Jane", "Roe", "New Co", "jane@example.com", "Active"},
+		{"Sam", "Same", "", "sam@example.com", ""},
+		{"Idle", "Changed", "", "idle@example.com", "inactive"},
+		{"", "", "", "gone@example.com", "delete"},
+		{"", "", "", "nobody@example.com", "delete"},
+		{"Bad", "Status", "", "bad@example.com", "archived"},
+		{"No", "Email", "", "", "delete"},
+	}
+	source := fileHeaderSource(newFileHeader(t, "sync.xlsx", buildWorkbook(t, rows)))
+
+	response, _, err := service.processExcelSource(source, ImportModeLive)
+	if err != nil {
+		t.Fatalf("status import failed: %v", err)
+	}
+
+	want := models.ImportActionCounts{Inserted: 1, Updated: 1, Unchanged: 1, Deleted: 1, NotFound: 1, Skipped: 1}
+	if response.Actions == nil || *response.Actions != want {
+		t.Fatalf("Expected actions %+v, got %+v", want, response.Actions)
+	}
+	if response.TotalRecords != 8 || response.InvalidRecords != 2 || response.InsertedRecords != 1 {
+		t.Errorf("Expected 8 rows with 2 invalid and 1 inserted, got %+v", response)
+	}
+
+	if _, err := repo.GetEmployeeByEmail("john@example.com"); err != nil {
+		t.Error("Expected the active new row to be inserted")
+	}
+	if jane, _ := repo.GetEmployeeByEmail("jane@example.com"); jane == nil || jane.CompanyName != "New Co" {
+		t.Errorf("Expected the active existing row to update jane, got %+v", jane)
+	}
+	if idle, _ := repo.GetEmployeeByEmail("idle@example.com"); idle == nil || idle.LastName != "Kept" {
+		t.Errorf("Expected the inactive row to be skipped, got %+v", idle)
+	}
+	if _, err := repo.GetEmployeeByEmail("gone@example.com"); err == nil {
+		t.Error("Expected the delete row to remove gone@example.com")
+	}
+	if _, err := repo.GetEmployeeByEmail("bad@example.com"); err == nil {
+		t.Error("Expected the row with an unknown status to be rejected")
+	}
+}
+
+func TestStatusImport_UpsertKeepsAbsentColumns(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Import: config.ImportConfig{StatusActions: "active=upsert"}})
+	latitude, longitude := 51.5, -0.12
+	repo.Seed(models.Employee{FirstName: "Jane", LastName: "Roe", CompanyName: "Old Co", City: "London",
+		Email: "jane@example.com", Latitude: &latitude, Longitude: &longitude})
+
+	rows := [][]string{
+		{"first_name", "last_name", "company_name", "email", "status"},
+		{"Jane", "Roe", "New Co", "jane@example.com", "active"},
+	}
+	source := fileHeaderSource(newFileHeader(t, "sync.xlsx", buildWorkbook(t, rows)))
+	if _, _, err := service.processExcelSource(source, ImportModeLive); err != nil {
+		t.Fatalf("status import failed: %v", err)
+	}
+
+	jane, _ := repo.GetEmployeeByEmail("jane@example.com")
+	if jane == nil || jane.CompanyName != "New Co" {
+		t.Fatalf("Expected the upsert to update the company, got %+v", jane)
+	}
+	if jane.City != "London" || jane.Latitude == nil || *jane.Latitude != latitude || jane.Longitude == nil {
+		t.Errorf("Expected columns missing from the file to be kept, got %+v", jane)
+	}
+}
+
+func TestStatusImport_RequiresLiveMode(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Import: config.ImportConfig{StatusActions: "delete=delete"}})
+	repo.Seed(models.Employee{FirstName: "Gone", LastName: "Soon", Email: "gone@example.com"})
+
+	rows := [][]string{{"first_name", "last_name", "email", "status"}, {"", "", "gone@example.com", "delete"}}
+	source := fileHeaderSource(newFileHeader(t, "sync.xlsx", buildWorkbook(t, rows)))
+	if _, _, err := service.processExcelSource(source, ImportModeStaging); err == nil || !strings.Contains(err.Error(), "status") {
+		t.Errorf("Expected a staging import with a status column to be refused, got %v", err)
+	}
+	if repo.Count() != 1 {
+		t.Errorf("Expected nothing to be deleted, got %d employees", repo.Count())
+	}
+}
+
+func TestResolveStatusActions(t *testing.T) {
+	got := resolveStatusActions(" Current=upsert, gone = DELETE ,parked=skip,broken,retired=archive")
+	want := map[string]StatusAction{"current": StatusUpsert, "gone": StatusDelete, "parked": StatusSkip}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"log"
+	"strings"
+)
+
+// ImportTransform reshapes an imported employee before it is validated and
+// saved, e.g. to derive or default fields. Transforms run in order on every
+// parsed row, after the configured input corrections.
+type ImportTransform func(employee *models.Employee)
+
+// Names of the built-in transforms enabled through IMPORT_TRANSFORMS
+const (
+	TransformUppercasePostal = "uppercase_postal"
+	TransformDefaultCompany  = "default_company"
+)
+
+// builtinTransforms builds the named transforms from configuration
+var builtinTransforms = map[string]func(cfg *config.Config) ImportTransform{
+	TransformUppercasePostal: func(*config.Config) ImportTransform {
+		return func(employee *models.Employee) {
+			employee.Postal = strings.ToUpper(employee.Postal)
+		}
+	},
+	TransformDefaultCompany: func(cfg *config.Config) ImportTransform {
+		company := strings.TrimSpace(cfg.Import.DefaultCompany)
+		return func(employee *models.Employee) {
+			if employee.CompanyName == "" {
+				employee.CompanyName = company
+			}
+		}
+	},
+}
+
+// resolveImportTransforms turns the comma-separated IMPORT_TRANSFORMS list
+// into transforms in the listed order. Unknown names are logged and ignored.
+func resolveImportTransforms(cfg *config.Config) []ImportTransform {
+	var transforms []ImportTransform
+	for _, name := range strings.Split(cfg.Import.Transforms, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		build, ok := builtinTransforms[name]
+		if !ok {
+			log.Printf("Warning: unknown IMPORT_TRANSFORMS entry %q ignored", name)
+			continue
+		}
+		transforms = append(transforms, build(cfg))
+	}
+	return transforms
+}
+
+// AddImportTransform appends a custom transform that runs after the
+// configured ones. Register transforms at startup, before imports begin.
+func (s *ExcelService) AddImportTransform(transform ImportTransform) {
+	s.transforms = append(s.transforms, transform)
+}
+
+// applyTransforms runs every import transform on employee in order
+func (s *ExcelService) applyTransforms(employee *models.Employee) {
+	for _, transform := range s.transforms {
+		transform(employee)
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestImportTransforms(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{Import: config.ImportConfig{
+		Transforms:     "uppercase_postal, unknown, default_company",
+		DefaultCompany: "Acme",
+	}})
+	if len(service.transforms) != 2 {
+		t.Fatalf("Expected the two built-in transforms, got %d", len(service.transforms))
+	}
+
+	// Custom transforms run after the configured ones and see their output
+	service.AddImportTransform(func(employee *models.Employee) {
+		if employee.CompanyName == "Acme" && employee.County == "" {
+			employee.County = "Kent"
+		}
+	})
+
+	rows := [][]string{
+		{"first_name", "last_name", "email", "postal", "company_name"},
+		{"John", "Doe", "john@example.com", "sw1a 1aa", ""},
+		{"Jane", "Roe", "jane@example.com", "ec1a 1bb", "Globex"},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil || response.InsertedRecords != 2 {
+		t.Fatalf("import failed: %v %+v", err, response)
+	}
+
+	john, _ := repo.GetEmployeeByEmail("john@example.com")
+	if john.Postal != "SW1A 1AA" || john.CompanyName != "Acme" || john.County != "Kent" {
+		t.Errorf("Expected postal uppercased, company defaulted and county derived, got %+v", john)
+	}
+	jane, _ := repo.GetEmployeeByEmail("jane@example.com")
+	if jane.Postal != "EC1A 1BB" || jane.CompanyName != "Globex" || jane.County != "" {
+		t.Errorf("Expected only the postal to change for a row with a company, got %+v", jane)
+	}
+}
+
+func TestImportTransforms_BeforeValidation(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{})
+	service.AddImportTransform(func(employee *models.Employee) {
+		employee.Email = strings.ToLower(employee.FirstName) + "@example.com"
+	})
+
+	rows := [][]string{
+		{"first_name", "last_name", "email"},
+		{"John", "Doe", "not-an-email"},
+	}
+	response, err := service.ProcessExcelFile(newFileHeader(t, "employees.xlsx", buildWorkbook(t, rows)))
+	if err != nil || response.InsertedRecords != 1 {
+		t.Errorf("Expected the transformed row to pass validation, got %v %+v", err, response)
+	}
+}
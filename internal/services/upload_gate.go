@@ -0,0 +1,52 @@
+package services
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrTooManyUploads is returned when a client already has the maximum number
+// of imports in flight
+var ErrTooManyUploads = errors.New("too many uploads in progress from this client")
+
+// uploadGate caps how many imports each client may have queued or running at
+// once, so a single client cannot fill the job queue with large files
+type uploadGate struct {
+	mu       sync.Mutex
+	limit    int // 0 disables the gate
+	inFlight map[string]int
+}
+
+func newUploadGate(limit int) *uploadGate {
+	return &uploadGate{limit: limit, inFlight: make(map[string]int)}
+}
+
+// acquire reserves a slot for client, reporting false when it has none left
+func (g *uploadGate) acquire(client string) bool {
+	if g.limit <= 0 {
+		return true
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.inFlight[client] >= g.limit {
+		return false
+	}
+	g.inFlight[client]++
+	return true
+}
+
+// release frees a slot taken by acquire
+func (g *uploadGate) release(client string) {
+	if g.limit <= 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.inFlight[client] <= 1 {
+		delete(g.inFlight, client)
+		return
+	}
+	g.inFlight[client]--
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUploadGate_Concurrent(t *testing.T) {
+	gate := newUploadGate(2)
+
+	var admitted atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if gate.acquire("10.0.0.1") {
+				admitted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if admitted.Load() != 2 {
+		t.Fatalf("Expected exactly 2 of 20 concurrent uploads admitted, got %d", admitted.Load())
+	}
+	if !gate.acquire("10.0.0.2") {
+		t.Error("Expected another client to have its own slots")
+	}
+
+	gate.release("10.0.0.1")
+	if !gate.acquire("10.0.0.1") {
+		t.Error("Expected a released slot to be reusable")
+	}
+
+	unlimited := newUploadGate(0)
+	for i := 0; i < 5; i++ {
+		if !unlimited.acquire("10.0.0.1") {
+			t.Fatal("Expected no limit when MAX_UPLOADS_PER_IP is 0")
+		}
+	}
+}
+
+func TestEnqueueExcelJob_UploadsPerIP(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{MaxUploadsPerIP: 2}})
+	service.jobQueue = make(chan *JobRequest, 10) // No workers: jobs stay in flight until processed below
+
+	rows := [][]string{importHeaders, {"John", "Doe", "", "", "", "", "", "", "john@example.com", ""}}
+	content := buildWorkbook(t, rows)
+	enqueue := func(clientIP string) error {
+		source := fileHeaderSource(newFileHeader(t, "employees.xlsx", content))
+		_, err := service.enqueueExcelJob(source, ImportModeLive, events.Actor{IP: clientIP})
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := enqueue("10.0.0.1"); err != nil {
+			t.Fatalf("upload %d: %v", i+1, err)
+		}
+	}
+	if err := enqueue("10.0.0.1"); !errors.Is(err, ErrTooManyUploads) {
+		t.Fatalf("Expected the third upload to trip the gate, got %v", err)
+	}
+
+	// Finishing a job, even a failed one, frees its slot
+	service.processJobRequest(<-service.jobQueue)
+	if err := enqueue("10.0.0.1"); err != nil {
+		t.Errorf("Expected a slot after a job finished, got %v", err)
+	}
+}
+
+func TestDispatch_TimeoutReleasesUploadSlot(t *testing.T) {
+	service, _ := newTestExcelService(&config.Config{Import: config.ImportConfig{MaxUploadsPerIP: 1}})
+	service.jobQueue = make(chan *JobRequest, 10)
+	service.workerPool = make(chan chan *JobRequest) // No worker ever frees up
+	service.quit = make(chan bool)
+	service.workerWait = 10 * time.Millisecond
+	go service.dispatch()
+	defer close(service.quit)
+
+	content := buildWorkbook(t, [][]string{importHeaders, {"John", "Doe", "", "", "", "", "", "", "john@example.com", ""}})
+	source := fileHeaderSource(newFileHeader(t, "employees.xlsx", content))
+	jobID, err := service.enqueueExcelJob(source, ImportModeLive, events.Actor{IP: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("enqueueExcelJob failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		service.mu.RLock()
+		status := service.jobs[jobID].Status
+		service.mu.RUnlock()
+		if status == JobStatusFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the job to fail waiting for a worker, got %s", status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The abandoned job must not keep the client's only slot
+	if !service.uploadGate.acquire("10.0.0.1") {
+		t.Error("Expected the timed-out job to release its upload slot")
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"employee-management/internal/models"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+)
+
+// defaultMaxZipUncompressed applies when ZIP_MAX_UNCOMPRESSED_SIZE is not positive
+const defaultMaxZipUncompressed = 100 * 1024 * 1024
+
+// isZipUpload reports whether an upload is a ZIP archive of Excel files
+func isZipUpload(filename string) bool {
+	return strings.HasSuffix(strings.ToLower(filename), ".zip")
+}
+
+// isExcelFilename reports whether a name has an extension the Excel parser reads
+func isExcelFilename(filename string) bool {
+	filename = strings.ToLower(filename)
+	return strings.HasSuffix(filename, ".xlsx") || strings.HasSuffix(filename, ".xls")
+}
+
+// validateImportUpload checks an upload for the import endpoints, which take
+// a ZIP of Excel files as well as a single Excel file. The size limit applies
+// to the archive as uploaded.
+func (s *ExcelService) validateImportUpload(filename string, size int64) error {
+	if !isZipUpload(filename) {
+		return s.validateExcelUpload(filename, size)
+	}
+	if maxSize := s.config.Server.MaxFileSize; size > maxSize {
+		return fmt.Errorf("file size %d bytes exceeds maximum allowed size %d bytes", size, maxSize)
+	}
+	return nil
+}
+
+// parseZipContent parses every Excel file in a ZIP archive and merges the
+// results into one sheet, so the archive is imported like a single file. The
+// per-file summaries and warnings about skipped entries are returned with it.
+// An entry that fails to parse is reported in its summary and does not stop
+// the others; the archive fails only when no entry could be parsed or it
+// expands beyond ZIP_MAX_UNCOMPRESSED_SIZE.
+func (s *ExcelService) parseZipContent(content []byte) (*parsedSheet, []models.FileImportSummary, []string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid ZIP archive: %w", err)
+	}
+
+	budget := s.config.Import.MaxZipUncompressed
+	if budget <= 0 {
+		budget = defaultMaxZipUncompressed
+	}
+	remaining := budget
+
+	merged := &parsedSheet{}
+	var files []models.FileImportSummary
+	var warnings []string
+	parsed := 0
+
+	for _, entry := range archive.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		name := entry.Name
+		if !isExcelFilename(name) || isHiddenEntry(name) {
+			warnings = append(warnings, fmt.Sprintf("skipped %s: not an Excel file", name))
+			continue
+		}
+
+		data, err := readZipEntry(entry, remaining)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s: %w (limit %d bytes)", name, err, budget)
+		}
+		remaining -= int64(len(data))
+
+		summary := models.FileImportSummary{Filename: name}
+		sheet, err := s.parseExcelContent(data, name)
+		if err != nil {
+			summary.Error = err.Error()
+			files = append(files, summary)
+			warnings = append(warnings, fmt.Sprintf("skipped %s: %v", name, err))
+			continue
+		}
+		parsed++
+
+		summary.TotalRecords = sheet.validRows() + sheet.InvalidRows
+		summary.ValidRecords = sheet.validRows()
+		summary.InvalidRecords = sheet.InvalidRows
+		files = append(files, summary)
+		merged.merge(sheet, name, s.maxValidationErrors())
+	}
+
+	if parsed == 0 {
+		return nil, files, warnings, fmt.Errorf("archive contains no importable Excel files")
+	}
+	log.Printf("Parsed ZIP archive: %d Excel files, %d valid employees, %d invalid rows",
+		parsed, len(merged.Employees), merged.InvalidRows)
+	return merged, files, warnings, nil
+}
+
+// isHiddenEntry reports archive metadata such as __MACOSX/ folders and
+// dot-files, which can carry an Excel extension without being a workbook
+func isHiddenEntry(name string) bool {
+	return strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), ".")
+}
+
+// readZipEntry decompresses one entry, failing once it would use more than
+// limit bytes. The declared size is checked first but not trusted: the
+// reader is capped as well, so a forged header cannot get past the limit.
+func readZipEntry(entry *zip.File, limit int64) ([]byte, error) {
+	if entry.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("archive expands beyond the decompressed size limit")
+	}
+
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open entry: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entry: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("archive expands beyond the decompressed size limit")
+	}
+	return data, nil
+}
+
+// merge adds one file's results to an archive's. Row references are prefixed
+// with the file name, and detailed errors and warnings stay within the cap.
+func (p *parsedSheet) merge(sheet *parsedSheet, filename string, maxErrors int) {
+	p.Employees = append(p.Employees, sheet.Employees...)
+	p.InvalidRows += sheet.InvalidRows
+	p.HasStatus = p.HasStatus || sheet.HasStatus
+	p.DeleteEmails = append(p.DeleteEmails, sheet.DeleteEmails...)
+	p.StatusSkipped += sheet.StatusSkipped
+	p.Truncated = p.Truncated || sheet.Truncated
+
+	for _, problem := range sheet.Errors {
+		if len(p.Errors) >= maxErrors {
+			p.Truncated = true
+			break
+		}
+		p.Errors = append(p.Errors, models.ValidationError{Field: filename + ": " + problem.Field, Message: problem.Message})
+	}
+	for _, failed := range sheet.FailedRows {
+		if len(p.FailedRows) >= maxErrors {
+			break
+		}
+		failed.Errors = filename + ": " + failed.Errors
+		p.FailedRows = append(p.FailedRows, failed)
+	}
+	for _, problem := range sheet.Warnings {
+		if len(p.Warnings) >= maxErrors {
+			break
+		}
+		p.Warnings = append(p.Warnings, models.ValidationError{Field: filename + ": " + problem.Field, Message: problem.Message})
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"employee-management/internal/config"
+	"strings"
+	"testing"
+)
+
+// buildZip packs the given entries (name -> content) into an in-memory archive
+func buildZip(t *testing.T, entries map[string][]byte, order ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, name := range order {
+		part, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", name, err)
+		}
+		part.Write(entries[name])
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessExcelFile_Zip(t *testing.T) {
+	service, repo := newTestExcelService(&config.Config{})
+
+	entries := map[string][]byte{
+		"north.xlsx": buildWorkbook(t, [][]string{
+			{"first_name", "last_name", "email"},
+			{"John", "Doe", "john@example.com"},
+			{"Jane", "Roe", "invalid"},
+		}),
+		"south/south.xlsx": buildWorkbook(t, [][]string{
+			{"first_name", "last_name", "email"},
+			{"Ann", "Lee", "ann@example.com"},
+			{"Bob", "Ray", "bob@example.com"},
+		}),
+		"readme.txt":     []byte("monthly export"),
+		"broken.xlsx":    []byte("not a workbook"),
+		"south/":         nil,
+		"._north.xlsx":   []byte("resource fork"),
+		"__MACOSX/x.xls": []byte("resource fork"),
+	}
+	archive := buildZip(t, entries, "north.xlsx", "readme.txt", "south/", "south/south.xlsx", "broken.xlsx", "._north.xlsx", "__MACOSX/x.xls")
+
+	response, failedRows, err := service.processExcelSource(fileHeaderSource(newFileHeader(t, "monthly.zip", archive)), ImportModeLive)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	if response.TotalRecords != 4 || response.InsertedRecords != 3 || response.InvalidRecords != 1 || repo.Count() != 3 {
+		t.Errorf("Expected rows from both workbooks aggregated, got %+v", response)
+	}
+	if len(response.Files) != 3 {
+		t.Fatalf("Expected a summary per Excel entry, got %+v", response.Files)
+	}
+	north, south, broken := response.Files[0], response.Files[1], response.Files[2]
+	if north.Filename != "north.xlsx" || north.ValidRecords != 1 || north.InvalidRecords != 1 {
+		t.Errorf("Unexpected north summary: %+v", north)
+	}
+	if south.Filename != "south/south.xlsx" || south.ValidRecords != 2 || south.InvalidRecords != 0 {
+		t.Errorf("Unexpected south summary: %+v", south)
+	}
+	if broken.Error == "" {
+		t.Errorf("Expected the unparseable entry to carry an error, got %+v", broken)
+	}
+
+	skipped := strings.Join(response.Warnings, "\n")
+	for _, name := range []string{"readme.txt", "broken.xlsx", "._north.xlsx", "__MACOSX/x.xls"} {
+		if !strings.Contains(skipped, "skipped "+name) {
+			t.Errorf("Expected a warning for %s, got %v", name, response.Warnings)
+		}
+	}
+	if len(failedRows) != 1 || !strings.HasPrefix(failedRows[0].Errors, "north.xlsx: ") {
+		t.Errorf("Expected the invalid row tagged with its file, got %+v", failedRows)
+	}
+}
+
+func TestProcessExcelFile_ZipLimits(t *testing.T) {
+	workbook := buildWorkbook(t, [][]string{{"first_name", "last_name", "email"}, {"John", "Doe", "john@example.com"}})
+
+	// The budget fits three copies of the workbook, not four
+	service, repo := newTestExcelService(&config.Config{Import: config.ImportConfig{MaxZipUncompressed: int64(len(workbook)) * 3}})
+	entries := map[string][]byte{"a.xlsx": workbook, "b.xlsx": workbook, "c.xlsx": workbook, "d.xlsx": workbook}
+
+	archive := buildZip(t, entries, "a.xlsx", "b.xlsx", "c.xlsx", "d.xlsx")
+	_, err := service.ProcessExcelFile(newFileHeader(t, "bomb.zip", archive))
+	if err == nil || !strings.Contains(err.Error(), "decompressed size limit") {
+		t.Errorf("Expected the decompressed size cap to reject the archive, got %v", err)
+	}
+	if repo.Count() != 0 {
+		t.Errorf("Expected nothing imported from a rejected archive, got %d rows", repo.Count())
+	}
+
+	archive = buildZip(t, map[string][]byte{"notes.txt": []byte("x")}, "notes.txt")
+	if _, err := service.ProcessExcelFile(newFileHeader(t, "empty.zip", archive)); err == nil {
+		t.Error("Expected an archive without Excel files to fail")
+	}
+
+	if _, err := service.ProcessExcelFile(newFileHeader(t, "fake.zip", []byte("not a zip"))); err == nil {
+		t.Error("Expected a corrupt archive to fail")
+	}
+}
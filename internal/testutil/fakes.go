@@ -0,0 +1,934 @@
+package testutil
+
+import (
+	"employee-management/internal/database"
+	"employee-management/internal/models"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Compile-time checks that the fakes satisfy the real interfaces
+var (
+	_ database.Repository     = (*FakeRepository)(nil)
+	_ database.CacheInterface = (*FakeCache)(nil)
+)
+
+// FakeRepository is an in-memory implementation of database.Repository for tests
+type FakeRepository struct {
+	mu        sync.Mutex
+	employees map[int]models.Employee
+	nextID    int
+	staged    []models.StagedEmployee
+	stagedID  int
+
+	// Call counters so tests can assert on database traffic
+	CreateCalls int
+	UpdateCalls int
+	DeleteCalls int
+	ListCalls   int
+	StatsCalls  int
+	CountCalls  int
+
+	// CreateErr, when set, is returned by CreateEmployee instead of inserting
+	CreateErr error
+
+	// ReplaceErr, when set, makes ReplaceEmployees fail after loading the new
+	// rows and restore the old ones, like a rolled back transaction
+	ReplaceErr error
+
+	// UniquePhone mimics the UNIQUE_PHONE index: non-empty phones must be unique
+	UniquePhone bool
+}
+
+// NewFakeRepository creates an empty fake repository
+func NewFakeRepository() *FakeRepository {
+	return &FakeRepository{
+		employees: make(map[int]models.Employee),
+		nextID:    1,
+	}
+}
+
+// Seed inserts employees directly, assigning IDs where missing
+func (r *FakeRepository) Seed(employees ...models.Employee) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, employee := range employees {
+		if employee.ID == 0 {
+			employee.ID = r.nextID
+		}
+		if employee.ID >= r.nextID {
+			r.nextID = employee.ID + 1
+		}
+		r.employees[employee.ID] = employee
+	}
+}
+
+// Count returns the number of stored employees
+func (r *FakeRepository) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.employees)
+}
+
+// CreateEmployee stores a new employee, rejecting duplicate emails like the unique index
+func (r *FakeRepository) CreateEmployee(employee *models.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.CreateCalls++
+	if r.CreateErr != nil {
+		return r.CreateErr
+	}
+	return r.insert(employee)
+}
+
+func (r *FakeRepository) insert(employee *models.Employee) error {
+	for _, existing := range r.employees {
+		if existing.Email == employee.Email {
+			return fmt.Errorf("Error 1062: Duplicate entry '%s' for key 'employees.email'", employee.Email)
+		}
+	}
+	if err := r.checkPhone(employee); err != nil {
+		return err
+	}
+	// Like MySQL, an explicit ID is inserted as given and moves the counter past it
+	if employee.ID == 0 {
+		employee.ID = r.nextID
+	} else if _, exists := r.employees[employee.ID]; exists {
+		return fmt.Errorf("Error 1062: Duplicate entry '%d' for key 'employees.PRIMARY'", employee.ID)
+	}
+	if employee.ID >= r.nextID {
+		r.nextID = employee.ID + 1
+	}
+	r.employees[employee.ID] = *employee
+	return nil
+}
+
+// GetEmployeeByID returns the employee with the given ID
+func (r *FakeRepository) GetEmployeeByID(id int) (*models.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	employee, ok := r.employees[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &employee, nil
+}
+
+// GetEmployeeByEmail returns the employee with the given email
+func (r *FakeRepository) GetEmployeeByEmail(email string) (*models.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, employee := range r.employees {
+		if employee.Email == email {
+			return &employee, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// GetEmployeeByPhone returns the employee with the given phone
+func (r *FakeRepository) GetEmployeeByPhone(phone string) (*models.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matches := r.sorted(func(e models.Employee) bool { return e.Phone == phone })
+	if len(matches) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &matches[0], nil
+}
+
+// checkPhone enforces UniquePhone with the error MySQL reports for the index
+func (r *FakeRepository) checkPhone(employee *models.Employee) error {
+	if !r.UniquePhone || employee.Phone == "" {
+		return nil
+	}
+	for _, existing := range r.employees {
+		if existing.ID != employee.ID && existing.Phone == employee.Phone {
+			return fmt.Errorf("Error 1062: Duplicate entry '%s' for key 'employees.idx_employees_phone_unique'", employee.Phone)
+		}
+	}
+	return nil
+}
+
+// GetAllEmployees returns a page of employees in the given order
+func (r *FakeRepository) GetAllEmployees(limit, offset int, order models.SortOptions) ([]models.Employee, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ListCalls++
+	all := orderBy(r.sorted(func(models.Employee) bool { return true }), order)
+	return paginate(all, limit, offset), int64(len(all)), nil
+}
+
+// GetEmployeesAfterID returns up to limit employees above cursorID in ID order
+func (r *FakeRepository) GetEmployeesAfterID(cursorID, limit int) ([]models.Employee, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	employees := r.sorted(func(employee models.Employee) bool { return employee.ID > cursorID })
+	if len(employees) > limit {
+		return employees[:limit], true, nil
+	}
+	return employees, false, nil
+}
+
+// GetNextEmployee returns the employee with the lowest ID above afterID
+func (r *FakeRepository) GetNextEmployee(afterID int) (*models.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var next *models.Employee
+	for id, employee := range r.employees {
+		if id > afterID && (next == nil || id < next.ID) {
+			employee := employee
+			next = &employee
+		}
+	}
+	if next == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return next, nil
+}
+
+// UpdateEmployee replaces a stored employee
+func (r *FakeRepository) UpdateEmployee(employee *models.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.UpdateCalls++
+	if _, ok := r.employees[employee.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	for _, existing := range r.employees {
+		if existing.ID != employee.ID && existing.Email == employee.Email {
+			return fmt.Errorf("Error 1062: Duplicate entry '%s' for key 'employees.email'", employee.Email)
+		}
+	}
+	if err := r.checkPhone(employee); err != nil {
+		return err
+	}
+	// Like GORM's autoUpdateTime, saving stamps updated_at on the caller's struct
+	employee.UpdatedAt = time.Now()
+	r.employees[employee.ID] = *employee
+	return nil
+}
+
+// DeleteEmployee removes an employee by ID
+func (r *FakeRepository) DeleteEmployee(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.DeleteCalls++
+	delete(r.employees, id)
+	return nil
+}
+
+// GetEmployeesByIDs returns the stored employees among ids, in ID order
+func (r *FakeRepository) GetEmployeesByIDs(ids []int) ([]models.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return r.sorted(func(employee models.Employee) bool { return wanted[employee.ID] }), nil
+}
+
+// DeleteEmployeesInBatch removes the employees with the given IDs and returns
+// how many existed
+func (r *FakeRepository) DeleteEmployeesInBatch(ids []int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.DeleteCalls++
+	deleted := 0
+	for _, id := range ids {
+		if _, ok := r.employees[id]; ok {
+			delete(r.employees, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CreateEmployeesInBatch inserts employees, silently skipping duplicates
+func (r *FakeRepository) CreateEmployeesInBatch(employees []models.Employee) error {
+	_, _, _, err := r.CreateEmployeesInBatchWithResult(employees)
+	return err
+}
+
+// CreateEmployeesInBatchWithResult inserts employees and reports duplicates
+func (r *FakeRepository) CreateEmployeesInBatchWithResult(employees []models.Employee) (int, int, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var inserted, skipped int
+	var duplicateEmails []string
+	for _, employee := range employees {
+		if err := r.insert(&employee); err != nil {
+			skipped++
+			duplicateEmails = append(duplicateEmails, employee.Email)
+			continue
+		}
+		inserted++
+	}
+	return inserted, skipped, duplicateEmails, nil
+}
+
+// CreateStagedEmployees appends rows to the staging table
+func (r *FakeRepository) CreateStagedEmployees(staged []models.StagedEmployee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, row := range staged {
+		r.stagedID++
+		row.ID = r.stagedID
+		r.staged = append(r.staged, row)
+	}
+	return nil
+}
+
+// GetStagedEmployees returns one page of a staging batch in load order
+func (r *FakeRepository) GetStagedEmployees(batchID string, limit, offset int) ([]models.StagedEmployee, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch := r.stagedBatch(batchID)
+	total := int64(len(batch))
+	if limit == 0 || offset >= len(batch) {
+		return []models.StagedEmployee{}, total, nil
+	}
+	end := offset + limit
+	if end > len(batch) {
+		end = len(batch)
+	}
+	return batch[offset:end], total, nil
+}
+
+// PromoteStagedEmployees inserts a staging batch, skipping duplicates, and removes it
+func (r *FakeRepository) PromoteStagedEmployees(batchID string) (int, int, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch := r.stagedBatch(batchID)
+	if len(batch) == 0 {
+		return 0, 0, nil, gorm.ErrRecordNotFound
+	}
+
+	var inserted, skipped int
+	var duplicateEmails []string
+	for _, row := range batch {
+		employee := row.ToEmployee()
+		if err := r.insert(&employee); err != nil {
+			skipped++
+			duplicateEmails = append(duplicateEmails, employee.Email)
+			continue
+		}
+		inserted++
+	}
+	r.removeStaged(batchID)
+	return inserted, skipped, duplicateEmails, nil
+}
+
+// ReplaceEmployees swaps every employee for the given ones
+func (r *FakeRepository) ReplaceEmployees(employees []models.Employee) (int64, int, int, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, previousID := r.employees, r.nextID
+	removed := int64(len(r.employees))
+	r.employees = make(map[int]models.Employee)
+
+	var inserted, skipped int
+	var duplicateEmails []string
+	for _, employee := range employees {
+		if err := r.insert(&employee); err != nil {
+			skipped++
+			duplicateEmails = append(duplicateEmails, employee.Email)
+			continue
+		}
+		inserted++
+	}
+
+	if r.ReplaceErr != nil {
+		r.employees, r.nextID = previous, previousID
+		return 0, 0, 0, nil, r.ReplaceErr
+	}
+	return removed, inserted, skipped, duplicateEmails, nil
+}
+
+// SyncEmployees upserts by email, updating only columns of an existing
+// employee, and deletes the given emails. Unlike the real repository it does
+// not roll back a failed sync.
+func (r *FakeRepository) SyncEmployees(upserts []models.Employee, columns []string, deleteEmails []string) (*models.ImportActionCounts, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := &models.ImportActionCounts{}
+	var conflicts []string
+	for _, employee := range upserts {
+		existing, found := r.byEmail(employee.Email)
+		var err error
+		if !found {
+			if err = r.insert(&employee); err == nil {
+				counts.Inserted++
+			}
+		} else {
+			updated := existing
+			for _, column := range columns {
+				switch column {
+				case "latitude":
+					updated.Latitude = employee.Latitude
+				case "longitude":
+					updated.Longitude = employee.Longitude
+				default:
+					updated.SetColumnValue(column, employee.ColumnValue(column))
+				}
+			}
+			if reflect.DeepEqual(updated, existing) {
+				counts.Unchanged++
+			} else if err = r.checkPhone(&updated); err == nil {
+				updated.UpdatedAt = time.Now()
+				r.employees[updated.ID] = updated
+				counts.Updated++
+			}
+		}
+		if err != nil {
+			counts.Conflicts++
+			conflicts = append(conflicts, employee.Email)
+		}
+	}
+
+	for _, email := range deleteEmails {
+		existing, found := r.byEmail(email)
+		if !found {
+			counts.NotFound++
+			continue
+		}
+		delete(r.employees, existing.ID)
+		counts.Deleted++
+	}
+	return counts, conflicts, nil
+}
+
+// byEmail finds an employee by email, ignoring case like MySQL
+func (r *FakeRepository) byEmail(email string) (models.Employee, bool) {
+	for _, employee := range r.employees {
+		if strings.EqualFold(employee.Email, email) {
+			return employee, true
+		}
+	}
+	return models.Employee{}, false
+}
+
+// DeleteStagedEmployees discards a staging batch
+func (r *FakeRepository) DeleteStagedEmployees(batchID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return int64(r.removeStaged(batchID)), nil
+}
+
+// stagedBatch returns the staged rows of a batch; callers hold r.mu
+func (r *FakeRepository) stagedBatch(batchID string) []models.StagedEmployee {
+	var batch []models.StagedEmployee
+	for _, row := range r.staged {
+		if row.BatchID == batchID {
+			batch = append(batch, row)
+		}
+	}
+	return batch
+}
+
+// removeStaged drops the rows of a batch and returns how many there were; callers hold r.mu
+func (r *FakeRepository) removeStaged(batchID string) int {
+	kept := r.staged[:0]
+	for _, row := range r.staged {
+		if row.BatchID != batchID {
+			kept = append(kept, row)
+		}
+	}
+	removed := len(r.staged) - len(kept)
+	r.staged = kept
+	return removed
+}
+
+// SearchEmployees matches the query against name, email and company
+func (r *FakeRepository) SearchEmployees(query string, limit, offset int, order models.SortOptions) ([]models.Employee, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ListCalls++
+	matches := orderBy(r.sorted(matchesSearch(query)), order)
+	return paginate(matches, limit, offset), int64(len(matches)), nil
+}
+
+// CountEmployees returns how many employees match the query (all when empty)
+func (r *FakeRepository) CountEmployees(query string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.CountCalls++
+	keep := func(models.Employee) bool { return true }
+	if query != "" {
+		keep = matchesSearch(query)
+	}
+	return int64(len(r.sorted(keep))), nil
+}
+
+// ListEmployeesExcluding matches the query like SearchEmployees, leaving out excludeIDs
+func (r *FakeRepository) ListEmployeesExcluding(query string, excludeIDs []int, limit, offset int, order models.SortOptions) ([]models.Employee, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ListCalls++
+	excluded := make(map[int]bool, len(excludeIDs))
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+	search := func(models.Employee) bool { return true }
+	if query != "" {
+		search = matchesSearch(query)
+	}
+	matches := orderBy(r.sorted(func(employee models.Employee) bool { return !excluded[employee.ID] && search(employee) }), order)
+	if limit <= 0 {
+		return []models.Employee{}, int64(len(matches)), nil
+	}
+	return paginate(matches, limit, offset), int64(len(matches)), nil
+}
+
+// FilterEmployees matches every filter literally as a substring of its
+// column, combined with the query like SearchEmployees and leaving out excludeIDs
+func (r *FakeRepository) FilterEmployees(filters map[string]string, query string, excludeIDs []int, limit, offset int, order models.SortOptions) ([]models.Employee, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ListCalls++
+	patterns := make(map[string]*regexp.Regexp, len(filters))
+	for column, value := range filters {
+		if err := models.ValidateFilterField(column); err != nil {
+			return nil, 0, err
+		}
+		patterns[column] = likePattern(database.EscapeLike(value))
+	}
+	excluded := make(map[int]bool, len(excludeIDs))
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+	search := func(models.Employee) bool { return true }
+	if query != "" {
+		search = matchesSearch(query)
+	}
+	matches := orderBy(r.sorted(func(employee models.Employee) bool {
+		if excluded[employee.ID] {
+			return false
+		}
+		for column, pattern := range patterns {
+			if !pattern.MatchString(employee.ColumnValue(column)) {
+				return false
+			}
+		}
+		return search(employee)
+	}), order)
+	if limit <= 0 {
+		return []models.Employee{}, int64(len(matches)), nil
+	}
+	return paginate(matches, limit, offset), int64(len(matches)), nil
+}
+
+// ExplainSearch returns a canned single-row plan describing the query
+func (r *FakeRepository) ExplainSearch(query string, excludeIDs []int, limit, offset int, order models.SortOptions) ([]map[string]interface{}, error) {
+	extra := ""
+	if query != "" || len(excludeIDs) > 0 {
+		extra = "Using where"
+	}
+	return []map[string]interface{}{{
+		"id":    1,
+		"table": "employees",
+		"type":  "ALL",
+		"rows":  limit + offset,
+		"Extra": extra,
+	}}, nil
+}
+
+// StreamEmployees calls fn for every employee matching the query in ID order
+func (r *FakeRepository) StreamEmployees(query string, fn func(employee *models.Employee) error) error {
+	r.mu.Lock()
+	matches := r.sorted(matchesSearch(query))
+	r.mu.Unlock()
+
+	for i := range matches {
+		if err := fn(&matches[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetEmployeeStats computes the same aggregates as the SQL implementation
+func (r *FakeRepository) GetEmployeeStats(topCompanies int) (*models.EmployeeStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.StatsCalls++
+	stats := &models.EmployeeStats{TopCompanies: []models.CompanyCount{}}
+	companies := make(map[string]int64)
+	for _, employee := range r.employees {
+		stats.TotalEmployees++
+		if employee.Email != "" {
+			stats.WithEmail++
+		}
+		if employee.CompanyName != "" {
+			companies[employee.CompanyName]++
+		}
+		createdAt := employee.CreatedAt
+		if stats.NewestCreatedAt == nil || createdAt.After(*stats.NewestCreatedAt) {
+			stats.NewestCreatedAt = &createdAt
+		}
+		if stats.OldestCreatedAt == nil || createdAt.Before(*stats.OldestCreatedAt) {
+			stats.OldestCreatedAt = &createdAt
+		}
+	}
+	stats.WithoutEmail = stats.TotalEmployees - stats.WithEmail
+
+	for name, count := range companies {
+		stats.TopCompanies = append(stats.TopCompanies, models.CompanyCount{CompanyName: name, Count: count})
+	}
+	sort.Slice(stats.TopCompanies, func(i, j int) bool {
+		a, b := stats.TopCompanies[i], stats.TopCompanies[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.CompanyName < b.CompanyName
+	})
+	if len(stats.TopCompanies) > topCompanies {
+		stats.TopCompanies = stats.TopCompanies[:topCompanies]
+	}
+
+	return stats, nil
+}
+
+// matchesSearch mirrors the repository's case-insensitive LIKE search across
+// the searchable columns, including the % and _ wildcards and \ escapes
+func matchesSearch(query string) func(models.Employee) bool {
+	pattern := likePattern(query)
+	return func(e models.Employee) bool {
+		for _, column := range models.SearchableEmployeeColumns() {
+			if pattern.MatchString(e.ColumnValue(column)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// likePattern compiles the LIKE pattern %query% into a regular expression
+func likePattern(query string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("(?is)^.*")
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			expr.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(runes[i])))
+		}
+	}
+	expr.WriteString(".*$")
+	return regexp.MustCompile(expr.String())
+}
+
+func (r *FakeRepository) sorted(keep func(models.Employee) bool) []models.Employee {
+	var result []models.Employee
+	for _, employee := range r.employees {
+		if keep(employee) {
+			result = append(result, employee)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// orderBy reorders employees already in ID order like SortOptions.OrderClause
+func orderBy(employees []models.Employee, order models.SortOptions) []models.Employee {
+	if order == (models.SortOptions{}) {
+		return employees
+	}
+	key := func(employee *models.Employee) string {
+		switch order.Field {
+		case "":
+			return ""
+		case models.SortCreatedAt:
+			return employee.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000000")
+		}
+		if order.CaseInsensitive {
+			return strings.ToLower(employee.ColumnValue(order.Field))
+		}
+		return employee.ColumnValue(order.Field)
+	}
+	sort.SliceStable(employees, func(i, j int) bool {
+		a, b := key(&employees[i]), key(&employees[j])
+		if a == b {
+			return (employees[i].ID < employees[j].ID) != order.Desc
+		}
+		return (a < b) != order.Desc
+	})
+	return employees
+}
+
+func paginate(employees []models.Employee, limit, offset int) []models.Employee {
+	if offset >= len(employees) {
+		return []models.Employee{}
+	}
+	end := offset + limit
+	if end > len(employees) {
+		end = len(employees)
+	}
+	return employees[offset:end]
+}
+
+// FakeCache is an in-memory implementation of database.CacheInterface for tests
+type FakeCache struct {
+	mu        sync.Mutex
+	employees map[int]models.Employee
+	lists     map[string]fakeList
+	listTTLs  map[string]time.Duration
+	stats     *models.EmployeeStats
+	modified  time.Time
+
+	// SetErr, when set, is returned by every write operation
+	SetErr error
+	// HealthErr, when set, is returned by Health and GetCacheStats
+	HealthErr error
+}
+
+type fakeList struct {
+	employees []models.Employee
+	total     int64
+}
+
+// NewFakeCache creates an empty fake cache
+func NewFakeCache() *FakeCache {
+	return &FakeCache{
+		employees: make(map[int]models.Employee),
+		lists:     make(map[string]fakeList),
+		listTTLs:  make(map[string]time.Duration),
+	}
+}
+
+// SetEmployee caches a single employee
+func (c *FakeCache) SetEmployee(employee *models.Employee) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.SetErr != nil {
+		return c.SetErr
+	}
+	c.employees[employee.ID] = *employee
+	return nil
+}
+
+// GetEmployee returns a cached employee or nil on a miss
+func (c *FakeCache) GetEmployee(id int) (*models.Employee, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	employee, ok := c.employees[id]
+	if !ok {
+		return nil, nil
+	}
+	return &employee, nil
+}
+
+// DeleteEmployee removes a cached employee
+func (c *FakeCache) DeleteEmployee(id int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.employees, id)
+	return nil
+}
+
+// SetEmployeeList caches a list page
+func (c *FakeCache) SetEmployeeList(key string, employees []models.Employee, total int64, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.SetErr != nil {
+		return c.SetErr
+	}
+	c.lists[key] = fakeList{employees: employees, total: total}
+	c.listTTLs[key] = ttl
+	return nil
+}
+
+// ListTTLs returns the TTL each cached list page was written with, by key
+func (c *FakeCache) ListTTLs() map[string]time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ttls := make(map[string]time.Duration, len(c.listTTLs))
+	for key, ttl := range c.listTTLs {
+		ttls[key] = ttl
+	}
+	return ttls
+}
+
+// GetEmployeeList returns a cached list page or nil on a miss
+func (c *FakeCache) GetEmployeeList(key string) ([]models.Employee, int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	list, ok := c.lists[key]
+	if !ok {
+		return nil, 0, nil
+	}
+	return list.employees, list.total, nil
+}
+
+// SetEmployeeStats caches aggregate stats
+func (c *FakeCache) SetEmployeeStats(stats *models.EmployeeStats) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.SetErr != nil {
+		return c.SetErr
+	}
+	c.stats = stats
+	return nil
+}
+
+// GetEmployeeStats returns cached stats or nil on a miss
+func (c *FakeCache) GetEmployeeStats() (*models.EmployeeStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats, nil
+}
+
+// SetLastModified records the latest write time
+func (c *FakeCache) SetLastModified(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.SetErr != nil {
+		return c.SetErr
+	}
+	c.modified = t
+	return nil
+}
+
+// GetLastModified returns the recorded write time or zero on a miss
+func (c *FakeCache) GetLastModified() (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.modified, nil
+}
+
+// Inspect returns the entry stored under a full cache key (employee:<id>,
+// employee_list:stats or employee_list:<list key>) with the TTL it was written with
+func (c *FakeCache) Inspect(key string) (*database.CacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var value interface{}
+	var ttl time.Duration
+	if idText, ok := strings.CutPrefix(key, "employee:"); ok {
+		id, err := strconv.Atoi(idText)
+		if err != nil {
+			return nil, nil
+		}
+		employee, found := c.employees[id]
+		if !found {
+			return nil, nil
+		}
+		value = employee
+	} else if listKey, ok := strings.CutPrefix(key, "employee_list:"); ok {
+		if listKey == "stats" && c.stats != nil {
+			value = c.stats
+		} else if list, found := c.lists[listKey]; found {
+			value = database.EmployeeListData{Employees: list.employees, Total: list.total}
+			ttl = c.listTTLs[listKey]
+		} else {
+			return nil, nil
+		}
+	} else {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return &database.CacheEntry{Key: key, Value: data, TTL: ttl}, nil
+}
+
+// InvalidateEmployeeCache clears all cached employees
+func (c *FakeCache) InvalidateEmployeeCache() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.employees = make(map[int]models.Employee)
+	return nil
+}
+
+// InvalidateEmployeeListCache clears all cached lists
+func (c *FakeCache) InvalidateEmployeeListCache() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lists = make(map[string]fakeList)
+	c.listTTLs = make(map[string]time.Duration)
+	c.stats = nil
+	return nil
+}
+
+// Health returns HealthErr
+func (c *FakeCache) Health() error {
+	return c.HealthErr
+}
+
+// GetCacheStats counts the cached employees and lists
+func (c *FakeCache) GetCacheStats() (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.HealthErr != nil {
+		return nil, c.HealthErr
+	}
+	return map[string]interface{}{
+		"backend":               "fake",
+		"cached_employees":      len(c.employees),
+		"cached_employee_lists": len(c.lists),
+		"cache_expiry_minutes":  0.0,
+	}, nil
+}
+
+// Close is a no-op
+func (c *FakeCache) Close() error {
+	return nil
+}